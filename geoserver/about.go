@@ -0,0 +1,374 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+const (
+	// aboutNameKey is the key of the name of a resource described by the about endpoints
+	aboutNameKey = "@name"
+
+	// componentGeoServer is the name of Geoserver in its version information
+	componentGeoServer = "GeoServer"
+
+	// componentGeoTools is the name of GeoTools in Geoserver's version information
+	componentGeoTools = "GeoTools"
+
+	// componentGeoWebCache is the name of GeoWebCache in Geoserver's version information
+	componentGeoWebCache = "GeoWebCache"
+)
+
+// ComponentVersion is the version and build information of a component of Geoserver
+type ComponentVersion struct {
+	// Name is the name of the component e.g "GeoTools".
+	Name string
+
+	// Version is the version of the component e.g "2.12.0".
+	Version string
+
+	// GitRevision is the revision the component was built from.
+	GitRevision string
+
+	// BuildTimestamp is when the component was built.
+	BuildTimestamp string
+}
+
+// GetVersionResponse is the version information of Geoserver and its major components
+type GetVersionResponse struct {
+	// GeoServer is the version of Geoserver.
+	GeoServer *ComponentVersion
+
+	// GeoTools is the version of GeoTools used by Geoserver.
+	GeoTools *ComponentVersion
+
+	// GeoWebCache is the version of the embedded GeoWebCache.
+	GeoWebCache *ComponentVersion
+
+	// Components are the versions of every component reported, including the above.
+	Components []*ComponentVersion
+}
+
+// ModuleStatus is the status of a module of Geoserver
+type ModuleStatus struct {
+	// Module is the identifier of the module e.g "gs-main".
+	Module string
+
+	// Name is the human readable name of the module.
+	Name string
+
+	// Component is the library the module wraps, when it does.
+	Component string
+
+	// Version is the version of the module.
+	Version string
+
+	// Message is additional information about the status of the module.
+	Message string
+
+	// Enabled is true when the module is enabled.
+	Enabled bool
+
+	// Available is true when the module is working.
+	Available bool
+}
+
+// GetStatusResponse is the status of every module of Geoserver
+type GetStatusResponse struct {
+	Modules []*ModuleStatus
+}
+
+// ManifestEntry is the manifest of a library installed in Geoserver
+type ManifestEntry struct {
+	// Name is the name of the library e.g "gs-wps-core-2.12.0".
+	Name string
+
+	// Attributes are the attributes of the library's manifest e.g "Implementation-Version".
+	Attributes map[string]string
+}
+
+// GetManifestResponse is the manifests of every library installed in Geoserver, which includes its extensions
+type GetManifestResponse struct {
+	Entries []*ManifestEntry
+}
+
+// SystemMetric is a measurement of the system Geoserver is running on
+type SystemMetric struct {
+	// Name is the identifier of the metric e.g "CPU_LOAD".
+	Name string
+
+	// Description describes the metric.
+	Description string
+
+	// Category is the category of the metric e.g "CPU".
+	Category string
+
+	// Value is the value of the metric.
+	Value string
+
+	// Unit is the unit of the value e.g "%".
+	Unit string
+
+	// Available is false when the metric could not be measured.
+	Available bool
+}
+
+// SystemStatus is the status of the system Geoserver is running on
+type SystemStatus struct {
+	// CPULoad is the load of the CPUs, as a percentage.
+	CPULoad float64
+
+	// GeoServerCPUUsage is the CPU used by Geoserver, as a percentage.
+	GeoServerCPUUsage float64
+
+	// MemoryUsed is the physical memory used, in bytes.
+	MemoryUsed int64
+
+	// MemoryTotal is the physical memory available, in bytes.
+	MemoryTotal int64
+
+	// GeoServerMemoryUsage is the memory used by Geoserver's JVM, as a percentage.
+	GeoServerMemoryUsage float64
+
+	// GeoServerThreads is the number of threads used by Geoserver.
+	GeoServerThreads int
+
+	// Metrics are every metric reported, including those above.
+	Metrics []*SystemMetric
+}
+
+// Metric returns the metric with the provided name, or nil when it was not reported
+func (status *SystemStatus) Metric(name string) *SystemMetric {
+	for _, metric := range status.Metrics {
+		if metric.Name == name {
+			return metric
+		}
+	}
+	return nil
+}
+
+// GetVersion gets the versions of Geoserver, GeoTools and GeoWebCache along with their build information,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetVersion() (version *GetVersionResponse, err error) {
+	restResponse := &restAbout{}
+	err = client.getRestResource(client.geoserverBaseURL+"/rest/about/version.json", "the version", restResponse)
+	if err != nil {
+		return
+	}
+
+	version = &GetVersionResponse{Components: make([]*ComponentVersion, 0)}
+	for _, resource := range restResponse.About.Resources {
+		component := &ComponentVersion{
+			Name:           resource[aboutNameKey],
+			Version:        resource["Version"],
+			GitRevision:    resource["Git-Revision"],
+			BuildTimestamp: resource["Build-Timestamp"],
+		}
+		version.Components = append(version.Components, component)
+
+		switch component.Name {
+		case componentGeoServer:
+			version.GeoServer = component
+		case componentGeoTools:
+			version.GeoTools = component
+		case componentGeoWebCache:
+			version.GeoWebCache = component
+		}
+	}
+
+	if version.GeoServer == nil {
+		err = fmt.Errorf("unable to get the version, Geoserver did not report its own version")
+	}
+	return
+}
+
+// GetStatus gets the status of every module of Geoserver, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetStatus() (status *GetStatusResponse, err error) {
+	restResponse := &restModuleStatuses{}
+	err = client.getRestResource(client.geoserverBaseURL+"/rest/about/status.json", "the module status", restResponse)
+	if err != nil {
+		return
+	}
+
+	status = &GetStatusResponse{Modules: make([]*ModuleStatus, 0)}
+	for _, module := range restResponse.Statuses.Statuses {
+		status.Modules = append(status.Modules, &ModuleStatus{
+			Module:    module.Module,
+			Name:      module.Name,
+			Component: module.Component,
+			Version:   module.Version,
+			Message:   module.Message,
+			Enabled:   module.Enabled,
+			Available: module.Available,
+		})
+	}
+	return
+}
+
+// GetManifest gets the manifests of every library installed in Geoserver, which includes its extensions,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetManifest() (manifest *GetManifestResponse, err error) {
+	restResponse := &restAbout{}
+	err = client.getRestResource(client.geoserverBaseURL+"/rest/about/manifest.json", "the manifest", restResponse)
+	if err != nil {
+		return
+	}
+
+	manifest = &GetManifestResponse{Entries: make([]*ManifestEntry, 0)}
+	for _, resource := range restResponse.About.Resources {
+		entry := &ManifestEntry{Name: resource[aboutNameKey], Attributes: make(map[string]string)}
+		for key, value := range resource {
+			if key != aboutNameKey {
+				entry.Attributes[key] = value
+			}
+		}
+		manifest.Entries = append(manifest.Entries, entry)
+	}
+	return
+}
+
+// GetSystemStatus gets the memory, CPU and thread usage of the system Geoserver is running on,
+// returning ErrUnsupportedByServerVersion before Geoserver 2.12, or an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetSystemStatus() (status *SystemStatus, err error) {
+	err = client.requireServerVersion(2, 12)
+	if err != nil {
+		return
+	}
+
+	restResponse := &restSystemStatus{}
+	err = client.getRestResource(client.geoserverBaseURL+"/rest/about/system-status.json", "the system status", restResponse)
+	if err != nil {
+		return
+	}
+
+	return restSystemStatusToSystemStatus(restResponse), nil
+}
+
+/**
+ * REST API
+ */
+
+// restAbout exists in order to represent the JSON returned by Geoserver for its version and manifest
+type restAbout struct {
+	About struct {
+		Resources restAboutResources `json:"resource"`
+	} `json:"about"`
+}
+
+// restAboutResources are the resources described by the about endpoints, which Geoserver encodes as a single
+// object when there is only one
+type restAboutResources []map[string]string
+
+// restModuleStatuses exists in order to represent the JSON returned by Geoserver for the status of its modules
+type restModuleStatuses struct {
+	Statuses struct {
+		Statuses []*restModuleStatus `json:"status"`
+	} `json:"statuss"`
+}
+
+// restModuleStatus is the status of a module
+type restModuleStatus struct {
+	Module    string `json:"module"`
+	Name      string `json:"name"`
+	Component string `json:"component"`
+	Version   string `json:"version"`
+	Message   string `json:"message"`
+	Enabled   bool   `json:"isEnabled"`
+	Available bool   `json:"isAvailable"`
+}
+
+// restSystemStatus exists in order to represent the JSON returned by Geoserver for the status of its system
+type restSystemStatus struct {
+	Metrics struct {
+		Metrics []*restSystemMetric `json:"metric"`
+	} `json:"metrics"`
+}
+
+// restSystemMetric is a measurement of the system
+type restSystemMetric struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Category    string      `json:"category"`
+	Value       interface{} `json:"value"`
+	Unit        string      `json:"unit"`
+	Available   bool        `json:"available"`
+}
+
+// UnmarshalJSON unmarshals either an array of resources or a single resource, converting every value to a string
+func (resources *restAboutResources) UnmarshalJSON(data []byte) (err error) {
+	var many []map[string]interface{}
+	if json.Unmarshal(data, &many) != nil {
+		var single map[string]interface{}
+		err = json.Unmarshal(data, &single)
+		if err != nil {
+			return
+		}
+		many = append(many, single)
+	}
+
+	*resources = make(restAboutResources, 0)
+	for _, resource := range many {
+		converted := make(map[string]string)
+		for key, value := range resource {
+			converted[key] = jsonValueToString(value)
+		}
+		*resources = append(*resources, converted)
+	}
+	return
+}
+
+// restSystemStatusToSystemStatus converts a restSystemStatus into a SystemStatus
+func restSystemStatusToSystemStatus(restStatus *restSystemStatus) *SystemStatus {
+	status := &SystemStatus{Metrics: make([]*SystemMetric, 0)}
+	for _, restMetric := range restStatus.Metrics.Metrics {
+		metric := &SystemMetric{
+			Name:        restMetric.Name,
+			Description: restMetric.Description,
+			Category:    restMetric.Category,
+			Unit:        restMetric.Unit,
+			Available:   restMetric.Available,
+		}
+		if restMetric.Value != nil {
+			metric.Value = jsonValueToString(restMetric.Value)
+		}
+		status.Metrics = append(status.Metrics, metric)
+
+		if !metric.Available {
+			continue
+		}
+
+		value, parseErr := strconv.ParseFloat(metric.Value, 64)
+		if parseErr != nil {
+			continue
+		}
+
+		switch metric.Name {
+		case "CPU_LOAD":
+			status.CPULoad = value
+		case "GEOSERVER_CPU_USAGE":
+			status.GeoServerCPUUsage = value
+		case "MEMORY_USED":
+			status.MemoryUsed = int64(value)
+		case "MEMORY_TOTAL":
+			status.MemoryTotal = int64(value)
+		case "GEOSERVER_JVM_MEMORY_USAGE":
+			status.GeoServerMemoryUsage = value
+		case "GEOSERVER_THREADS":
+			status.GeoServerThreads = int(value)
+		}
+	}
+	return status
+}
+
+// jsonValueToString converts a JSON value into a string, without using exponents for large numbers
+func jsonValueToString(value interface{}) string {
+	if number, isNumber := value.(float64); isNumber {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
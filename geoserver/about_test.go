@@ -0,0 +1,51 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetVersionPicksOutTheMajorComponents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/about/version.json", r.URL.Path)
+		w.Write([]byte(`{"about":{"resource":[{"@name":"GeoServer","Build-Timestamp":"01-Oct-2017 10:46","Version":"2.12.0",` +
+			`"Git-Revision":"e1ac1c4"},{"@name":"GeoTools","Version":18.0,"Git-Revision":"6f1bd3d"},` +
+			`{"@name":"GeoWebCache","Version":"1.12.0","Git-Revision":"1.12.x/0d1a2f3"}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	version, err := client.GetVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, &ComponentVersion{Name: "GeoServer", Version: "2.12.0", GitRevision: "e1ac1c4", BuildTimestamp: "01-Oct-2017 10:46"}, version.GeoServer)
+	assert.Equal(t, "18", version.GeoTools.Version)
+	assert.Equal(t, "1.12.0", version.GeoWebCache.Version)
+	assert.Len(t, version.Components, 3)
+}
+
+func TestAboutResourcesCanBeASingleObject(t *testing.T) {
+	restResponse := &restAbout{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"about":{"resource":{"@name":"gs-wps-core-2.12.0","Implementation-Version":"2.12.0"}}}`), restResponse))
+	assert.Equal(t, restAboutResources{{"@name": "gs-wps-core-2.12.0", "Implementation-Version": "2.12.0"}}, restResponse.About.Resources)
+}
+
+func TestSystemStatusExtractsMemoryCPUAndThreads(t *testing.T) {
+	restStatus := &restSystemStatus{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"metrics":{"metric":[`+
+		`{"available":true,"category":"CPU","name":"CPU_LOAD","unit":"%","value":"12.5"},`+
+		`{"available":true,"category":"MEMORY","name":"MEMORY_TOTAL","unit":"bytes","value":16722894848},`+
+		`{"available":false,"category":"MEMORY","name":"MEMORY_USED","unit":"bytes","value":"NOT AVAILABLE"},`+
+		`{"available":true,"category":"GEOSERVER","name":"GEOSERVER_THREADS","unit":"","value":"57"}]}}`), restStatus))
+
+	status := restSystemStatusToSystemStatus(restStatus)
+	assert.Equal(t, 12.5, status.CPULoad)
+	assert.Equal(t, int64(16722894848), status.MemoryTotal)
+	assert.Equal(t, int64(0), status.MemoryUsed)
+	assert.Equal(t, 57, status.GeoServerThreads)
+	assert.Equal(t, "16722894848", status.Metric("MEMORY_TOTAL").Value)
+	assert.Nil(t, status.Metric("SWAP_USED"))
+}
@@ -0,0 +1,604 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// AccessMode is the kind of access a data access rule grants
+type AccessMode string
+
+const (
+	// AccessModeRead grants read access to a layer
+	AccessModeRead AccessMode = "r"
+
+	// AccessModeWrite grants write access to a layer, for example through WFS-T
+	AccessModeWrite AccessMode = "w"
+
+	// AccessModeAdmin grants administrative access to a workspace
+	AccessModeAdmin AccessMode = "a"
+)
+
+// CatalogMode determines how Geoserver treats layers a user is not allowed to access
+type CatalogMode string
+
+const (
+	// CatalogModeHide hides layers the user cannot access, as if they did not exist
+	CatalogModeHide CatalogMode = "HIDE"
+
+	// CatalogModeMixed hides layers the user cannot read, but challenges direct access to them
+	CatalogModeMixed CatalogMode = "MIXED"
+
+	// CatalogModeChallenge lists every layer, challenging the user when they access one they cannot
+	CatalogModeChallenge CatalogMode = "CHALLENGE"
+)
+
+// anyRole is the role Geoserver uses to grant access to everyone
+const anyRole = "*"
+
+// DataAccessRule grants roles access to the layers of a workspace
+type DataAccessRule struct {
+	// Workspace is the workspace the rule applies to, "*" applies it to all workspaces
+	Workspace string
+
+	// Layer is the layer the rule applies to, "*" applies it to all layers in the workspace
+	Layer string
+
+	// AccessMode is the kind of access granted
+	AccessMode AccessMode
+
+	// Roles are the roles granted access, "*" grants access to everyone
+	Roles []string
+}
+
+// ServiceAccessRule grants roles access to an operation of an OGC service e.g "wfs.Transaction"
+type ServiceAccessRule struct {
+	// Service is the service the rule applies to e.g "wfs", "*" applies it to all services
+	Service string
+
+	// Method is the operation of the service the rule applies to e.g "Transaction", "*" applies it to all operations
+	Method string
+
+	// Roles are the roles granted access, "*" grants access to everyone
+	Roles []string
+}
+
+// RestAccessRule grants roles access to the parts of the REST API matching a URL pattern
+type RestAccessRule struct {
+	// URLPattern is an Ant style pattern matching the REST URLs the rule applies to e.g "/rest/workspaces/**"
+	URLPattern string
+
+	// Methods are the HTTP methods the rule applies to e.g "GET"
+	Methods []string
+
+	// Roles are the roles granted access, "*" grants access to everyone
+	Roles []string
+}
+
+// GetDataAccessRulesResponse is the response to GetDataAccessRules
+type GetDataAccessRulesResponse struct {
+	// Rules are the data access rules, ordered by workspace, layer and access mode
+	Rules []*DataAccessRule
+}
+
+// GetDataAccessRules gets the data access rules, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetDataAccessRules() (response *GetDataAccessRulesResponse, err error) {
+	url := client.geoserverBaseURL + "/rest/security/acl/layers"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for data access rules",
+		urlKey, url,
+	)
+
+	rules, err := client.getAccessRules(url, "unable to get data access rules")
+	if err != nil {
+		return
+	}
+
+	response = &GetDataAccessRulesResponse{
+		Rules: make([]*DataAccessRule, 0),
+	}
+	for _, key := range sortedKeys(rules) {
+		var rule *DataAccessRule
+		rule, err = parseDataAccessRule(key, rules[key])
+		if err != nil {
+			return
+		}
+		response.Rules = append(response.Rules, rule)
+	}
+	return
+}
+
+// AddDataAccessRules adds data access rules, returning an error if any of them already exist or it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) AddDataAccessRules(rules []*DataAccessRule) (err error) {
+	return client.sendDataAccessRules(http.MethodPost, rules, "unable to add data access rules")
+}
+
+// ReplaceDataAccessRules replaces the roles of existing data access rules, returning an error if any of them
+// do not exist or it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) ReplaceDataAccessRules(rules []*DataAccessRule) (err error) {
+	return client.sendDataAccessRules(http.MethodPut, rules, "unable to replace data access rules")
+}
+
+// DeleteDataAccessRule deletes the data access rule for a workspace, layer and access mode, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteDataAccessRule(workspace string, layer string, accessMode AccessMode) (err error) {
+	rule := &DataAccessRule{Workspace: workspace, Layer: layer, AccessMode: accessMode, Roles: []string{anyRole}}
+	err = rule.validate()
+	if err != nil {
+		return
+	}
+
+	url := client.accessRuleURL("layers", rule.key())
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Deleting a Geoserver data access rule",
+		urlKey, url,
+	)
+
+	_, err = client.doSecurityRequest(http.MethodDelete, url, nil, fmt.Sprintf("unable to delete data access rule '%s'", rule.key()))
+	return
+}
+
+// accessRuleURL creates the URL of an access rule of a kind, layers, services or rest. Each segment of the rule is escaped
+// on its own, as servlet containers reject encoded slashes, and a REST rule keeps its leading slash as Geoserver reads
+// it from the rest of the path.
+func (client *RestGeoserverClient) accessRuleURL(kind string, key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = pathEscape(segment)
+	}
+	return client.geoserverBaseURL + "/rest/security/acl/" + kind + "/" + strings.Join(segments, "/")
+}
+
+// GetServiceAccessRulesResponse is the response to GetServiceAccessRules
+type GetServiceAccessRulesResponse struct {
+	// Rules are the service access rules, ordered by service and method
+	Rules []*ServiceAccessRule
+}
+
+// GetRestAccessRulesResponse is the response to GetRestAccessRules
+type GetRestAccessRulesResponse struct {
+	// Rules are the REST access rules, ordered by URL pattern
+	Rules []*RestAccessRule
+}
+
+// GetServiceAccessRules gets the service access rules, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetServiceAccessRules() (response *GetServiceAccessRulesResponse, err error) {
+	url := client.geoserverBaseURL + "/rest/security/acl/services"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for service access rules",
+		urlKey, url,
+	)
+
+	rules, err := client.getAccessRules(url, "unable to get service access rules")
+	if err != nil {
+		return
+	}
+
+	response = &GetServiceAccessRulesResponse{
+		Rules: make([]*ServiceAccessRule, 0),
+	}
+	for _, key := range sortedKeys(rules) {
+		var rule *ServiceAccessRule
+		rule, err = parseServiceAccessRule(key, rules[key])
+		if err != nil {
+			return
+		}
+		response.Rules = append(response.Rules, rule)
+	}
+	return
+}
+
+// AddServiceAccessRules adds service access rules, returning an error if any of them already exist or it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) AddServiceAccessRules(rules []*ServiceAccessRule) (err error) {
+	return client.sendServiceAccessRules(http.MethodPost, rules, "unable to add service access rules")
+}
+
+// ReplaceServiceAccessRules replaces the roles of existing service access rules, returning an error if any of them
+// do not exist or it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) ReplaceServiceAccessRules(rules []*ServiceAccessRule) (err error) {
+	return client.sendServiceAccessRules(http.MethodPut, rules, "unable to replace service access rules")
+}
+
+// DeleteServiceAccessRule deletes the service access rule for a service and method, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteServiceAccessRule(service string, method string) (err error) {
+	rule := &ServiceAccessRule{Service: service, Method: method, Roles: []string{anyRole}}
+	err = rule.validate()
+	if err != nil {
+		return
+	}
+
+	url := client.accessRuleURL("services", rule.key())
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Deleting a Geoserver service access rule",
+		urlKey, url,
+	)
+
+	_, err = client.doSecurityRequest(http.MethodDelete, url, nil, fmt.Sprintf("unable to delete service access rule '%s'", rule.key()))
+	return
+}
+
+// GetRestAccessRules gets the REST access rules, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetRestAccessRules() (response *GetRestAccessRulesResponse, err error) {
+	url := client.geoserverBaseURL + "/rest/security/acl/rest"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for REST access rules",
+		urlKey, url,
+	)
+
+	rules, err := client.getAccessRules(url, "unable to get REST access rules")
+	if err != nil {
+		return
+	}
+
+	response = &GetRestAccessRulesResponse{
+		Rules: make([]*RestAccessRule, 0),
+	}
+	for _, key := range sortedKeys(rules) {
+		var rule *RestAccessRule
+		rule, err = parseRestAccessRule(key, rules[key])
+		if err != nil {
+			return
+		}
+		response.Rules = append(response.Rules, rule)
+	}
+	return
+}
+
+// AddRestAccessRules adds REST access rules, returning an error if any of them already exist or it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) AddRestAccessRules(rules []*RestAccessRule) (err error) {
+	return client.sendRestAccessRules(http.MethodPost, rules, "unable to add REST access rules")
+}
+
+// ReplaceRestAccessRules replaces the roles of existing REST access rules, returning an error if any of them
+// do not exist or it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) ReplaceRestAccessRules(rules []*RestAccessRule) (err error) {
+	return client.sendRestAccessRules(http.MethodPut, rules, "unable to replace REST access rules")
+}
+
+// DeleteRestAccessRule deletes the REST access rule for a URL pattern and HTTP methods, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteRestAccessRule(urlPattern string, methods []string) (err error) {
+	rule := &RestAccessRule{URLPattern: urlPattern, Methods: methods, Roles: []string{anyRole}}
+	err = rule.validate()
+	if err != nil {
+		return
+	}
+
+	url := client.accessRuleURL("rest", rule.key())
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Deleting a Geoserver REST access rule",
+		urlKey, url,
+	)
+
+	_, err = client.doSecurityRequest(http.MethodDelete, url, nil, fmt.Sprintf("unable to delete REST access rule '%s'", rule.key()))
+	return
+}
+
+// GetCatalogMode gets the catalog mode, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetCatalogMode() (mode CatalogMode, err error) {
+	url := client.geoserverBaseURL + "/rest/security/acl/catalog"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for the catalog mode",
+		urlKey, url,
+	)
+
+	responseBody, err := client.doSecurityRequest(http.MethodGet, url, nil, "unable to get the catalog mode")
+	if err != nil {
+		return
+	}
+
+	restResponse := &restCatalogMode{}
+	err = json.Unmarshal(responseBody, restResponse)
+	if err != nil {
+		client.logUnexpectedResponse("Geoserver returned an invalid response", url, httpCodeOK, responseBody)
+		return
+	}
+
+	mode = restResponse.Mode
+	return
+}
+
+// SetCatalogMode sets the catalog mode, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) SetCatalogMode(mode CatalogMode) (err error) {
+	if !mode.isValid() {
+		err = fmt.Errorf("unable to set the catalog mode, '%s' is not a valid catalog mode", mode)
+		return
+	}
+
+	url := client.geoserverBaseURL + "/rest/security/acl/catalog"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Setting the Geoserver catalog mode",
+		urlKey, url,
+		"mode", string(mode),
+	)
+
+	_, err = client.doSecurityRequest(http.MethodPut, url, &restCatalogMode{Mode: mode}, fmt.Sprintf("unable to set the catalog mode to '%s'", mode))
+	return
+}
+
+// sendDataAccessRules sends data access rules to Geoserver using the provided method
+func (client *RestGeoserverClient) sendDataAccessRules(method string, rules []*DataAccessRule, failure string) (err error) {
+	restRequest := make(map[string]string)
+	for _, rule := range rules {
+		err = rule.validate()
+		if err != nil {
+			err = fmt.Errorf("%s, %s", failure, err.Error())
+			return
+		}
+		restRequest[rule.key()] = strings.Join(rule.Roles, ",")
+	}
+
+	return client.sendAccessRules(method, client.geoserverBaseURL+"/rest/security/acl/layers", restRequest, failure)
+}
+
+// sendServiceAccessRules sends service access rules to Geoserver using the provided method
+func (client *RestGeoserverClient) sendServiceAccessRules(method string, rules []*ServiceAccessRule, failure string) (err error) {
+	restRequest := make(map[string]string)
+	for _, rule := range rules {
+		err = rule.validate()
+		if err != nil {
+			err = fmt.Errorf("%s, %s", failure, err.Error())
+			return
+		}
+		restRequest[rule.key()] = strings.Join(rule.Roles, ",")
+	}
+
+	return client.sendAccessRules(method, client.geoserverBaseURL+"/rest/security/acl/services", restRequest, failure)
+}
+
+// sendRestAccessRules sends REST access rules to Geoserver using the provided method
+func (client *RestGeoserverClient) sendRestAccessRules(method string, rules []*RestAccessRule, failure string) (err error) {
+	restRequest := make(map[string]string)
+	for _, rule := range rules {
+		err = rule.validate()
+		if err != nil {
+			err = fmt.Errorf("%s, %s", failure, err.Error())
+			return
+		}
+		restRequest[rule.key()] = strings.Join(rule.Roles, ",")
+	}
+
+	return client.sendAccessRules(method, client.geoserverBaseURL+"/rest/security/acl/rest", restRequest, failure)
+}
+
+// getAccessRules gets access rules from Geoserver, which represents them as a map of rule keys to comma separated roles
+func (client *RestGeoserverClient) getAccessRules(url string, failure string) (rules map[string]string, err error) {
+	responseBody, err := client.doSecurityRequest(http.MethodGet, url, nil, failure)
+	if err != nil {
+		return
+	}
+
+	rules = make(map[string]string)
+	err = json.Unmarshal(responseBody, &rules)
+	if err != nil {
+		client.logUnexpectedResponse("Geoserver returned an invalid response", url, httpCodeOK, responseBody)
+	}
+	return
+}
+
+// sendAccessRules sends access rules, keyed by rule key, to Geoserver using the provided method
+func (client *RestGeoserverClient) sendAccessRules(method string, url string, rules map[string]string, failure string) (err error) {
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Sending Geoserver access rules",
+		urlKey, url,
+		"rules", fmt.Sprintf("%v", rules),
+	)
+
+	_, err = client.doSecurityRequest(method, url, rules, failure)
+	return
+}
+
+// isValid returns true when the access mode is one Geoserver understands
+func (mode AccessMode) isValid() bool {
+	switch mode {
+	case AccessModeRead, AccessModeWrite, AccessModeAdmin:
+		return true
+	}
+	return false
+}
+
+// isValid returns true when the catalog mode is one Geoserver understands
+func (mode CatalogMode) isValid() bool {
+	switch mode {
+	case CatalogModeHide, CatalogModeMixed, CatalogModeChallenge:
+		return true
+	}
+	return false
+}
+
+// key returns the key Geoserver uses to identify the rule e.g "topp.states.r"
+func (rule *DataAccessRule) key() string {
+	return rule.Workspace + "." + rule.Layer + "." + string(rule.AccessMode)
+}
+
+// validate checks the rule can be understood by Geoserver
+func (rule *DataAccessRule) validate() error {
+	if rule.Workspace == "" || strings.Contains(rule.Workspace, ".") {
+		return fmt.Errorf("'%s' is not a valid workspace for a data access rule", rule.Workspace)
+	}
+	if rule.Layer == "" {
+		return fmt.Errorf("a layer is required for data access rules in workspace '%s'", rule.Workspace)
+	}
+	if !rule.AccessMode.isValid() {
+		return fmt.Errorf("'%s' is not a valid access mode", rule.AccessMode)
+	}
+	if len(rule.Roles) == 0 {
+		return fmt.Errorf("data access rule '%s' does not grant access to any roles", rule.key())
+	}
+	return nil
+}
+
+// serviceRulePartPattern matches the service or method of a service access rule
+var serviceRulePartPattern = regexp.MustCompile(`^(\*|[A-Za-z][A-Za-z0-9_-]*)$`)
+
+// key returns the key Geoserver uses to identify the rule e.g "wfs.Transaction"
+func (rule *ServiceAccessRule) key() string {
+	return rule.Service + "." + rule.Method
+}
+
+// validate checks the rule can be understood by Geoserver
+func (rule *ServiceAccessRule) validate() error {
+	if !serviceRulePartPattern.MatchString(rule.Service) {
+		return fmt.Errorf("'%s' is not a valid service for a service access rule", rule.Service)
+	}
+	if !serviceRulePartPattern.MatchString(rule.Method) {
+		return fmt.Errorf("'%s' is not a valid method for a service access rule", rule.Method)
+	}
+	if rule.Service == "*" && rule.Method != "*" {
+		return fmt.Errorf("service access rule '%s' must apply to all methods as it applies to all services", rule.key())
+	}
+	if len(rule.Roles) == 0 {
+		return fmt.Errorf("service access rule '%s' does not grant access to any roles", rule.key())
+	}
+	return nil
+}
+
+// restRuleMethods are the HTTP methods which REST access rules can apply to
+var restRuleMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+// key returns the key Geoserver uses to identify the rule e.g "/rest/**;GET,POST"
+func (rule *RestAccessRule) key() string {
+	return rule.URLPattern + ";" + strings.Join(rule.Methods, ",")
+}
+
+// validate checks the rule can be understood by Geoserver
+func (rule *RestAccessRule) validate() error {
+	if !strings.HasPrefix(rule.URLPattern, "/") || strings.ContainsAny(rule.URLPattern, ";, ") {
+		return fmt.Errorf("'%s' is not a valid URL pattern for a REST access rule", rule.URLPattern)
+	}
+	if len(rule.Methods) == 0 {
+		return fmt.Errorf("REST access rule for '%s' does not apply to any HTTP methods", rule.URLPattern)
+	}
+	for _, method := range rule.Methods {
+		if !restRuleMethods[method] {
+			return fmt.Errorf("'%s' is not a valid HTTP method for a REST access rule", method)
+		}
+	}
+	if len(rule.Roles) == 0 {
+		return fmt.Errorf("REST access rule '%s' does not grant access to any roles", rule.key())
+	}
+	return nil
+}
+
+// parseServiceAccessRule parses a service access rule from the key and roles Geoserver uses to represent it
+func parseServiceAccessRule(key string, roles string) (rule *ServiceAccessRule, err error) {
+	parts := strings.Split(key, ".")
+	if len(parts) != 2 {
+		err = fmt.Errorf("unable to parse service access rule '%s'", key)
+		return
+	}
+
+	rule = &ServiceAccessRule{
+		Service: parts[0],
+		Method:  parts[1],
+		Roles:   splitCommaList(roles),
+	}
+	return
+}
+
+// parseRestAccessRule parses a REST access rule from the key and roles Geoserver uses to represent it
+func parseRestAccessRule(key string, roles string) (rule *RestAccessRule, err error) {
+	separator := strings.LastIndex(key, ";")
+	if separator < 0 {
+		err = fmt.Errorf("unable to parse REST access rule '%s'", key)
+		return
+	}
+
+	rule = &RestAccessRule{
+		URLPattern: key[:separator],
+		Methods:    splitCommaList(key[separator+1:]),
+		Roles:      splitCommaList(roles),
+	}
+	return
+}
+
+// parseDataAccessRule parses a data access rule from the key and roles Geoserver uses to represent it
+func parseDataAccessRule(key string, roles string) (rule *DataAccessRule, err error) {
+	first := strings.Index(key, ".")
+	last := strings.LastIndex(key, ".")
+	if first < 0 || first == last {
+		err = fmt.Errorf("unable to parse data access rule '%s'", key)
+		return
+	}
+
+	rule = &DataAccessRule{
+		Workspace:  key[:first],
+		Layer:      key[first+1 : last],
+		AccessMode: AccessMode(key[last+1:]),
+		Roles:      splitCommaList(roles),
+	}
+	return
+}
+
+// splitCommaList splits a comma separated list, ignoring blank values
+func splitCommaList(list string) []string {
+	result := make([]string, 0)
+	for _, value := range strings.Split(list, ",") {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// sortedKeys returns the keys of the map in order
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+/**
+ * REST API
+ */
+
+// restCatalogMode exists in order to represent the JSON used by Geoserver for the catalog mode
+type restCatalogMode struct {
+	Mode CatalogMode `json:"mode"`
+}
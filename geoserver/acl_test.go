@@ -0,0 +1,84 @@
+package geoserver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDataAccessRulesRoundTripThroughTheGeoserverRepresentation(t *testing.T) {
+	rule := &DataAccessRule{Workspace: "topp", Layer: "states.2018", AccessMode: AccessModeWrite, Roles: []string{"ROLE_EDITOR", "ADMIN"}}
+	assert.NoError(t, rule.validate())
+
+	parsed, err := parseDataAccessRule(rule.key(), "ROLE_EDITOR, ADMIN")
+	assert.NoError(t, err)
+	assert.Equal(t, rule, parsed)
+}
+
+func TestDataAccessRulesAreValidated(t *testing.T) {
+	for _, rule := range []*DataAccessRule{
+		{Workspace: "", Layer: "*", AccessMode: AccessModeRead, Roles: []string{"*"}},
+		{Workspace: "a.b", Layer: "*", AccessMode: AccessModeRead, Roles: []string{"*"}},
+		{Workspace: "topp", Layer: "", AccessMode: AccessModeRead, Roles: []string{"*"}},
+		{Workspace: "topp", Layer: "*", AccessMode: "x", Roles: []string{"*"}},
+		{Workspace: "topp", Layer: "*", AccessMode: AccessModeRead},
+	} {
+		assert.Error(t, rule.validate(), rule.key())
+	}
+}
+
+func TestServiceAccessRulesRoundTripThroughTheGeoserverRepresentation(t *testing.T) {
+	rule := &ServiceAccessRule{Service: "wfs", Method: "Transaction", Roles: []string{"ROLE_EDITOR"}}
+	assert.NoError(t, rule.validate())
+	assert.Equal(t, "wfs.Transaction", rule.key())
+
+	parsed, err := parseServiceAccessRule(rule.key(), "ROLE_EDITOR")
+	assert.NoError(t, err)
+	assert.Equal(t, rule, parsed)
+}
+
+func TestServiceAccessRulesAreValidated(t *testing.T) {
+	for _, rule := range []*ServiceAccessRule{
+		{Service: "", Method: "GetMap", Roles: []string{"*"}},
+		{Service: "wms.x", Method: "GetMap", Roles: []string{"*"}},
+		{Service: "wms", Method: "Get Map", Roles: []string{"*"}},
+		{Service: "*", Method: "GetMap", Roles: []string{"*"}},
+		{Service: "wms", Method: "GetMap"},
+	} {
+		assert.Error(t, rule.validate(), rule.key())
+	}
+}
+
+func TestRestAccessRulesRoundTripThroughTheGeoserverRepresentation(t *testing.T) {
+	rule := &RestAccessRule{URLPattern: "/rest/workspaces/**", Methods: []string{"GET", "POST"}, Roles: []string{"ADMIN"}}
+	assert.NoError(t, rule.validate())
+	assert.Equal(t, "/rest/workspaces/**;GET,POST", rule.key())
+
+	parsed, err := parseRestAccessRule(rule.key(), "ADMIN")
+	assert.NoError(t, err)
+	assert.Equal(t, rule, parsed)
+}
+
+func TestRestAccessRulesAreValidated(t *testing.T) {
+	for _, rule := range []*RestAccessRule{
+		{URLPattern: "rest/**", Methods: []string{"GET"}, Roles: []string{"*"}},
+		{URLPattern: "/rest/**;GET", Methods: []string{"GET"}, Roles: []string{"*"}},
+		{URLPattern: "/rest/**", Roles: []string{"*"}},
+		{URLPattern: "/rest/**", Methods: []string{"get"}, Roles: []string{"*"}},
+		{URLPattern: "/rest/**", Methods: []string{"GET"}},
+	} {
+		assert.Error(t, rule.validate(), rule.key())
+	}
+}
+
+func TestAccessRuleURLsEscapeEachPathSegment(t *testing.T) {
+	client := NewRestGeoserverClient(&recordingLogger{}, nil, "http://localhost/geoserver", "admin", "geoserver")
+
+	dataRule := &DataAccessRule{Workspace: "topp", Layer: "*", AccessMode: AccessModeRead}
+	assert.Equal(t, "http://localhost/geoserver/rest/security/acl/layers/topp.%2A.r", client.accessRuleURL("layers", dataRule.key()))
+
+	serviceRule := &ServiceAccessRule{Service: "wfs", Method: "GetFeature"}
+	assert.Equal(t, "http://localhost/geoserver/rest/security/acl/services/wfs.GetFeature", client.accessRuleURL("services", serviceRule.key()))
+
+	restRule := &RestAccessRule{URLPattern: "/rest/**", Methods: []string{"GET", "POST"}}
+	assert.Equal(t, "http://localhost/geoserver/rest/security/acl/rest//rest/%2A%2A%3BGET%2CPOST", client.accessRuleURL("rest", restRule.key()))
+}
@@ -0,0 +1,531 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CascadedStore is a store which cascades a remote WMS or WMTS service through Geoserver
+type CascadedStore struct {
+	// Name is the name of the store.
+	Name string
+
+	// Workspace is the workspace of the store.
+	Workspace string
+
+	// Description describes the store.
+	Description string
+
+	// Enabled is true when the store is enabled, a disabled store is created when it is false.
+	Enabled bool
+
+	// CapabilitiesURL is the URL of the GetCapabilities document of the remote service.
+	CapabilitiesURL string
+
+	// Username is the user Geoserver authenticates with against the remote service, when it requires it.
+	Username string
+
+	// Password is the password Geoserver authenticates with against the remote service.
+	// Geoserver returns it encrypted, so it is left unchanged by updates when it is empty.
+	Password string
+
+	// MaxConnections is the maximum number of concurrent connections to the remote service,
+	// Geoserver's default is used when it is zero.
+	MaxConnections int
+
+	// ReadTimeout is how long Geoserver waits for the remote service to respond, in seconds,
+	// Geoserver's default is used when it is zero.
+	ReadTimeout time.Duration
+
+	// ConnectTimeout is how long Geoserver waits to connect to the remote service, in seconds,
+	// Geoserver's default is used when it is zero.
+	ConnectTimeout time.Duration
+}
+
+// CascadedLayer is a layer of a remote WMS or WMTS service published through a cascaded store
+type CascadedLayer struct {
+	// Name is the name of the layer in Geoserver.
+	Name string
+
+	// NativeName is the name of the layer in the remote service, the name is used when it is empty.
+	NativeName string
+
+	// Workspace is the workspace of the layer's store.
+	Workspace string
+
+	// Store is the cascaded store the layer belongs to.
+	Store string
+
+	// Title is the title of the layer.
+	Title string
+
+	// Abstract describes the layer.
+	Abstract string
+
+	// SRS is the coordinate reference system of the layer e.g "EPSG:4326".
+	SRS string
+
+	// Enabled is true when the layer is enabled, a disabled layer is created when it is false.
+	Enabled bool
+}
+
+// ListWMSStores lists the names of the cascaded WMS stores of a workspace, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) ListWMSStores(workspace string) (names []string, err error) {
+	return client.listCascadedStores(ServiceWMS, workspace)
+}
+
+// GetWMSStore gets a cascaded WMS store, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetWMSStore(workspace string, store string) (wmsStore *CascadedStore, err error) {
+	return client.getCascadedStore(ServiceWMS, workspace, store)
+}
+
+// CreateWMSStore creates a store which cascades a remote WMS service, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) CreateWMSStore(store *CascadedStore) (err error) {
+	return client.sendCascadedStore(ServiceWMS, http.MethodPost, store)
+}
+
+// UpdateWMSStore updates a cascaded WMS store, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UpdateWMSStore(store *CascadedStore) (err error) {
+	return client.sendCascadedStore(ServiceWMS, http.MethodPut, store)
+}
+
+// DeleteWMSStore deletes a cascaded WMS store along with its layers, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteWMSStore(workspace string, store string) (err error) {
+	return client.deleteCascadedStore(ServiceWMS, workspace, store)
+}
+
+// ListWMSLayers lists the names of the layers of a cascaded WMS store, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) ListWMSLayers(workspace string, store string) (names []string, err error) {
+	return client.listCascadedLayers(ServiceWMS, workspace, store)
+}
+
+// GetWMSLayer gets a layer of a cascaded WMS store, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetWMSLayer(workspace string, store string, layer string) (wmsLayer *CascadedLayer, err error) {
+	return client.getCascadedLayer(ServiceWMS, workspace, store, layer)
+}
+
+// CreateWMSLayer publishes a layer of a cascaded WMS store, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) CreateWMSLayer(layer *CascadedLayer) (err error) {
+	return client.sendCascadedLayer(ServiceWMS, http.MethodPost, layer)
+}
+
+// UpdateWMSLayer updates a layer of a cascaded WMS store, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UpdateWMSLayer(layer *CascadedLayer) (err error) {
+	return client.sendCascadedLayer(ServiceWMS, http.MethodPut, layer)
+}
+
+// DeleteWMSLayer deletes a layer of a cascaded WMS store, unpublishing it, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteWMSLayer(workspace string, store string, layer string) (err error) {
+	return client.deleteCascadedLayer(ServiceWMS, workspace, store, layer)
+}
+
+// ListWMTSStores lists the names of the cascaded WMTS stores of a workspace, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) ListWMTSStores(workspace string) (names []string, err error) {
+	return client.listCascadedStores(ServiceWMTS, workspace)
+}
+
+// GetWMTSStore gets a cascaded WMTS store, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetWMTSStore(workspace string, store string) (wmtsStore *CascadedStore, err error) {
+	return client.getCascadedStore(ServiceWMTS, workspace, store)
+}
+
+// CreateWMTSStore creates a store which cascades a remote WMTS service, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) CreateWMTSStore(store *CascadedStore) (err error) {
+	return client.sendCascadedStore(ServiceWMTS, http.MethodPost, store)
+}
+
+// UpdateWMTSStore updates a cascaded WMTS store, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UpdateWMTSStore(store *CascadedStore) (err error) {
+	return client.sendCascadedStore(ServiceWMTS, http.MethodPut, store)
+}
+
+// DeleteWMTSStore deletes a cascaded WMTS store along with its layers, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteWMTSStore(workspace string, store string) (err error) {
+	return client.deleteCascadedStore(ServiceWMTS, workspace, store)
+}
+
+// ListWMTSLayers lists the names of the layers of a cascaded WMTS store, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) ListWMTSLayers(workspace string, store string) (names []string, err error) {
+	return client.listCascadedLayers(ServiceWMTS, workspace, store)
+}
+
+// GetWMTSLayer gets a layer of a cascaded WMTS store, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetWMTSLayer(workspace string, store string, layer string) (wmtsLayer *CascadedLayer, err error) {
+	return client.getCascadedLayer(ServiceWMTS, workspace, store, layer)
+}
+
+// CreateWMTSLayer publishes a layer of a cascaded WMTS store, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) CreateWMTSLayer(layer *CascadedLayer) (err error) {
+	return client.sendCascadedLayer(ServiceWMTS, http.MethodPost, layer)
+}
+
+// UpdateWMTSLayer updates a layer of a cascaded WMTS store, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UpdateWMTSLayer(layer *CascadedLayer) (err error) {
+	return client.sendCascadedLayer(ServiceWMTS, http.MethodPut, layer)
+}
+
+// DeleteWMTSLayer deletes a layer of a cascaded WMTS store, unpublishing it, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteWMTSLayer(workspace string, store string, layer string) (err error) {
+	return client.deleteCascadedLayer(ServiceWMTS, workspace, store, layer)
+}
+
+// cascadedStoresURL creates the URL of the cascaded stores of a service in a workspace
+func (client *RestGeoserverClient) cascadedStoresURL(service OWSService, workspace string) string {
+	return client.geoserverBaseURL + "/rest/workspaces/" + workspace + "/" + string(service) + "stores"
+}
+
+// cascadedLayersURL creates the URL of the layers of a cascaded store, Geoserver names them "wmslayers"
+// for WMS stores but only "layers" for WMTS stores
+func (client *RestGeoserverClient) cascadedLayersURL(service OWSService, workspace string, store string) string {
+	layersURL := client.cascadedStoresURL(service, workspace) + "/" + store + "/"
+	if service == ServiceWMTS {
+		return layersURL + "layers"
+	}
+	return layersURL + string(service) + "layers"
+}
+
+// cascadedStoreDescription describes a cascaded store for logs and errors
+func cascadedStoreDescription(service OWSService, workspace string, store string) string {
+	return fmt.Sprintf("%s store '%s:%s'", strings.ToUpper(string(service)), workspace, store)
+}
+
+// cascadedLayerDescription describes a layer of a cascaded store for logs and errors
+func cascadedLayerDescription(service OWSService, workspace string, store string, layer string) string {
+	return fmt.Sprintf("%s layer '%s' of %s", strings.ToUpper(string(service)), layer, cascadedStoreDescription(service, workspace, store))
+}
+
+// listCascadedStores lists the names of the cascaded stores of a service in a workspace
+func (client *RestGeoserverClient) listCascadedStores(service OWSService, workspace string) (names []string, err error) {
+	restResponse := &restCascadedStoresWrapper{}
+	err = client.getRestResource(client.cascadedStoresURL(service, workspace)+".json",
+		fmt.Sprintf("the %s stores of workspace '%s'", strings.ToUpper(string(service)), workspace), restResponse)
+	if err != nil {
+		return
+	}
+
+	if service == ServiceWMTS {
+		return restResponse.WMTSStores.names(), nil
+	}
+	return restResponse.WMSStores.names(), nil
+}
+
+// getCascadedStore gets a cascaded store, returning an error if Geoserver does not return it
+func (client *RestGeoserverClient) getCascadedStore(service OWSService, workspace string, store string) (cascadedStore *CascadedStore, err error) {
+	description := cascadedStoreDescription(service, workspace, store)
+	restResponse := &restCascadedStoreWrapper{}
+	err = client.getRestResource(client.cascadedStoresURL(service, workspace)+"/"+store+".json", description, restResponse)
+	if err != nil {
+		return
+	}
+
+	restStore := restResponse.store(service)
+	if restStore == nil {
+		err = fmt.Errorf("unable to get %s, Geoserver returned an invalid response", description)
+		return
+	}
+
+	cascadedStore = restCascadedStoreToCascadedStore(workspace, restStore)
+	return
+}
+
+// sendCascadedStore creates a cascaded store with POST or updates it with PUT
+func (client *RestGeoserverClient) sendCascadedStore(service OWSService, method string, store *CascadedStore) (err error) {
+	storeURL := client.cascadedStoresURL(service, store.Workspace)
+	if method == http.MethodPut {
+		storeURL += "/" + store.Name
+	}
+
+	payload := &restCascadedStoreWrapper{}
+	if service == ServiceWMTS {
+		payload.WMTSStore = newRestCascadedStore(service, store)
+	} else {
+		payload.WMSStore = newRestCascadedStore(service, store)
+	}
+
+	return client.sendRestResource(method, storeURL, cascadedStoreDescription(service, store.Workspace, store.Name), payload)
+}
+
+// deleteCascadedStore deletes a cascaded store along with its layers
+func (client *RestGeoserverClient) deleteCascadedStore(service OWSService, workspace string, store string) (err error) {
+	return client.deleteRestResource(client.cascadedStoresURL(service, workspace)+"/"+store+"?recurse=true",
+		cascadedStoreDescription(service, workspace, store))
+}
+
+// listCascadedLayers lists the names of the layers of a cascaded store
+func (client *RestGeoserverClient) listCascadedLayers(service OWSService, workspace string, store string) (names []string, err error) {
+	restResponse := &restCascadedLayersWrapper{}
+	err = client.getRestResource(client.cascadedLayersURL(service, workspace, store)+".json",
+		"the layers of "+cascadedStoreDescription(service, workspace, store), restResponse)
+	if err != nil {
+		return
+	}
+
+	if service == ServiceWMTS {
+		return restResponse.WMTSLayers.names(), nil
+	}
+	return restResponse.WMSLayers.names(), nil
+}
+
+// getCascadedLayer gets a layer of a cascaded store, returning an error if Geoserver does not return it
+func (client *RestGeoserverClient) getCascadedLayer(service OWSService, workspace string, store string, layer string) (cascadedLayer *CascadedLayer, err error) {
+	description := cascadedLayerDescription(service, workspace, store, layer)
+	restResponse := &restCascadedLayerWrapper{}
+	err = client.getRestResource(client.cascadedLayersURL(service, workspace, store)+"/"+layer+".json", description, restResponse)
+	if err != nil {
+		return
+	}
+
+	restLayer := restResponse.layer(service)
+	if restLayer == nil {
+		err = fmt.Errorf("unable to get %s, Geoserver returned an invalid response", description)
+		return
+	}
+
+	cascadedLayer = restCascadedLayerToCascadedLayer(workspace, store, restLayer)
+	return
+}
+
+// sendCascadedLayer creates a layer of a cascaded store with POST or updates it with PUT
+func (client *RestGeoserverClient) sendCascadedLayer(service OWSService, method string, layer *CascadedLayer) (err error) {
+	layerURL := client.cascadedLayersURL(service, layer.Workspace, layer.Store)
+	if method == http.MethodPut {
+		layerURL += "/" + layer.Name
+	}
+
+	payload := &restCascadedLayerWrapper{}
+	if service == ServiceWMTS {
+		payload.WMTSLayer = newRestCascadedLayer(layer)
+	} else {
+		payload.WMSLayer = newRestCascadedLayer(layer)
+	}
+
+	return client.sendRestResource(method, layerURL, cascadedLayerDescription(service, layer.Workspace, layer.Store, layer.Name), payload)
+}
+
+// deleteCascadedLayer deletes a layer of a cascaded store along with the layer publishing it
+func (client *RestGeoserverClient) deleteCascadedLayer(service OWSService, workspace string, store string, layer string) (err error) {
+	return client.deleteRestResource(client.cascadedLayersURL(service, workspace, store)+"/"+layer+"?recurse=true",
+		cascadedLayerDescription(service, workspace, store, layer))
+}
+
+/**
+ * REST API
+ */
+
+// restCascadedStoreWrapper exists in order to represent the JSON used by Geoserver for a cascaded store,
+// only the field of the service being used is set
+type restCascadedStoreWrapper struct {
+	WMSStore  *restCascadedStore `json:"wmsStore,omitempty"`
+	WMTSStore *restCascadedStore `json:"wmtsStore,omitempty"`
+}
+
+// redacted returns a copy of the wrapper whose store password is replaced, so that it can be logged
+func (wrapper *restCascadedStoreWrapper) redacted() interface{} {
+	return &restCascadedStoreWrapper{
+		WMSStore:  wrapper.WMSStore.redacted(),
+		WMTSStore: wrapper.WMTSStore.redacted(),
+	}
+}
+
+// restCascadedStore is a store which cascades a remote service
+type restCascadedStore struct {
+	Name            string           `json:"name"`
+	Description     string           `json:"description,omitempty"`
+	Type            string           `json:"type,omitempty"`
+	Enabled         bool             `json:"enabled"`
+	Workspace       *restNamedEntity `json:"workspace,omitempty"`
+	CapabilitiesURL string           `json:"capabilitiesURL,omitempty"`
+	Username        string           `json:"user,omitempty"`
+	Password        string           `json:"password,omitempty"`
+	MaxConnections  int              `json:"maxConnections,omitempty"`
+	ReadTimeout     int              `json:"readTimeout,omitempty"`
+	ConnectTimeout  int              `json:"connectTimeout,omitempty"`
+}
+
+// redacted returns a copy of the store whose password is replaced, or nil if there is no store
+func (restStore *restCascadedStore) redacted() *restCascadedStore {
+	if restStore == nil {
+		return nil
+	}
+	redacted := *restStore
+	if redacted.Password != "" {
+		redacted.Password = redactedValue
+	}
+	return &redacted
+}
+
+// restCascadedStoresWrapper exists in order to represent the JSON returned by Geoserver when listing cascaded stores
+type restCascadedStoresWrapper struct {
+	WMSStores  restCatalogList `json:"wmsStores"`
+	WMTSStores restCatalogList `json:"wmtsStores"`
+}
+
+// restCascadedLayerWrapper exists in order to represent the JSON used by Geoserver for a layer of a cascaded store,
+// only the field of the service being used is set
+type restCascadedLayerWrapper struct {
+	WMSLayer  *restCascadedLayer `json:"wmsLayer,omitempty"`
+	WMTSLayer *restCascadedLayer `json:"wmtsLayer,omitempty"`
+}
+
+// restCascadedLayer is a layer of a cascaded store
+type restCascadedLayer struct {
+	Name       string `json:"name"`
+	NativeName string `json:"nativeName,omitempty"`
+	Title      string `json:"title,omitempty"`
+	Abstract   string `json:"abstract,omitempty"`
+	SRS        string `json:"srs,omitempty"`
+	Enabled    bool   `json:"enabled"`
+}
+
+// restCascadedLayersWrapper exists in order to represent the JSON returned by Geoserver when listing the layers
+// of a cascaded store
+type restCascadedLayersWrapper struct {
+	WMSLayers  restCatalogList `json:"wmsLayers"`
+	WMTSLayers restCatalogList `json:"wmtsLayers"`
+}
+
+// restCatalogList is a list of catalog entities such as {"wmsStore":[{"name":"a"}]}, Geoserver encodes it as an
+// empty string when there are none and the list as a single object when there is only one
+type restCatalogList []*restNamedEntity
+
+// UnmarshalJSON unmarshals the entities of a list, whatever the key they are listed under
+func (list *restCatalogList) UnmarshalJSON(data []byte) (err error) {
+	*list = make(restCatalogList, 0)
+	if strings.HasPrefix(string(data), `"`) {
+		return
+	}
+
+	var keyed map[string]json.RawMessage
+	err = json.Unmarshal(data, &keyed)
+	if err != nil {
+		return
+	}
+
+	for _, entities := range keyed {
+		var many []*restNamedEntity
+		if json.Unmarshal(entities, &many) == nil {
+			*list = append(*list, many...)
+			continue
+		}
+
+		single := &restNamedEntity{}
+		err = json.Unmarshal(entities, single)
+		if err != nil {
+			return
+		}
+		*list = append(*list, single)
+	}
+	return
+}
+
+// names returns the names of the entities of a list
+func (list restCatalogList) names() []string {
+	names := make([]string, 0)
+	for _, entity := range list {
+		names = append(names, entity.Name)
+	}
+	return names
+}
+
+// store returns the cascaded store of a service
+func (wrapper *restCascadedStoreWrapper) store(service OWSService) *restCascadedStore {
+	if service == ServiceWMTS {
+		return wrapper.WMTSStore
+	}
+	return wrapper.WMSStore
+}
+
+// layer returns the layer of a cascaded store of a service
+func (wrapper *restCascadedLayerWrapper) layer(service OWSService) *restCascadedLayer {
+	if service == ServiceWMTS {
+		return wrapper.WMTSLayer
+	}
+	return wrapper.WMSLayer
+}
+
+// newRestCascadedStore converts a CascadedStore into a restCascadedStore
+func newRestCascadedStore(service OWSService, store *CascadedStore) *restCascadedStore {
+	return &restCascadedStore{
+		Name:            store.Name,
+		Description:     store.Description,
+		Type:            strings.ToUpper(string(service)),
+		Enabled:         store.Enabled,
+		Workspace:       &restNamedEntity{Name: store.Workspace},
+		CapabilitiesURL: store.CapabilitiesURL,
+		Username:        store.Username,
+		Password:        store.Password,
+		MaxConnections:  store.MaxConnections,
+		ReadTimeout:     int(store.ReadTimeout / time.Second),
+		ConnectTimeout:  int(store.ConnectTimeout / time.Second),
+	}
+}
+
+// restCascadedStoreToCascadedStore converts a restCascadedStore into a CascadedStore
+func restCascadedStoreToCascadedStore(workspace string, restStore *restCascadedStore) *CascadedStore {
+	store := &CascadedStore{
+		Name:            restStore.Name,
+		Workspace:       workspace,
+		Description:     restStore.Description,
+		Enabled:         restStore.Enabled,
+		CapabilitiesURL: restStore.CapabilitiesURL,
+		Username:        restStore.Username,
+		Password:        restStore.Password,
+		MaxConnections:  restStore.MaxConnections,
+		ReadTimeout:     time.Duration(restStore.ReadTimeout) * time.Second,
+		ConnectTimeout:  time.Duration(restStore.ConnectTimeout) * time.Second,
+	}
+	if restStore.Workspace != nil {
+		store.Workspace = restStore.Workspace.Name
+	}
+	return store
+}
+
+// newRestCascadedLayer converts a CascadedLayer into a restCascadedLayer
+func newRestCascadedLayer(layer *CascadedLayer) *restCascadedLayer {
+	return &restCascadedLayer{
+		Name:       layer.Name,
+		NativeName: layer.NativeName,
+		Title:      layer.Title,
+		Abstract:   layer.Abstract,
+		SRS:        layer.SRS,
+		Enabled:    layer.Enabled,
+	}
+}
+
+// restCascadedLayerToCascadedLayer converts a restCascadedLayer into a CascadedLayer, Geoserver only refers to the
+// store by its prefixed name so the workspace and store are those the layer was requested with
+func restCascadedLayerToCascadedLayer(workspace string, store string, restLayer *restCascadedLayer) *CascadedLayer {
+	return &CascadedLayer{
+		Name:       restLayer.Name,
+		NativeName: restLayer.NativeName,
+		Workspace:  workspace,
+		Store:      store,
+		Title:      restLayer.Title,
+		Abstract:   restLayer.Abstract,
+		SRS:        restLayer.SRS,
+		Enabled:    restLayer.Enabled,
+	}
+}
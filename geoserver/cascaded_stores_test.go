@@ -0,0 +1,114 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCascadedLayersURLDependsOnTheService(t *testing.T) {
+	client := NewRestGeoserverClient(&recordingLogger{}, nil, "http://localhost/geoserver", "admin", "geoserver")
+
+	assert.Equal(t, "http://localhost/geoserver/rest/workspaces/topp/wmsstores/remote/wmslayers", client.cascadedLayersURL(ServiceWMS, "topp", "remote"))
+	assert.Equal(t, "http://localhost/geoserver/rest/workspaces/topp/wmtsstores/remote/layers", client.cascadedLayersURL(ServiceWMTS, "topp", "remote"))
+}
+
+func TestRestCatalogListToleratesGeoserversEncodings(t *testing.T) {
+	for body, expected := range map[string][]string{
+		`{"wmsStores":""}`: {},
+		`{"wmsStores":{"wmsStore":{"name":"a","href":"http://localhost/a.json"}}}`: {"a"},
+		`{"wmsStores":{"wmsStore":[{"name":"a"},{"name":"b"}]}}`:                   {"a", "b"},
+		`{"wmtsStores":{"wmtsStore":[{"name":"c"}]}}`:                              {},
+	} {
+		restResponse := &restCascadedStoresWrapper{}
+		assert.NoError(t, json.Unmarshal([]byte(body), restResponse), body)
+		assert.Equal(t, expected, restResponse.WMSStores.names(), body)
+	}
+}
+
+func TestCreateWMSStoreSendsTheConnectionSettings(t *testing.T) {
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestBody, _ := ioutil.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.Path, string(requestBody)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	err := client.CreateWMSStore(&CascadedStore{
+		Name:            "remote",
+		Workspace:       "topp",
+		Enabled:         true,
+		CapabilitiesURL: "http://example.com/wms?request=GetCapabilities",
+		Username:        "user",
+		Password:        "secret",
+		MaxConnections:  6,
+		ReadTimeout:     time.Minute,
+		ConnectTimeout:  30 * time.Second,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/rest/workspaces/topp/wmsstores", path)
+	assert.JSONEq(t, `{"wmsStore":{"name":"remote","type":"WMS","enabled":true,"workspace":{"name":"topp"},`+
+		`"capabilitiesURL":"http://example.com/wms?request=GetCapabilities","user":"user","password":"secret",`+
+		`"maxConnections":6,"readTimeout":60,"connectTimeout":30}}`, body)
+}
+
+func TestCascadedStorePasswordsAreNeverLogged(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestBody, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(requestBody))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := NewRestGeoserverClient(logger, &http.Client{}, server.URL, "admin", "geoserver")
+
+	store := &CascadedStore{Name: "remote", Workspace: "topp", Username: "user", Password: "s3cr3t-password"}
+	assert.NoError(t, client.CreateWMSStore(store))
+	assert.NoError(t, client.CreateWMTSStore(store))
+	assert.Equal(t, 2, len(bodies))
+	for _, body := range bodies {
+		assert.Contains(t, body, `"password":"s3cr3t-password"`)
+	}
+	for _, line := range logger.lines {
+		assert.NotContains(t, line, "s3cr3t")
+	}
+}
+
+func TestGetWMTSLayerReadsTheLayer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/workspaces/topp/wmtsstores/remote/layers/osm.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"wmtsLayer":{"name":"osm","nativeName":"openstreetmap","title":"OSM","srs":"EPSG:3857",` +
+			`"enabled":true,"store":{"@class":"wmtsStore","name":"topp:remote"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	layer, err := client.GetWMTSLayer("topp", "remote", "osm")
+	assert.NoError(t, err)
+	assert.Equal(t, &CascadedLayer{
+		Name:       "osm",
+		NativeName: "openstreetmap",
+		Workspace:  "topp",
+		Store:      "remote",
+		Title:      "OSM",
+		SRS:        "EPSG:3857",
+		Enabled:    true,
+	}, layer)
+
+	_, err = client.GetWMSLayer("topp", "remote", "osm")
+	assert.Error(t, err)
+}
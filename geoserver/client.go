@@ -2,12 +2,16 @@ package geoserver
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"sync"
+	"time"
 )
 
 // Client is a Geoserver client
@@ -25,6 +29,9 @@ type Client interface {
 	// CreateWorkspace creates a workspace, returning an error if it is not possible.
 	CreateWorkspace(*CreateWorkspaceRequest) error
 
+	// CreateWorkspaceWithSettings creates a workspace along with its settings, returning an error if it is not possible.
+	CreateWorkspaceWithSettings(request *CreateWorkspaceRequest, settings *WorkspaceSettings) error
+
 	// DeleteWorkspace deletes a workspace, returning an error if it is not possible.
 	DeleteWorkspace(workspace string) error
 
@@ -46,20 +53,469 @@ type Client interface {
 	// GetFeatureTypes gets the feature types for the provided workspace and datastore
 	GetFeatureTypes(workspace string, datastore string) (*GetFeatureTypesResponse, error)
 
+	// GetFeatureTypeAttributes gets the attributes of a feature type, returning an error if it is not possible.
+	GetFeatureTypeAttributes(workspace string, datastore string, featureType string) ([]*Attribute, error)
+
+	// GetFeatureTypeDescription gets the title, abstract, keywords and links of a feature type, returning an error if it is not possible.
+	GetFeatureTypeDescription(workspace string, datastore string, featureType string) (*ResourceDescription, error)
+
 	// CreateFeatureType creates a "feature type", which is essentially a layer from a datastore.
 	CreateFeatureType(request *CreateFeatureTypeRequest) error
 
+	// CreateSQLViewFeatureType creates a feature type backed by a parameterised SQL query rather than a table.
+	CreateSQLViewFeatureType(request *CreateSQLViewFeatureTypeRequest) error
+
+	// UpdateFeatureType updates a feature type, only changing the fields which are provided in the request.
+	UpdateFeatureType(request *UpdateFeatureTypeRequest) error
+
 	// DeleteFeatureType deletes a feature type if it exists.
 	DeleteFeatureType(workspace string, datastore string, featureType string) error
+
+	// CreateCoverage publishes a coverage from a coverage store, returning an error if it is not possible.
+	CreateCoverage(request *CreateCoverageRequest) error
+
+	// UpdateCoverage updates a coverage, only changing the fields which are provided in the request.
+	UpdateCoverage(request *UpdateCoverageRequest) error
+
+	// GetCoverageDescription gets the title, abstract, keywords and links of a coverage, returning an error if it is not possible.
+	GetCoverageDescription(workspace string, coverageStore string, coverage string) (*ResourceDescription, error)
+
+	// GetProcesses gets the WPS processes offered by Geoserver, returning an error if it is not possible.
+	GetProcesses() (*GetProcessesResponse, error)
+
+	// DescribeProcess gets the inputs and outputs of a WPS process, returning an error if it is not possible.
+	DescribeProcess(process string) (*ProcessDescription, error)
+
+	// Execute executes a WPS process either synchronously or asynchronously, returning an error if it is not possible.
+	Execute(request *ExecuteRequest) (*ExecuteResponse, error)
+
+	// GetExecutionStatus gets the status of an asynchronous WPS process execution, returning an error if it is not possible.
+	GetExecutionStatus(executionID string) (*ExecuteResponse, error)
+
+	// WaitForExecution polls an asynchronous WPS process execution until it completes, fails or the context is done.
+	WaitForExecution(ctx context.Context, executionID string, pollInterval time.Duration) (*ExecuteResponse, error)
+
+	// Dismiss cancels an asynchronous WPS process execution, returning an error if it is not possible.
+	Dismiss(executionID string) error
+
+	// GetUsers gets the users of the default user group service, returning an error if it is not possible.
+	GetUsers() (*GetUsersResponse, error)
+
+	// CreateUser creates a user in the default user group service, returning an error if it is not possible.
+	CreateUser(request *CreateUserRequest) error
+
+	// UpdateUser updates a user in the default user group service, returning an error if it is not possible.
+	UpdateUser(request *UpdateUserRequest) error
+
+	// DeleteUser deletes a user from the default user group service, returning an error if it is not possible.
+	DeleteUser(user string) error
+
+	// GetGroups gets the groups of the default user group service, returning an error if it is not possible.
+	GetGroups() (*GetGroupsResponse, error)
+
+	// GetUserGroups gets the groups a user belongs to, returning an error if it is not possible.
+	GetUserGroups(user string) (*GetGroupsResponse, error)
+
+	// CreateGroup creates a group in the default user group service, returning an error if it is not possible.
+	CreateGroup(group string) error
+
+	// DeleteGroup deletes a group from the default user group service, returning an error if it is not possible.
+	DeleteGroup(group string) error
+
+	// AddUserToGroup adds a user to a group, returning an error if it is not possible.
+	AddUserToGroup(user string, group string) error
+
+	// RemoveUserFromGroup removes a user from a group, returning an error if it is not possible.
+	RemoveUserFromGroup(user string, group string) error
+
+	// GetRoles gets the roles of the default role service, returning an error if it is not possible.
+	GetRoles() (*GetRolesResponse, error)
+
+	// GetUserRoles gets the roles associated with a user, returning an error if it is not possible.
+	GetUserRoles(user string) (*GetRolesResponse, error)
+
+	// GetGroupRoles gets the roles associated with a group, returning an error if it is not possible.
+	GetGroupRoles(group string) (*GetRolesResponse, error)
+
+	// CreateRole creates a role in the default role service, returning an error if it is not possible.
+	CreateRole(role string) error
+
+	// DeleteRole deletes a role from the default role service, returning an error if it is not possible.
+	DeleteRole(role string) error
+
+	// AssignRoleToUser associates a role with a user, returning an error if it is not possible.
+	AssignRoleToUser(role string, user string) error
+
+	// RemoveRoleFromUser removes the association between a role and a user, returning an error if it is not possible.
+	RemoveRoleFromUser(role string, user string) error
+
+	// AssignRoleToGroup associates a role with a group, returning an error if it is not possible.
+	AssignRoleToGroup(role string, group string) error
+
+	// RemoveRoleFromGroup removes the association between a role and a group, returning an error if it is not possible.
+	RemoveRoleFromGroup(role string, group string) error
+
+	// ChangeMasterPassword changes the keystore master password, returning ErrIncorrectPassword if the old password is wrong.
+	ChangeMasterPassword(oldPassword string, newPassword string) error
+
+	// ChangeSelfPassword changes the password of the user the client authenticates as, returning ErrIncorrectPassword
+	// if the old password is wrong. The client uses the new password from then on.
+	ChangeSelfPassword(oldPassword string, newPassword string) error
+
+	// GetDataAccessRules gets the data access rules, returning an error if it is not possible.
+	GetDataAccessRules() (*GetDataAccessRulesResponse, error)
+
+	// AddDataAccessRules adds data access rules, returning an error if any of them already exist or it is not possible.
+	AddDataAccessRules(rules []*DataAccessRule) error
+
+	// ReplaceDataAccessRules replaces the roles of existing data access rules, returning an error if it is not possible.
+	ReplaceDataAccessRules(rules []*DataAccessRule) error
+
+	// DeleteDataAccessRule deletes the data access rule for a workspace, layer and access mode, returning an error if it is not possible.
+	DeleteDataAccessRule(workspace string, layer string, accessMode AccessMode) error
+
+	// GetServiceAccessRules gets the service access rules, returning an error if it is not possible.
+	GetServiceAccessRules() (*GetServiceAccessRulesResponse, error)
+
+	// AddServiceAccessRules adds service access rules, returning an error if any of them already exist or it is not possible.
+	AddServiceAccessRules(rules []*ServiceAccessRule) error
+
+	// ReplaceServiceAccessRules replaces the roles of existing service access rules, returning an error if it is not possible.
+	ReplaceServiceAccessRules(rules []*ServiceAccessRule) error
+
+	// DeleteServiceAccessRule deletes the service access rule for a service and method, returning an error if it is not possible.
+	DeleteServiceAccessRule(service string, method string) error
+
+	// GetRestAccessRules gets the REST access rules, returning an error if it is not possible.
+	GetRestAccessRules() (*GetRestAccessRulesResponse, error)
+
+	// AddRestAccessRules adds REST access rules, returning an error if any of them already exist or it is not possible.
+	AddRestAccessRules(rules []*RestAccessRule) error
+
+	// ReplaceRestAccessRules replaces the roles of existing REST access rules, returning an error if it is not possible.
+	ReplaceRestAccessRules(rules []*RestAccessRule) error
+
+	// DeleteRestAccessRule deletes the REST access rule for a URL pattern and HTTP methods, returning an error if it is not possible.
+	DeleteRestAccessRule(urlPattern string, methods []string) error
+
+	// GetCatalogMode gets the catalog mode, returning an error if it is not possible.
+	GetCatalogMode() (CatalogMode, error)
+
+	// SetCatalogMode sets the catalog mode, returning an error if it is not possible.
+	SetCatalogMode(mode CatalogMode) error
+
+	// ListTileLayers lists the layers GeoWebCache caches tiles for, returning an error if it is not possible.
+	ListTileLayers() (*ListTileLayersResponse, error)
+
+	// GetTileLayer gets the GeoWebCache configuration of a layer, returning an error if it is not possible.
+	GetTileLayer(layer string) (*TileLayer, error)
+
+	// PutTileLayer creates or replaces the GeoWebCache configuration of a layer, returning an error if it is not possible.
+	PutTileLayer(tileLayer *TileLayer) error
+
+	// DeleteTileLayer deletes the GeoWebCache configuration of a layer, returning an error if it is not possible.
+	DeleteTileLayer(layer string) error
+
+	// Seed generates the tiles of a layer which are missing from the cache, returning an error if it is not possible.
+	Seed(request *SeedRequest) error
+
+	// Reseed generates the tiles of a layer, replacing those already cached, returning an error if it is not possible.
+	Reseed(request *SeedRequest) error
+
+	// Truncate removes the cached tiles of a layer, returning an error if it is not possible.
+	Truncate(request *SeedRequest) error
+
+	// GetSeedStatus gets the progress of the seed tasks of a layer, or of all layers when the layer is empty.
+	GetSeedStatus(layer string) (*GetSeedStatusResponse, error)
+
+	// KillSeedTasks kills the seed tasks of a layer, or of all layers when the layer is empty.
+	KillSeedTasks(layer string, mode KillSeedTasksMode) error
+
+	// WaitForSeed polls the seed tasks of a layer until none are running or pending, or the context is done.
+	WaitForSeed(ctx context.Context, layer string, pollInterval time.Duration) (*GetSeedStatusResponse, error)
+
+	// ListGridSets lists the GeoWebCache gridsets, returning an error if it is not possible.
+	ListGridSets() (*ListGridSetsResponse, error)
+
+	// GetGridSet gets a GeoWebCache gridset, returning an error if it is not possible.
+	GetGridSet(name string) (*GridSet, error)
+
+	// PutGridSet creates or replaces a GeoWebCache gridset, returning an error if it is not possible.
+	PutGridSet(gridSet *GridSet) error
+
+	// DeleteGridSet deletes a GeoWebCache gridset, returning an error if it is not possible.
+	DeleteGridSet(name string) error
+
+	// ListBlobStores lists the GeoWebCache blob stores, returning an error if it is not possible.
+	ListBlobStores() (*ListBlobStoresResponse, error)
+
+	// GetBlobStore gets a GeoWebCache file or S3 blob store, returning an error if it is not possible.
+	GetBlobStore(id string) (BlobStore, error)
+
+	// PutBlobStore creates or replaces a GeoWebCache file or S3 blob store, returning an error if it is not possible.
+	PutBlobStore(blobStore BlobStore) error
+
+	// DeleteBlobStore deletes a GeoWebCache blob store, returning an error if it is not possible.
+	DeleteBlobStore(id string) error
+
+	// GetDiskQuota gets the GeoWebCache disk quota configuration, returning an error if it is not possible.
+	GetDiskQuota() (*DiskQuota, error)
+
+	// SetDiskQuota sets the GeoWebCache disk quota configuration, returning an error if it is not possible.
+	SetDiskQuota(diskQuota *DiskQuota) error
+
+	// MassTruncateLayer removes all the cached tiles of a layer, returning an error if it is not possible.
+	MassTruncateLayer(layer string) error
+
+	// MassTruncateOrphans removes the cached tiles of a layer for parameter sets which are no longer allowed, returning an error if it is not possible.
+	MassTruncateOrphans(layer string) error
+
+	// TileURLTemplate returns a URL template for the tiles of the request, as used by web mapping libraries, returning an error if the request has no gridset.
+	TileURLTemplate(request *TileRequest) (string, error)
+
+	// TileURL returns the URL of a tile, returning an error if the tile's zoom level does not exist in the gridset.
+	TileURL(request *TileRequest, tile *TileCoordinate) (string, error)
+
+	// GetTile fetches a tile from GeoWebCache, returning an error if it is not possible.
+	GetTile(request *TileRequest, tile *TileCoordinate) ([]byte, error)
+
+	// DownloadTiles fetches tiles from GeoWebCache with bounded parallelism, passing each one to the handler.
+	DownloadTiles(ctx context.Context, request *TileRequest, tiles []*TileCoordinate, parallelism int, handler func(tile *TileCoordinate, image []byte) error) error
+
+	// CreateImport creates an import targeting a workspace and optionally a store, returning an error if it is not possible.
+	CreateImport(request *CreateImportRequest) (*Import, error)
+
+	// GetImport gets an import and the state of its tasks, returning an error if it is not possible.
+	GetImport(importID int) (*Import, error)
+
+	// DeleteImport deletes an import, returning an error if it is not possible.
+	DeleteImport(importID int) error
+
+	// UploadImportFile streams a file to an import, returning the tasks created for it.
+	UploadImportFile(importID int, filename string, content io.Reader) ([]*ImportTask, error)
+
+	// GetImportTask gets a task of an import, returning an error if it is not possible.
+	GetImportTask(importID int, taskID int) (*ImportTask, error)
+
+	// UpdateImportTask changes the update mode, layer name or SRS of a task, returning an error if it is not possible.
+	UpdateImportTask(request *UpdateImportTaskRequest) error
+
+	// AddImportTransform adds a transform to a task, returning an error if it is not possible.
+	AddImportTransform(importID int, taskID int, transform *ImportTransform) error
+
+	// RunImport runs an import, synchronously or asynchronously, returning an error if it is not possible.
+	RunImport(importID int, async bool) error
+
+	// WaitForImport polls an import until it is complete or the context is done.
+	WaitForImport(ctx context.Context, importID int, pollInterval time.Duration) (*Import, error)
+
+	// GetSettings gets the global settings of Geoserver, returning an error if it is not possible.
+	GetSettings() (*GeoServerSettings, error)
+
+	// UpdateSettings replaces the global settings of Geoserver, returning an error if it is not possible.
+	UpdateSettings(settings *GeoServerSettings) error
+
+	// GetWorkspaceSettings gets the settings of a workspace, returning an error if it is not possible.
+	GetWorkspaceSettings(workspace string) (*WorkspaceSettings, error)
+
+	// CreateWorkspaceSettings creates settings for a workspace, returning an error if it is not possible.
+	CreateWorkspaceSettings(workspace string, settings *WorkspaceSettings) error
+
+	// UpdateWorkspaceSettings replaces the settings of a workspace, returning an error if it is not possible.
+	UpdateWorkspaceSettings(workspace string, settings *WorkspaceSettings) error
+
+	// DeleteWorkspaceSettings deletes the settings of a workspace, returning an error if it is not possible.
+	DeleteWorkspaceSettings(workspace string) error
+
+	// GetWMSSettings gets the WMS settings of a workspace, or the global settings when the workspace is empty.
+	GetWMSSettings(workspace string) (*WMSSettings, error)
+
+	// UpdateWMSSettings replaces the WMS settings of a workspace, or the global settings when the workspace is empty.
+	UpdateWMSSettings(workspace string, settings *WMSSettings) error
+
+	// DeleteWMSSettings deletes the WMS settings of a workspace, returning an error if it is not possible.
+	DeleteWMSSettings(workspace string) error
+
+	// GetWFSSettings gets the WFS settings of a workspace, or the global settings when the workspace is empty.
+	GetWFSSettings(workspace string) (*WFSSettings, error)
+
+	// UpdateWFSSettings replaces the WFS settings of a workspace, or the global settings when the workspace is empty.
+	UpdateWFSSettings(workspace string, settings *WFSSettings) error
+
+	// DeleteWFSSettings deletes the WFS settings of a workspace, returning an error if it is not possible.
+	DeleteWFSSettings(workspace string) error
+
+	// GetWCSSettings gets the WCS settings of a workspace, or the global settings when the workspace is empty.
+	GetWCSSettings(workspace string) (*WCSSettings, error)
+
+	// UpdateWCSSettings replaces the WCS settings of a workspace, or the global settings when the workspace is empty.
+	UpdateWCSSettings(workspace string, settings *WCSSettings) error
+
+	// DeleteWCSSettings deletes the WCS settings of a workspace, returning an error if it is not possible.
+	DeleteWCSSettings(workspace string) error
+
+	// GetWMTSSettings gets the WMTS settings of a workspace, or the global settings when the workspace is empty.
+	GetWMTSSettings(workspace string) (*WMTSSettings, error)
+
+	// UpdateWMTSSettings replaces the WMTS settings of a workspace, or the global settings when the workspace is empty.
+	UpdateWMTSSettings(workspace string, settings *WMTSSettings) error
+
+	// DeleteWMTSSettings deletes the WMTS settings of a workspace, returning an error if it is not possible.
+	DeleteWMTSSettings(workspace string) error
+
+	// GetLoggingSettings gets the logging settings of Geoserver, returning an error if it is not possible.
+	GetLoggingSettings() (*LoggingSettings, error)
+
+	// UpdateLoggingSettings replaces the logging settings of Geoserver, returning an error if it is not possible.
+	UpdateLoggingSettings(settings *LoggingSettings) error
+
+	// WithLoggingProfile switches Geoserver to a logging profile while the callback runs, then restores the previous settings.
+	WithLoggingProfile(profile LoggingProfile, callback func() error) error
+
+	// Reload reloads the catalog and configuration from the data directory, returning an error if it is not possible.
+	Reload() error
+
+	// Reset resets the store, raster reader and authentication caches, returning an error if it is not possible.
+	Reset() error
+
+	// ReloadAndWait reloads the catalog and waits until Geoserver is healthy again, or the timeout passes.
+	ReloadAndWait(timeout time.Duration, pollInterval time.Duration) error
+
+	// GetVersion gets the versions of Geoserver, GeoTools and GeoWebCache, returning an error if it is not possible.
+	GetVersion() (*GetVersionResponse, error)
+
+	// GetStatus gets the status of every module of Geoserver, returning an error if it is not possible.
+	GetStatus() (*GetStatusResponse, error)
+
+	// GetManifest gets the manifests of every library installed in Geoserver, returning an error if it is not possible.
+	GetManifest() (*GetManifestResponse, error)
+
+	// GetSystemStatus gets the memory, CPU and thread usage of Geoserver's system, returning an error if it is not possible.
+	GetSystemStatus() (*SystemStatus, error)
+
+	// ServerVersion returns the version of Geoserver, detecting it the first time it is needed.
+	ServerVersion() (*ServerVersion, error)
+
+	// GetResourceMetadata gets the metadata of a resource in the data directory, returning an error if it is not possible.
+	GetResourceMetadata(path string) (*ResourceInfo, error)
+
+	// ListResourceDirectory lists a directory in the data directory, returning an error if it is not possible.
+	ListResourceDirectory(path string) (*ResourceDirectory, error)
+
+	// DownloadResource streams a file in the data directory to the destination, returning an error if it is not possible.
+	DownloadResource(path string, destination io.Writer) error
+
+	// UploadResource streams content to a file in the data directory, returning an error if it is not possible.
+	UploadResource(path string, content io.Reader) error
+
+	// MoveResource moves a resource within the data directory, returning an error if it is not possible.
+	MoveResource(source string, destination string) error
+
+	// CopyResource copies a resource within the data directory, returning an error if it is not possible.
+	CopyResource(source string, destination string) error
+
+	// DeleteResource deletes a resource in the data directory, returning an error if it is not possible.
+	DeleteResource(path string) error
+
+	// ListTemplates lists the names of the templates at a level of the catalog, returning an error if it is not possible.
+	ListTemplates(scope *TemplateScope) ([]string, error)
+
+	// GetTemplate gets the content of a template at a level of the catalog, returning an error if it is not possible.
+	GetTemplate(scope *TemplateScope, name string) (string, error)
+
+	// PutTemplate creates or replaces a template at a level of the catalog, returning an error if it is not possible.
+	PutTemplate(scope *TemplateScope, name string, content string) error
+
+	// DeleteTemplate deletes a template at a level of the catalog, returning an error if it is not possible.
+	DeleteTemplate(scope *TemplateScope, name string) error
+
+	// ResolveTemplate finds the template which Geoserver applies to a feature type, or nil when the built-in one applies.
+	ResolveTemplate(workspace string, dataStore string, featureType string, name string) (*ResolvedTemplate, error)
+
+	// GetFonts gets the names of the fonts available to Geoserver, returning an error if it is not possible.
+	GetFonts() ([]string, error)
+
+	// ValidateSLD checks the fonts and property names an SLD document refers to against Geoserver before it is uploaded.
+	ValidateSLD(sld []byte, workspace string, datastore string, featureType string) (*SLDValidationResult, error)
+
+	// ListWMSStores lists the names of the cascaded WMS stores of a workspace, returning an error if it is not possible.
+	ListWMSStores(workspace string) ([]string, error)
+
+	// GetWMSStore gets a cascaded WMS store, returning an error if it is not possible.
+	GetWMSStore(workspace string, store string) (*CascadedStore, error)
+
+	// CreateWMSStore creates a store which cascades a remote WMS service, returning an error if it is not possible.
+	CreateWMSStore(store *CascadedStore) error
+
+	// UpdateWMSStore updates a cascaded WMS store, returning an error if it is not possible.
+	UpdateWMSStore(store *CascadedStore) error
+
+	// DeleteWMSStore deletes a cascaded WMS store along with its layers, returning an error if it is not possible.
+	DeleteWMSStore(workspace string, store string) error
+
+	// ListWMSLayers lists the names of the layers of a cascaded WMS store, returning an error if it is not possible.
+	ListWMSLayers(workspace string, store string) ([]string, error)
+
+	// GetWMSLayer gets a layer of a cascaded WMS store, returning an error if it is not possible.
+	GetWMSLayer(workspace string, store string, layer string) (*CascadedLayer, error)
+
+	// CreateWMSLayer publishes a layer of a cascaded WMS store, returning an error if it is not possible.
+	CreateWMSLayer(layer *CascadedLayer) error
+
+	// UpdateWMSLayer updates a layer of a cascaded WMS store, returning an error if it is not possible.
+	UpdateWMSLayer(layer *CascadedLayer) error
+
+	// DeleteWMSLayer deletes a layer of a cascaded WMS store, returning an error if it is not possible.
+	DeleteWMSLayer(workspace string, store string, layer string) error
+
+	// ListWMTSStores lists the names of the cascaded WMTS stores of a workspace, returning an error if it is not possible.
+	ListWMTSStores(workspace string) ([]string, error)
+
+	// GetWMTSStore gets a cascaded WMTS store, returning an error if it is not possible.
+	GetWMTSStore(workspace string, store string) (*CascadedStore, error)
+
+	// CreateWMTSStore creates a store which cascades a remote WMTS service, returning an error if it is not possible.
+	CreateWMTSStore(store *CascadedStore) error
+
+	// UpdateWMTSStore updates a cascaded WMTS store, returning an error if it is not possible.
+	UpdateWMTSStore(store *CascadedStore) error
+
+	// DeleteWMTSStore deletes a cascaded WMTS store along with its layers, returning an error if it is not possible.
+	DeleteWMTSStore(workspace string, store string) error
+
+	// ListWMTSLayers lists the names of the layers of a cascaded WMTS store, returning an error if it is not possible.
+	ListWMTSLayers(workspace string, store string) ([]string, error)
+
+	// GetWMTSLayer gets a layer of a cascaded WMTS store, returning an error if it is not possible.
+	GetWMTSLayer(workspace string, store string, layer string) (*CascadedLayer, error)
+
+	// CreateWMTSLayer publishes a layer of a cascaded WMTS store, returning an error if it is not possible.
+	CreateWMTSLayer(layer *CascadedLayer) error
+
+	// UpdateWMTSLayer updates a layer of a cascaded WMTS store, returning an error if it is not possible.
+	UpdateWMTSLayer(layer *CascadedLayer) error
+
+	// DeleteWMTSLayer deletes a layer of a cascaded WMTS store, returning an error if it is not possible.
+	DeleteWMTSLayer(workspace string, store string, layer string) error
 }
 
 // RestGeoserverClient is a implementation of GeoserverClient which uses Geoserver's REST API.
 type RestGeoserverClient struct {
-	logger            LoggerFunc
-	httpClient        *http.Client
-	geoserverBaseURL  string
+	logger           LoggerFunc
+	httpClient       *http.Client
+	geoserverBaseURL string
+
+	// credentialsMutex guards the credentials, which change when the client changes its own password
+	credentialsMutex  sync.RWMutex
 	geoserverUsername string
 	geoserverPassword string
+
+	// versionMutex guards the version of Geoserver, which is detected the first time it is needed, and the last failure
+	// to detect it, which is returned until versionRetryInterval has passed
+	versionMutex     sync.Mutex
+	serverVersion    *ServerVersion
+	serverVersionErr error
+	versionFailedAt  time.Time
 }
 
 // NewRestGeoserverClient creates a new RestGeoserverClient.
@@ -286,6 +742,30 @@ func (client *RestGeoserverClient) CreateWorkspace(request *CreateWorkspaceReque
 	return
 }
 
+// CreateWorkspaceWithSettings creates a workspace along with its settings, which override the global settings for
+// its services, returning an error if it is not possible. The workspace is deleted again when its settings cannot be
+// created, so that the call can be retried.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) CreateWorkspaceWithSettings(request *CreateWorkspaceRequest, settings *WorkspaceSettings) (err error) {
+	err = client.CreateWorkspace(request)
+	if err != nil {
+		return
+	}
+
+	err = client.CreateWorkspaceSettings(request.Workspace, settings)
+	if err != nil {
+		if deleteErr := client.DeleteWorkspace(request.Workspace); deleteErr != nil {
+			client.logger.Log(
+				levelKey, levelWarn,
+				messageKey, "Unable to delete the workspace whose settings could not be created",
+				"workspace", request.Workspace,
+				errorKey, deleteErr.Error(),
+			)
+		}
+	}
+	return
+}
+
 // DeleteWorkspace deletes a workspace, returning an error if it is not possible.
 // It interacts with with Geoserver using its REST API.
 func (client *RestGeoserverClient) DeleteWorkspace(workspace string) (err error) {
@@ -659,12 +1139,121 @@ func (client *RestGeoserverClient) GetFeatureTypes(workspace string, datastore s
 	return
 }
 
+// GetFeatureTypeAttributes gets the attributes of a feature type, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetFeatureTypeAttributes(workspace string, datastore string, featureType string) (attributes []*Attribute, err error) {
+	url := client.geoserverBaseURL + "/rest/workspaces/" + workspace + "/datastores/" + datastore + "/featuretypes/" + featureType + ".json"
+
+	restResponse := &getFeatureTypeAttributesRestResponse{}
+	err = client.getRestResource(url, fmt.Sprintf("feature type '%s:%s'", workspace, featureType), restResponse)
+	if err != nil {
+		return
+	}
+
+	if restResponse.FeatureType == nil {
+		err = fmt.Errorf("unable to get feature type '%s:%s', Geoserver returned an invalid response", workspace, featureType)
+		return
+	}
+
+	return restAttributesToAttributes(restResponse.FeatureType.Attributes)
+}
+
 // CreateFeatureType creates a "feature type", which is essentially a layer from a datastore.
 // It interacts with with Geoserver using its REST API.
 func (client *RestGeoserverClient) CreateFeatureType(request *CreateFeatureTypeRequest) (err error) {
-	url := client.geoserverBaseURL + "/rest/workspaces/" + request.Workspace + "/datastores/" + request.DataStore + "/featuretypes.json"
+	version, err := client.ServerVersion()
+	if err != nil {
+		// The version only decides the package of the geometry binding, so the legacy one is used when it is unknown.
+		// ServerVersion has already warned about the failure.
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "Creating the feature type with the legacy JTS geometry package",
+			errorKey, err.Error(),
+		)
+		version = nil
+	}
+
+	return client.createFeatureType(request, newCreateFeatureTypeRestRequest(request, version))
+}
+
+// CreateSQLViewFeatureType creates a feature type backed by a parameterised SQL query rather than a table.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) CreateSQLViewFeatureType(request *CreateSQLViewFeatureTypeRequest) (err error) {
+	restRequest, err := newCreateSQLViewFeatureTypeRestRequest(request)
+	if err != nil {
+		return
+	}
 
-	restRequest := newCreateFeatureTypeRestRequest(request)
+	return client.createFeatureType(&request.CreateFeatureTypeRequest, restRequest)
+}
+
+// UpdateFeatureType updates a feature type, only changing the fields which are provided in the request.
+// The feature type is read from Geoserver first, so that the rest of its configuration is preserved.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UpdateFeatureType(request *UpdateFeatureTypeRequest) (err error) {
+	url := client.geoserverBaseURL + "/rest/workspaces/" + request.Workspace + "/datastores/" + request.DataStore + "/featuretypes/" + request.Name + ".json"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Updating a Geoserver feature type",
+		urlKey, url,
+		"workspace", request.Workspace,
+		"datastore", request.DataStore,
+		"featureType", request.Name,
+	)
+
+	statusCode, responseBody, err := client.doJSONRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Unable to get feature type to update", url, statusCode, responseBody)
+		err = fmt.Errorf("unable to update feature type '%s', it could not be found", request.Name)
+		return
+	}
+
+	document := make(map[string]map[string]interface{})
+	err = json.Unmarshal(responseBody, &document)
+	if err != nil {
+		client.logUnexpectedResponse("Geoserver returned an invalid response", url, statusCode, responseBody)
+		return
+	}
+
+	featureType, ok := document["featureType"]
+	if !ok {
+		err = fmt.Errorf("unable to update feature type '%s', Geoserver returned an invalid response", request.Name)
+		return
+	}
+
+	err = applyFeatureTypeUpdate(featureType, request)
+	if err != nil {
+		return
+	}
+
+	statusCode, responseBody, err = client.doJSONRequest(http.MethodPut, url, document)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode {
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "Feature type updated successfully",
+			urlKey, url,
+			"featureType", request.Name,
+		)
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to update feature type", url, statusCode, responseBody)
+	err = fmt.Errorf("unable to update feature type '%s'", request.Name)
+	return
+}
+
+// createFeatureType sends the request to create a feature type to Geoserver
+func (client *RestGeoserverClient) createFeatureType(request *CreateFeatureTypeRequest, restRequest *createFeatureTypeRestRequest) (err error) {
+	url := client.geoserverBaseURL + "/rest/workspaces/" + request.Workspace + "/datastores/" + request.DataStore + "/featuretypes.json"
 
 	var requestJSONBytes []byte
 	requestJSONBytes, err = json.Marshal(restRequest)
@@ -763,12 +1352,113 @@ func (client *RestGeoserverClient) DeleteFeatureType(workspace string, datastore
 
 // createAuthJSONRequest creates a HTTP request, which uses JSON as a payload basic auth
 func (client *RestGeoserverClient) createAuthJSONRequest(method string, url string, body io.Reader) (request *http.Request, err error) {
+	return client.createAuthRequest(method, url, applicationJSON, body)
+}
+
+// createAuthRequest creates a HTTP request using basic auth, where the content type is used for both the payload and the response
+func (client *RestGeoserverClient) createAuthRequest(method string, url string, contentType string, body io.Reader) (request *http.Request, err error) {
 	request, err = http.NewRequest(method, url, body)
 	if err != nil {
 		return
 	}
-	request.Header.Set(contentTypeHeader, applicationJSON)
-	request.Header.Set(acceptHeader, applicationJSON)
-	request.SetBasicAuth(client.geoserverUsername, client.geoserverPassword)
+	request.Header.Set(contentTypeHeader, contentType)
+	request.Header.Set(acceptHeader, contentType)
+	request.SetBasicAuth(client.credentials())
+	return
+}
+
+// credentials returns the username and password used to authenticate with Geoserver
+func (client *RestGeoserverClient) credentials() (username string, password string) {
+	client.credentialsMutex.RLock()
+	defer client.credentialsMutex.RUnlock()
+	return client.geoserverUsername, client.geoserverPassword
+}
+
+// doRequest sends a request to Geoserver, returning the status code and body of the response
+func (client *RestGeoserverClient) doRequest(request *http.Request) (statusCode int, responseBody []byte, err error) {
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "Could not communicate with Geoserver",
+			urlKey, request.URL.String(),
+			errorKey, err.Error(),
+		)
+		return
+	}
+	defer response.Body.Close()
+
+	statusCode = response.StatusCode
+	responseBody, err = ioutil.ReadAll(response.Body)
 	return
 }
+
+// doJSONRequest sends a JSON request to Geoserver, marshalling the payload when one is provided.
+// It returns the status code and body of the response.
+func (client *RestGeoserverClient) doJSONRequest(method string, url string, payload interface{}) (statusCode int, responseBody []byte, err error) {
+	var body io.Reader
+	if payload != nil {
+		var payloadBytes []byte
+		payloadBytes, err = json.Marshal(payload)
+		if err != nil {
+			return
+		}
+		body = bytes.NewReader(payloadBytes)
+	}
+
+	req, err := client.createAuthJSONRequest(method, url, body)
+	if err != nil {
+		return
+	}
+
+	return client.doRequest(req)
+}
+
+// doXMLRequest sends an XML request to Geoserver, marshalling the payload when one is provided.
+// It returns the status code and body of the response.
+func (client *RestGeoserverClient) doXMLRequest(method string, url string, payload interface{}) (statusCode int, responseBody []byte, err error) {
+	var body io.Reader
+	if payload != nil {
+		var payloadBytes []byte
+		payloadBytes, err = xml.Marshal(payload)
+		if err != nil {
+			return
+		}
+		body = bytes.NewReader(payloadBytes)
+	}
+
+	req, err := client.createAuthRequest(method, url, applicationXML, body)
+	if err != nil {
+		return
+	}
+
+	return client.doRequest(req)
+}
+
+// redactedValue replaces secrets in the payloads which are logged
+const redactedValue = "********"
+
+// secretHolder is implemented by payloads which hold secrets, such as passwords, that must not be logged
+type secretHolder interface {
+	// redacted returns a copy of the payload with its secrets replaced, so that it can be logged.
+	redacted() interface{}
+}
+
+// loggablePayload returns a payload which can be logged, redacting its secrets when it has any
+func loggablePayload(payload interface{}) interface{} {
+	if holder, ok := payload.(secretHolder); ok {
+		return holder.redacted()
+	}
+	return payload
+}
+
+// logUnexpectedResponse logs a response from Geoserver that has a status code other than the one expected
+func (client *RestGeoserverClient) logUnexpectedResponse(message string, url string, statusCode int, responseBody []byte) {
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, message,
+		urlKey, url,
+		"responseStatus", fmt.Sprintf("%d", statusCode),
+		"responseBody", string(responseBody),
+	)
+}
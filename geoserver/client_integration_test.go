@@ -1,10 +1,14 @@
 package geoserver
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"strings"
 	"testing"
+	"time"
 )
 
 const (
@@ -527,6 +531,805 @@ func (suite *RestGeoserverClientTestSuite) TestDeleteFeatureTypeDoesNotReturnAnE
 	assert.NoError(suite.T(), err)
 }
 
+func (suite *RestGeoserverClientTestSuite) TestSQLViewFeatureTypeCanBeCreated() {
+	workspace := "d41d8cd98"
+	suite.underTest.CreateWorkspace(&CreateWorkspaceRequest{workspace})
+
+	datastore := "98ecf8427"
+	description := "9800998ecf84"
+	suite.underTest.CreateDatastore(&CreateDatastoreRequest{
+		Name:        datastore,
+		Description: description,
+		Type:        postgresDatastoreType,
+		Workspace:   workspace,
+		ConnectionDetails: newGeoserverPostgisConnectionDetails(
+			suite.postgresConnectionDetails.Container,
+			suite.postgresConnectionDetails.Port,
+			suite.postgresConnectionDetails.Username,
+			suite.postgresConnectionDetails.Password,
+			testSchema,
+			testDatabase,
+		),
+	})
+
+	layerName := "sqlview7b3e"
+
+	err := suite.underTest.CreateSQLViewFeatureType(&CreateSQLViewFeatureTypeRequest{
+		CreateFeatureTypeRequest: CreateFeatureTypeRequest{
+			Name:       layerName,
+			NativeName: layerName,
+			Title:      layerName,
+			Abstract:   "An SQL view created by an integration test",
+			SRS:        "EPSG:26910",
+			NativeBoundingBox: &BoundingBox{
+				MinX: -180,
+				MaxX: 180,
+				MinY: -90,
+				MaxY: 90,
+				CRS:  "EPSG:26910",
+			},
+			DataStore: datastore,
+			Workspace: workspace,
+		},
+		SQLView: &SQLView{
+			SQL:        "select id, geom, name from test_data where id > %minid%",
+			KeyColumns: []string{"id"},
+			Geometry: &SQLViewGeometry{
+				Name: "geom",
+				Type: GeometryTypePoint,
+				SRID: 26910,
+			},
+			Parameters: []*SQLViewParameter{
+				{
+					Name:            "minid",
+					DefaultValue:    "0",
+					ValidationRegex: "^[\\d]+$",
+				},
+			},
+		},
+	})
+	assert.NoError(suite.T(), err)
+
+	isExists, err := suite.underTest.FeatureTypeExists(workspace, datastore, layerName)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), isExists)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestCreateSQLViewFeatureTypeReturnsAnErrorWhenTheSQLViewIsInvalid() {
+	err := suite.underTest.CreateSQLViewFeatureType(&CreateSQLViewFeatureTypeRequest{
+		CreateFeatureTypeRequest: CreateFeatureTypeRequest{
+			Name:      "sqlview7b3e",
+			DataStore: "98ecf8427",
+			Workspace: "d41d8cd98",
+		},
+		SQLView: &SQLView{
+			SQL: "select id, geom, name from test_data",
+			Parameters: []*SQLViewParameter{
+				{Name: "unreferenced"},
+			},
+		},
+	})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestFeatureTypeCanBeUpdatedWithTimeAndElevationDimensions() {
+	workspace := "d41d8cd98"
+	suite.underTest.CreateWorkspace(&CreateWorkspaceRequest{workspace})
+
+	datastore := "98ecf8427"
+	suite.underTest.CreateDatastore(&CreateDatastoreRequest{
+		Name:        datastore,
+		Description: "9800998ecf84",
+		Type:        postgresDatastoreType,
+		Workspace:   workspace,
+		ConnectionDetails: newGeoserverPostgisConnectionDetails(
+			suite.postgresConnectionDetails.Container,
+			suite.postgresConnectionDetails.Port,
+			suite.postgresConnectionDetails.Username,
+			suite.postgresConnectionDetails.Password,
+			testSchema,
+			testDatabase,
+		),
+	})
+
+	layerName := "timeview9c1d"
+	err := suite.underTest.CreateSQLViewFeatureType(&CreateSQLViewFeatureTypeRequest{
+		CreateFeatureTypeRequest: CreateFeatureTypeRequest{
+			Name:       layerName,
+			NativeName: layerName,
+			Title:      layerName,
+			SRS:        "EPSG:26910",
+			DataStore:  datastore,
+			Workspace:  workspace,
+		},
+		SQLView: &SQLView{
+			SQL:        "select id, geom, name, now() as observed, 10.0 as depth from test_data",
+			KeyColumns: []string{"id"},
+			Geometry:   &SQLViewGeometry{Name: "geom", Type: GeometryTypePoint, SRID: 26910},
+		},
+	})
+	assert.NoError(suite.T(), err)
+
+	err = suite.underTest.UpdateFeatureType(&UpdateFeatureTypeRequest{
+		Name:      layerName,
+		DataStore: datastore,
+		Workspace: workspace,
+		Title:     "Observations",
+		Time: &DimensionInfo{
+			Enabled:      true,
+			Attribute:    "observed",
+			Presentation: PresentationList,
+			Units:        "ISO8601",
+			DefaultValue: &DimensionDefaultValue{Strategy: StrategyMaximum},
+		},
+		Elevation: &DimensionInfo{
+			Enabled:      true,
+			Attribute:    "depth",
+			Presentation: PresentationContinuousInterval,
+			Units:        "EPSG:5030",
+			UnitSymbol:   "m",
+		},
+	})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestUpdateFeatureTypeReturnsAnErrorWhenTheFeatureTypeDoesNotExist() {
+	workspace := "d41d8cd98"
+	suite.underTest.CreateWorkspace(&CreateWorkspaceRequest{workspace})
+
+	err := suite.underTest.UpdateFeatureType(&UpdateFeatureTypeRequest{
+		Name:      "idonotexist",
+		DataStore: "idonotexist",
+		Workspace: workspace,
+		Title:     "Never applied",
+	})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestFeatureTypeCanBeUpdatedWithKeywordsAndLinks() {
+	workspace := "d41d8cd98"
+	suite.underTest.CreateWorkspace(&CreateWorkspaceRequest{workspace})
+
+	datastore := "98ecf8427"
+	suite.underTest.CreateDatastore(&CreateDatastoreRequest{
+		Name:        datastore,
+		Description: "9800998ecf84",
+		Type:        postgresDatastoreType,
+		Workspace:   workspace,
+		ConnectionDetails: newGeoserverPostgisConnectionDetails(
+			suite.postgresConnectionDetails.Container,
+			suite.postgresConnectionDetails.Port,
+			suite.postgresConnectionDetails.Username,
+			suite.postgresConnectionDetails.Password,
+			testSchema,
+			testDatabase,
+		),
+	})
+
+	layerName := "test_data"
+	err := suite.underTest.CreateFeatureType(&CreateFeatureTypeRequest{
+		Name:       layerName,
+		NativeName: layerName,
+		Title:      layerName,
+		SRS:        "EPSG:26910",
+		DataStore:  datastore,
+		Workspace:  workspace,
+		Keywords:   []*Keyword{{Value: "points"}},
+	})
+	assert.NoError(suite.T(), err)
+
+	err = suite.underTest.UpdateFeatureType(&UpdateFeatureTypeRequest{
+		Name:          layerName,
+		DataStore:     datastore,
+		Workspace:     workspace,
+		Keywords:      []*Keyword{{Value: "points", Language: "en", Vocabulary: "GEMET"}},
+		MetadataLinks: []*MetadataLink{{Type: "ISO19115:2003", Format: "text/xml", URL: "http://example.com/metadata.xml"}},
+		DataLinks:     []*DataLink{{Format: "text/html", URL: "http://example.com/test_data"}},
+	})
+	assert.NoError(suite.T(), err)
+
+	description, err := suite.underTest.GetFeatureTypeDescription(workspace, datastore, layerName)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []*Keyword{{Value: "points", Language: "en", Vocabulary: "GEMET"}}, description.Keywords)
+	assert.Equal(suite.T(), []*MetadataLink{{Type: "ISO19115:2003", Format: "text/xml", URL: "http://example.com/metadata.xml"}}, description.MetadataLinks)
+	assert.Equal(suite.T(), []*DataLink{{Format: "text/html", URL: "http://example.com/test_data"}}, description.DataLinks)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestUsersGroupsAndRolesCanBeManaged() {
+	user := "e4da3b7fb"
+	group := "1679091c5"
+	role := "ROLE_8F14E45F"
+
+	err := suite.underTest.CreateUser(&CreateUserRequest{Name: user, Password: "c9f0f895fb", Enabled: true})
+	assert.NoError(suite.T(), err)
+
+	err = suite.underTest.UpdateUser(&UpdateUserRequest{Name: user, Password: "45c48cce2e", Enabled: false})
+	assert.NoError(suite.T(), err)
+
+	users, err := suite.underTest.GetUsers()
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), users.Users, &User{Name: user, Enabled: false})
+
+	err = suite.underTest.CreateGroup(group)
+	assert.NoError(suite.T(), err)
+
+	err = suite.underTest.AddUserToGroup(user, group)
+	assert.NoError(suite.T(), err)
+
+	groups, err := suite.underTest.GetUserGroups(user)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), groups.Groups, group)
+
+	err = suite.underTest.CreateRole(role)
+	assert.NoError(suite.T(), err)
+
+	err = suite.underTest.AssignRoleToUser(role, user)
+	assert.NoError(suite.T(), err)
+
+	err = suite.underTest.AssignRoleToGroup(role, group)
+	assert.NoError(suite.T(), err)
+
+	roles, err := suite.underTest.GetUserRoles(user)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), roles.Roles, role)
+
+	roles, err = suite.underTest.GetGroupRoles(group)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), roles.Roles, role)
+
+	assert.NoError(suite.T(), suite.underTest.RemoveRoleFromGroup(role, group))
+	assert.NoError(suite.T(), suite.underTest.RemoveRoleFromUser(role, user))
+	assert.NoError(suite.T(), suite.underTest.DeleteRole(role))
+	assert.NoError(suite.T(), suite.underTest.RemoveUserFromGroup(user, group))
+	assert.NoError(suite.T(), suite.underTest.DeleteGroup(group))
+	assert.NoError(suite.T(), suite.underTest.DeleteUser(user))
+}
+
+func (suite *RestGeoserverClientTestSuite) TestDataAccessRulesCanBeManaged() {
+	workspace := "d41d8cd98"
+	suite.underTest.CreateWorkspace(&CreateWorkspaceRequest{workspace})
+
+	rule := &DataAccessRule{Workspace: workspace, Layer: "*", AccessMode: AccessModeRead, Roles: []string{"ROLE_AUTHENTICATED"}}
+	err := suite.underTest.AddDataAccessRules([]*DataAccessRule{rule})
+	assert.NoError(suite.T(), err)
+
+	rule.Roles = []string{"ROLE_AUTHENTICATED", "ADMIN"}
+	err = suite.underTest.ReplaceDataAccessRules([]*DataAccessRule{rule})
+	assert.NoError(suite.T(), err)
+
+	rules, err := suite.underTest.GetDataAccessRules()
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), rules.Rules, rule)
+
+	err = suite.underTest.DeleteDataAccessRule(workspace, "*", AccessModeRead)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestCatalogModeCanBeChanged() {
+	err := suite.underTest.SetCatalogMode(CatalogModeChallenge)
+	assert.NoError(suite.T(), err)
+
+	mode, err := suite.underTest.GetCatalogMode()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), CatalogModeChallenge, mode)
+
+	err = suite.underTest.SetCatalogMode(CatalogModeHide)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestServiceAccessRulesCanBeManaged() {
+	rule := &ServiceAccessRule{Service: "wfs", Method: "Transaction", Roles: []string{"ROLE_EDITOR"}}
+	err := suite.underTest.AddServiceAccessRules([]*ServiceAccessRule{rule})
+	assert.NoError(suite.T(), err)
+
+	rule.Roles = []string{"ROLE_EDITOR", "ADMIN"}
+	err = suite.underTest.ReplaceServiceAccessRules([]*ServiceAccessRule{rule})
+	assert.NoError(suite.T(), err)
+
+	rules, err := suite.underTest.GetServiceAccessRules()
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), rules.Rules, rule)
+
+	err = suite.underTest.DeleteServiceAccessRule("wfs", "Transaction")
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestRestAccessRulesCanBeManaged() {
+	rule := &RestAccessRule{URLPattern: "/rest/workspaces/d41d8cd98/**", Methods: []string{"GET"}, Roles: []string{"ADMIN"}}
+	err := suite.underTest.AddRestAccessRules([]*RestAccessRule{rule})
+	assert.NoError(suite.T(), err)
+
+	rules, err := suite.underTest.GetRestAccessRules()
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), rules.Rules, rule)
+
+	err = suite.underTest.DeleteRestAccessRule(rule.URLPattern, rule.Methods)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestTileLayerCanBeConfigured() {
+	workspace := "d41d8cd98"
+	suite.underTest.CreateWorkspace(&CreateWorkspaceRequest{workspace})
+
+	datastore := "98ecf8427"
+	suite.underTest.CreateDatastore(&CreateDatastoreRequest{
+		Name:        datastore,
+		Description: "9800998ecf84",
+		Type:        postgresDatastoreType,
+		Workspace:   workspace,
+		ConnectionDetails: newGeoserverPostgisConnectionDetails(
+			suite.postgresConnectionDetails.Container,
+			suite.postgresConnectionDetails.Port,
+			suite.postgresConnectionDetails.Username,
+			suite.postgresConnectionDetails.Password,
+			testSchema,
+			testDatabase,
+		),
+	})
+
+	layerName := "test_data"
+	suite.underTest.CreateFeatureType(&CreateFeatureTypeRequest{
+		Name:       layerName,
+		NativeName: layerName,
+		Title:      layerName,
+		SRS:        "EPSG:26910",
+		DataStore:  datastore,
+		Workspace:  workspace,
+	})
+
+	tileLayerName := workspace + ":" + layerName
+	tileLayer, err := suite.underTest.GetTileLayer(tileLayerName)
+	assert.NoError(suite.T(), err)
+
+	zoomStop := 10
+	tileLayer.MimeFormats = []string{"image/png"}
+	tileLayer.GridSubsets = []*GridSubset{{GridSetName: "EPSG:4326", ZoomStop: &zoomStop}}
+	tileLayer.MetaTilingX = 2
+	tileLayer.MetaTilingY = 2
+	tileLayer.Gutter = 10
+	tileLayer.ParameterFilters = []*ParameterFilter{{Type: ParameterFilterTypeString, Key: "ENV", DefaultValue: "a", Values: []string{"a", "b"}}}
+	err = suite.underTest.PutTileLayer(tileLayer)
+	assert.NoError(suite.T(), err)
+
+	tileLayers, err := suite.underTest.ListTileLayers()
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), tileLayers.Layers, tileLayerName)
+
+	updated, err := suite.underTest.GetTileLayer(tileLayerName)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 10, updated.Gutter)
+	assert.Equal(suite.T(), tileLayer.ParameterFilters, updated.ParameterFilters)
+
+	err = suite.underTest.DeleteTileLayer(tileLayerName)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestLayerCanBeSeededAndTruncated() {
+	workspace := "d41d8cd98"
+	suite.underTest.CreateWorkspace(&CreateWorkspaceRequest{workspace})
+
+	datastore := "98ecf8427"
+	suite.underTest.CreateDatastore(&CreateDatastoreRequest{
+		Name:        datastore,
+		Description: "9800998ecf84",
+		Type:        postgresDatastoreType,
+		Workspace:   workspace,
+		ConnectionDetails: newGeoserverPostgisConnectionDetails(
+			suite.postgresConnectionDetails.Container,
+			suite.postgresConnectionDetails.Port,
+			suite.postgresConnectionDetails.Username,
+			suite.postgresConnectionDetails.Password,
+			testSchema,
+			testDatabase,
+		),
+	})
+
+	layerName := "test_data"
+	suite.underTest.CreateFeatureType(&CreateFeatureTypeRequest{
+		Name:       layerName,
+		NativeName: layerName,
+		Title:      layerName,
+		SRS:        "EPSG:26910",
+		DataStore:  datastore,
+		Workspace:  workspace,
+	})
+
+	request := &SeedRequest{
+		Layer:     workspace + ":" + layerName,
+		GridSetID: "EPSG:4326",
+		ZoomStart: 0,
+		ZoomStop:  2,
+		Format:    "image/png",
+	}
+	err := suite.underTest.Seed(request)
+	assert.NoError(suite.T(), err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	status, err := suite.underTest.WaitForSeed(ctx, request.Layer, time.Second)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), status.IsComplete())
+
+	err = suite.underTest.Truncate(request)
+	assert.NoError(suite.T(), err)
+
+	err = suite.underTest.KillSeedTasks(request.Layer, KillAllSeedTasks)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestGridSetsCanBeManaged() {
+	extent := &BoundingBox{MinX: 0, MinY: 0, MaxX: 700000, MaxY: 1300000}
+	gridSet := &GridSet{
+		Name:          "British_National_Grid",
+		SRS:           "EPSG:27700",
+		Extent:        extent,
+		Resolutions:   ResolutionsForExtent(extent, 256, 256, 10),
+		MetersPerUnit: 1,
+		PixelSize:     defaultPixelSize,
+		TileWidth:     256,
+		TileHeight:    256,
+	}
+	err := suite.underTest.PutGridSet(gridSet)
+	assert.NoError(suite.T(), err)
+
+	gridSets, err := suite.underTest.ListGridSets()
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), gridSets.GridSets, gridSet.Name)
+
+	created, err := suite.underTest.GetGridSet(gridSet.Name)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), gridSet.Resolutions, created.Resolutions)
+
+	err = suite.underTest.DeleteGridSet(gridSet.Name)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestBlobStoresCanBeManaged() {
+	blobStore := &FileBlobStore{
+		BlobStoreInfo:       BlobStoreInfo{ID: "a87ff679a", Enabled: true},
+		BaseDirectory:       "/tmp/a87ff679a",
+		FileSystemBlockSize: 4096,
+	}
+	err := suite.underTest.PutBlobStore(blobStore)
+	assert.NoError(suite.T(), err)
+
+	blobStores, err := suite.underTest.ListBlobStores()
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), blobStores.BlobStores, blobStore.ID)
+
+	created, err := suite.underTest.GetBlobStore(blobStore.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), blobStore, created)
+
+	err = suite.underTest.DeleteBlobStore(blobStore.ID)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestDiskQuotaCanBeConfigured() {
+	diskQuota := &DiskQuota{
+		Enabled:                true,
+		CleanUpFrequency:       10 * time.Minute,
+		MaxConcurrentCleanUps:  2,
+		GlobalExpirationPolicy: ExpirationPolicyLRU,
+		GlobalQuota:            &Quota{Value: 1, Units: StorageUnitGiB},
+	}
+	err := suite.underTest.SetDiskQuota(diskQuota)
+	assert.NoError(suite.T(), err)
+
+	updated, err := suite.underTest.GetDiskQuota()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), diskQuota.CleanUpFrequency, updated.CleanUpFrequency)
+	assert.Equal(suite.T(), diskQuota.GlobalExpirationPolicy, updated.GlobalExpirationPolicy)
+
+	diskQuota.Enabled = false
+	err = suite.underTest.SetDiskQuota(diskQuota)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestSettingsCanBeManaged() {
+	settings, err := suite.underTest.GetSettings()
+	assert.NoError(suite.T(), err)
+
+	originalProxyBaseURL := settings.ProxyBaseURL
+	settings.ProxyBaseURL = "http://maps.example.com/geoserver"
+	err = suite.underTest.UpdateSettings(settings)
+	assert.NoError(suite.T(), err)
+
+	updated, err := suite.underTest.GetSettings()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), settings.ProxyBaseURL, updated.ProxyBaseURL)
+
+	settings.ProxyBaseURL = originalProxyBaseURL
+	err = suite.underTest.UpdateSettings(settings)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestWorkspaceSettingsCanBeProvisionedWithTheWorkspace() {
+	workspaceName := "d41d8cd98"
+	err := suite.underTest.CreateWorkspaceWithSettings(&CreateWorkspaceRequest{workspaceName}, &WorkspaceSettings{
+		Charset:     "UTF-8",
+		NumDecimals: 4,
+		Contact:     &ContactInfo{Person: "Jane Doe", Email: "jane@example.com"},
+	})
+	assert.NoError(suite.T(), err)
+
+	settings, err := suite.underTest.GetWorkspaceSettings(workspaceName)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), workspaceName, settings.Workspace)
+	assert.Equal(suite.T(), 4, settings.NumDecimals)
+	assert.Equal(suite.T(), "Jane Doe", settings.Contact.Person)
+
+	settings.NumDecimals = 6
+	err = suite.underTest.UpdateWorkspaceSettings(workspaceName, settings)
+	assert.NoError(suite.T(), err)
+
+	err = suite.underTest.DeleteWorkspaceSettings(workspaceName)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestServiceSettingsCanBeManagedPerWorkspace() {
+	workspaceName := "d41d8cd98"
+	err := suite.underTest.CreateWorkspace(&CreateWorkspaceRequest{workspaceName})
+	assert.NoError(suite.T(), err)
+
+	wfsSettings, err := suite.underTest.GetWFSSettings("")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), wfsSettings.MaxFeatures > 0)
+
+	wfsSettings.MaxFeatures = 500
+	wfsSettings.SRS = []string{"EPSG:4326", "EPSG:3857"}
+	err = suite.underTest.UpdateWFSSettings(workspaceName, wfsSettings)
+	assert.NoError(suite.T(), err)
+
+	workspaceWFSSettings, err := suite.underTest.GetWFSSettings(workspaceName)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), workspaceName, workspaceWFSSettings.Workspace)
+	assert.Equal(suite.T(), 500, workspaceWFSSettings.MaxFeatures)
+
+	err = suite.underTest.DeleteWFSSettings(workspaceName)
+	assert.NoError(suite.T(), err)
+
+	wmsSettings, err := suite.underTest.GetWMSSettings("")
+	assert.NoError(suite.T(), err)
+
+	wmsSettings.MaxRenderingTime = 30 * time.Second
+	wmsSettings.Watermark = &Watermark{Enabled: false, Position: WatermarkBottomRight, Transparency: 50}
+	err = suite.underTest.UpdateWMSSettings(workspaceName, wmsSettings)
+	assert.NoError(suite.T(), err)
+
+	workspaceWMSSettings, err := suite.underTest.GetWMSSettings(workspaceName)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), wmsSettings.MaxRenderingTime, workspaceWMSSettings.MaxRenderingTime)
+
+	err = suite.underTest.DeleteWMSSettings(workspaceName)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestLoggingIsRaisedForTheDurationOfACallback() {
+	previous, err := suite.underTest.GetLoggingSettings()
+	assert.NoError(suite.T(), err)
+
+	err = suite.underTest.WithLoggingProfile(LoggingVerbose, func() error {
+		during, err := suite.underTest.GetLoggingSettings()
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), LoggingVerbose, during.Level)
+		return err
+	})
+	assert.NoError(suite.T(), err)
+
+	after, err := suite.underTest.GetLoggingSettings()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), previous, after)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestCatalogCanBeReloadedAndReset() {
+	err := suite.underTest.Reset()
+	assert.NoError(suite.T(), err)
+
+	err = suite.underTest.ReloadAndWait(time.Minute, time.Second)
+	assert.NoError(suite.T(), err)
+
+	isHealthy, err := suite.underTest.IsHealthOk()
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), isHealthy)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestVersionStatusAndManifestAreReported() {
+	version, err := suite.underTest.GetVersion()
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), version.GeoServer.Version)
+	assert.NotNil(suite.T(), version.GeoTools)
+
+	status, err := suite.underTest.GetStatus()
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), status.Modules)
+
+	manifest, err := suite.underTest.GetManifest()
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), manifest.Entries)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestServerVersionIsDetected() {
+	version, err := suite.underTest.ServerVersion()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, version.Major)
+	assert.True(suite.T(), version.AtLeast(2, 10))
+}
+
+func (suite *RestGeoserverClientTestSuite) TestDataDirectoryResourcesCanBeManaged() {
+	err := suite.underTest.UploadResource("/d41d8cd98/icons/marker.svg", strings.NewReader("<svg/>"))
+	assert.NoError(suite.T(), err)
+
+	err = suite.underTest.CopyResource("d41d8cd98/icons/marker.svg", "d41d8cd98/icons/copy.svg")
+	assert.NoError(suite.T(), err)
+
+	err = suite.underTest.MoveResource("d41d8cd98/icons/copy.svg", "d41d8cd98/icons/moved.svg")
+	assert.NoError(suite.T(), err)
+
+	directory, err := suite.underTest.ListResourceDirectory("d41d8cd98/icons")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), directory.Children, 2)
+
+	info, err := suite.underTest.GetResourceMetadata("d41d8cd98/icons/moved.svg")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), ResourceTypeFile, info.Type)
+
+	content := &bytes.Buffer{}
+	err = suite.underTest.DownloadResource("d41d8cd98/icons/moved.svg", content)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "<svg/>", content.String())
+
+	err = suite.underTest.DeleteResource("d41d8cd98")
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestTemplatesAreResolvedFromTheMostSpecificLevel() {
+	workspace := "d41d8cd98"
+	suite.underTest.CreateWorkspace(&CreateWorkspaceRequest{workspace})
+
+	datastore := "98ecf8427"
+	suite.underTest.CreateDatastore(&CreateDatastoreRequest{
+		Name:        datastore,
+		Description: "9800998ecf84",
+		Type:        postgresDatastoreType,
+		Workspace:   workspace,
+		ConnectionDetails: newGeoserverPostgisConnectionDetails(
+			suite.postgresConnectionDetails.Container,
+			suite.postgresConnectionDetails.Port,
+			suite.postgresConnectionDetails.Username,
+			suite.postgresConnectionDetails.Password,
+			testSchema,
+			testDatabase,
+		),
+	})
+
+	layerName := "test_data"
+	suite.underTest.CreateFeatureType(&CreateFeatureTypeRequest{
+		Name:       layerName,
+		NativeName: layerName,
+		Title:      layerName,
+		SRS:        "EPSG:26910",
+		DataStore:  datastore,
+		Workspace:  workspace,
+	})
+
+	workspaceScope := &TemplateScope{Workspace: workspace}
+	err := suite.underTest.PutTemplate(workspaceScope, "content", "<p>${type.name}</p>")
+	assert.NoError(suite.T(), err)
+
+	names, err := suite.underTest.ListTemplates(workspaceScope)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"content.ftl"}, names)
+
+	template, err := suite.underTest.ResolveTemplate(workspace, datastore, layerName, "content.ftl")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), workspaceScope, template.Scope)
+	assert.Equal(suite.T(), "<p>${type.name}</p>", template.Content)
+
+	featureTypeScope := &TemplateScope{Workspace: workspace, DataStore: datastore, FeatureType: layerName}
+	err = suite.underTest.PutTemplate(featureTypeScope, "content.ftl", "<p>${type.title}</p>")
+	assert.NoError(suite.T(), err)
+
+	template, err = suite.underTest.ResolveTemplate(workspace, datastore, layerName, "content.ftl")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), featureTypeScope, template.Scope)
+
+	err = suite.underTest.DeleteTemplate(featureTypeScope, "content.ftl")
+	assert.NoError(suite.T(), err)
+	err = suite.underTest.DeleteTemplate(workspaceScope, "content.ftl")
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestSLDIsValidatedAgainstTheFeatureType() {
+	workspace := "a1f0c3e77"
+	suite.underTest.CreateWorkspace(&CreateWorkspaceRequest{workspace})
+
+	datastore := "0c3e77a1f"
+	suite.underTest.CreateDatastore(&CreateDatastoreRequest{
+		Name:        datastore,
+		Description: "77a1f0c3e0c3",
+		Type:        postgresDatastoreType,
+		Workspace:   workspace,
+		ConnectionDetails: newGeoserverPostgisConnectionDetails(
+			suite.postgresConnectionDetails.Container,
+			suite.postgresConnectionDetails.Port,
+			suite.postgresConnectionDetails.Username,
+			suite.postgresConnectionDetails.Password,
+			testSchema,
+			testDatabase,
+		),
+	})
+
+	layerName := "test_data"
+	suite.underTest.CreateFeatureType(&CreateFeatureTypeRequest{
+		Name:       layerName,
+		NativeName: layerName,
+		Title:      layerName,
+		SRS:        "EPSG:26910",
+		DataStore:  datastore,
+		Workspace:  workspace,
+	})
+
+	fonts, err := suite.underTest.GetFonts()
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), fonts)
+
+	sld := `<StyledLayerDescriptor version="1.0.0" xmlns="http://www.opengis.net/sld" xmlns:ogc="http://www.opengis.net/ogc">
+  <NamedLayer><Name>test_data</Name><UserStyle><FeatureTypeStyle><Rule>
+    <TextSymbolizer>
+      <Label><ogc:PropertyName>name</ogc:PropertyName></Label>
+      <Font><CssParameter name="font-family">SansSerif</CssParameter></Font>
+    </TextSymbolizer>
+    <TextSymbolizer>
+      <Label><ogc:PropertyName>missing</ogc:PropertyName></Label>
+      <Font><CssParameter name="font-family">Not A Font</CssParameter></Font>
+    </TextSymbolizer>
+  </Rule></FeatureTypeStyle></UserStyle></NamedLayer>
+</StyledLayerDescriptor>`
+
+	result, err := suite.underTest.ValidateSLD([]byte(sld), workspace, datastore, layerName)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"Not A Font"}, result.MissingFonts)
+	assert.Equal(suite.T(), []string{"missing"}, result.UnknownProperties)
+}
+
+func (suite *RestGeoserverClientTestSuite) TestCascadedWMSStoresCanBeManaged() {
+	workspace := "5e2b9c0d1"
+	suite.underTest.CreateWorkspace(&CreateWorkspaceRequest{workspace})
+
+	store := &CascadedStore{
+		Name:            "cascaded",
+		Workspace:       workspace,
+		Enabled:         true,
+		CapabilitiesURL: "http://localhost:8080/geoserver/wms?service=WMS&request=GetCapabilities",
+		MaxConnections:  4,
+		ReadTimeout:     time.Minute,
+		ConnectTimeout:  30 * time.Second,
+	}
+	err := suite.underTest.CreateWMSStore(store)
+	assert.NoError(suite.T(), err)
+
+	names, err := suite.underTest.ListWMSStores(workspace)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"cascaded"}, names)
+
+	store.Description = "Geoserver cascading itself"
+	store.MaxConnections = 8
+	err = suite.underTest.UpdateWMSStore(store)
+	assert.NoError(suite.T(), err)
+
+	retrieved, err := suite.underTest.GetWMSStore(workspace, "cascaded")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), store, retrieved)
+
+	err = suite.underTest.DeleteWMSStore(workspace, "cascaded")
+	assert.NoError(suite.T(), err)
+
+	names, err = suite.underTest.ListWMSStores(workspace)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), names)
+}
+
 func TestRunRestGeoserver10ClientTestSuite(t *testing.T) {
 	suite.Run(t, newRestGeoserverClientTestSuite(geoserverDockerTag10))
 }
@@ -0,0 +1,187 @@
+package geoserver
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// CreateCoverageRequest is the information required in order to publish a coverage from a coverage store.
+type CreateCoverageRequest struct {
+	// Name is the name of the coverage.
+	Name string
+
+	// NativeName is the name of the coverage in the coverage store, the name is used when it is empty.
+	NativeName string
+
+	// Title is the title of the coverage.
+	Title string
+
+	// Abstract is the abstract of the coverage.
+	Abstract string
+
+	// SRS is the SRS of the coverage, the native SRS is used when it is empty.
+	SRS string
+
+	// CoverageStore is the name of the coverage store to which the coverage belongs.
+	CoverageStore string
+
+	// Workspace is the name of the workspace to which the coverage belongs.
+	Workspace string
+
+	// Time is the configuration of the time dimension, used by WMS TIME requests. It is optional.
+	Time *DimensionInfo
+
+	// Elevation is the configuration of the elevation dimension, used by WMS ELEVATION requests. It is optional.
+	Elevation *DimensionInfo
+
+	// Keywords are the keywords describing the coverage.
+	Keywords []*Keyword
+
+	// MetadataLinks are the links to metadata records describing the coverage.
+	MetadataLinks []*MetadataLink
+
+	// DataLinks are the links to the data of the coverage.
+	DataLinks []*DataLink
+}
+
+// UpdateCoverageRequest is the information required in order to update a coverage.
+// Only the fields which are provided are updated, everything else about the coverage is left as it is.
+type UpdateCoverageRequest struct {
+	// Name is the name of the coverage to update.
+	Name string
+
+	// CoverageStore is the name of the coverage store to which the coverage belongs.
+	CoverageStore string
+
+	// Workspace is the name of the workspace to which the coverage belongs.
+	Workspace string
+
+	// Title is the new title of the coverage.
+	Title string
+
+	// Abstract is the new abstract of the coverage.
+	Abstract string
+
+	// Time is the new configuration of the time dimension.
+	Time *DimensionInfo
+
+	// Elevation is the new configuration of the elevation dimension.
+	Elevation *DimensionInfo
+
+	// Keywords replace the keywords of the coverage when provided.
+	Keywords []*Keyword
+
+	// MetadataLinks replace the metadata links of the coverage when provided.
+	MetadataLinks []*MetadataLink
+
+	// DataLinks replace the data links of the coverage when provided.
+	DataLinks []*DataLink
+}
+
+// CreateCoverage publishes a coverage from a coverage store, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) CreateCoverage(request *CreateCoverageRequest) (err error) {
+	return client.sendRestResource(http.MethodPost, client.coveragesURL(request.Workspace, request.CoverageStore)+".json",
+		coverageDescription(request.Workspace, request.CoverageStore, request.Name), newCreateCoverageRestRequest(request))
+}
+
+// UpdateCoverage updates a coverage, only changing the fields which are provided in the request.
+// The coverage is read from Geoserver first, so that the rest of its configuration is preserved.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UpdateCoverage(request *UpdateCoverageRequest) (err error) {
+	url := client.coveragesURL(request.Workspace, request.CoverageStore) + "/" + request.Name + ".json"
+	description := coverageDescription(request.Workspace, request.CoverageStore, request.Name)
+
+	document := make(map[string]map[string]interface{})
+	err = client.getRestResource(url, description, &document)
+	if err != nil {
+		return
+	}
+
+	coverage, ok := document["coverage"]
+	if !ok {
+		err = fmt.Errorf("unable to update %s, Geoserver returned an invalid response", description)
+		return
+	}
+
+	err = applyCoverageUpdate(coverage, request)
+	if err != nil {
+		return
+	}
+
+	return client.sendRestResource(http.MethodPut, url, description, document)
+}
+
+// coveragesURL creates the URL of the coverages of a coverage store
+func (client *RestGeoserverClient) coveragesURL(workspace string, coverageStore string) string {
+	return client.geoserverBaseURL + "/rest/workspaces/" + workspace + "/coveragestores/" + coverageStore + "/coverages"
+}
+
+// coverageDescription describes a coverage for logs and errors
+func coverageDescription(workspace string, coverageStore string, coverage string) string {
+	return fmt.Sprintf("coverage '%s:%s' of coverage store '%s'", workspace, coverage, coverageStore)
+}
+
+/**
+ * REST API
+ */
+
+// createCoverageRestRequest exists in order to represent the JSON required by Geoserver when creating a coverage.
+type createCoverageRestRequest struct {
+	Coverage *restCoverage `json:"coverage"`
+}
+
+// restCoverage is a Geoserver coverage used to interact with the REST API
+type restCoverage struct {
+	Name          string             `json:"name"`
+	NativeName    string             `json:"nativeName,omitempty"`
+	Title         string             `json:"title,omitempty"`
+	Abstract      string             `json:"abstract,omitempty"`
+	SRS           string             `json:"srs,omitempty"`
+	Enabled       bool               `json:"enabled"`
+	Metadata      *restMetadata      `json:"metadata,omitempty"`
+	Keywords      *restKeywords      `json:"keywords,omitempty"`
+	MetadataLinks *restMetadataLinks `json:"metadataLinks,omitempty"`
+	DataLinks     *restDataLinks     `json:"dataLinks,omitempty"`
+}
+
+// newCreateCoverageRestRequest converts a CreateCoverageRequest into a createCoverageRestRequest
+func newCreateCoverageRestRequest(request *CreateCoverageRequest) *createCoverageRestRequest {
+	coverage := &restCoverage{
+		Name:          request.Name,
+		NativeName:    request.NativeName,
+		Title:         request.Title,
+		Abstract:      request.Abstract,
+		SRS:           request.SRS,
+		Enabled:       true,
+		Keywords:      newRestKeywords(request.Keywords),
+		MetadataLinks: newRestMetadataLinks(request.MetadataLinks),
+		DataLinks:     newRestDataLinks(request.DataLinks),
+	}
+
+	entries := newDimensionMetadataEntries(request.Time, request.Elevation)
+	if len(entries) > 0 {
+		coverage.Metadata = &restMetadata{entries}
+	}
+
+	return &createCoverageRestRequest{coverage}
+}
+
+// applyCoverageUpdate applies the fields provided in the update request to the JSON representation of a coverage.
+func applyCoverageUpdate(coverage map[string]interface{}, request *UpdateCoverageRequest) error {
+	if request.Title != "" {
+		coverage["title"] = request.Title
+	}
+	if request.Abstract != "" {
+		coverage["abstract"] = request.Abstract
+	}
+
+	applyResourceInfoUpdate(coverage, request.Keywords, request.MetadataLinks, request.DataLinks)
+
+	for _, entry := range newDimensionMetadataEntries(request.Time, request.Elevation) {
+		if err := setMetadataEntry(coverage, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
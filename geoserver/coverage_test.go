@@ -0,0 +1,84 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateCoverageSendsTheDimensions(t *testing.T) {
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestBody, _ := ioutil.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.Path, string(requestBody)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(NewStdOutLogger(), &http.Client{}, server.URL, "admin", "geoserver")
+
+	err := client.CreateCoverage(&CreateCoverageRequest{
+		Name:          "temperature",
+		Title:         "Sea surface temperature",
+		CoverageStore: "sst",
+		Workspace:     "ocean",
+		Time:          &DimensionInfo{Enabled: true, Presentation: PresentationList},
+		Elevation:     &DimensionInfo{Enabled: true, Units: "EPSG:5030", UnitSymbol: "m"},
+		Keywords:      []*Keyword{{Value: "temperature"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/rest/workspaces/ocean/coveragestores/sst/coverages.json", path)
+	assert.JSONEq(t, `{"coverage":{"name":"temperature","title":"Sea surface temperature","enabled":true,"metadata":{"entry":[`+
+		`{"@key":"time","dimensionInfo":{"enabled":true,"presentation":"LIST","nearestMatchEnabled":false}},`+
+		`{"@key":"elevation","dimensionInfo":{"enabled":true,"units":"EPSG:5030","unitSymbol":"m","nearestMatchEnabled":false}}]},`+
+		`"keywords":{"string":["temperature"]}}}`, body)
+}
+
+func TestUpdateCoveragePreservesTheRestOfTheCoverage(t *testing.T) {
+	var updated map[string]map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/workspaces/ocean/coveragestores/sst/coverages/temperature.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"coverage":{"name":"temperature","title":"SST","nativeFormat":"NetCDF",` +
+				`"metadata":{"entry":{"@key":"dirName","$":"sst_temperature"}}}}`))
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&updated)
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(NewStdOutLogger(), &http.Client{}, server.URL, "admin", "geoserver")
+
+	err := client.UpdateCoverage(&UpdateCoverageRequest{
+		Name:          "temperature",
+		CoverageStore: "sst",
+		Workspace:     "ocean",
+		Abstract:      "Daily sea surface temperature",
+		Time:          &DimensionInfo{Enabled: true, Presentation: PresentationContinuousInterval},
+		DataLinks:     []*DataLink{{Format: "text/html", URL: "http://example.com/sst"}},
+	})
+	assert.NoError(t, err)
+
+	coverage := updated["coverage"]
+	assert.Equal(t, "SST", coverage["title"])
+	assert.Equal(t, "Daily sea surface temperature", coverage["abstract"])
+	assert.Equal(t, "NetCDF", coverage["nativeFormat"])
+	assert.Equal(t, map[string]interface{}{"org.geoserver.catalog.impl.DataLinkInfoImpl": []interface{}{
+		map[string]interface{}{"type": "text/html", "content": "http://example.com/sst"},
+	}}, coverage["dataLinks"])
+
+	entries := coverage["metadata"].(map[string]interface{})["entry"].([]interface{})
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "dirName", entries[0].(map[string]interface{})["@key"])
+	assert.Equal(t, "time", entries[1].(map[string]interface{})["@key"])
+
+	err = client.UpdateCoverage(&UpdateCoverageRequest{Name: "missing", CoverageStore: "sst", Workspace: "ocean"})
+	assert.Error(t, err)
+}
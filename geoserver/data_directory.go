@@ -0,0 +1,427 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrInvalidResourcePath is returned when a resource path is empty or could escape the data directory
+var ErrInvalidResourcePath = errors.New("the resource path is empty or escapes the data directory")
+
+// ResourceType is the type of a resource in the data directory
+type ResourceType string
+
+const (
+	// ResourceTypeFile is a file
+	ResourceTypeFile ResourceType = "resource"
+
+	// ResourceTypeDirectory is a directory
+	ResourceTypeDirectory ResourceType = "directory"
+
+	// ResourceTypeUndefined is a resource which does not exist
+	ResourceTypeUndefined ResourceType = "undefined"
+)
+
+const (
+	// resourceOperationMove moves a resource
+	resourceOperationMove = "move"
+
+	// resourceOperationCopy copies a resource
+	resourceOperationCopy = "copy"
+)
+
+// resourceTimeLayouts are the layouts Geoserver uses for the modification time of resources
+var resourceTimeLayouts = []string{
+	"2006-01-02 15:04:05.0 MST",
+	"2006-01-02 15:04:05.999 MST",
+	"2006-01-02 15:04:05 MST",
+	time.RFC1123,
+}
+
+// ResourceInfo is the metadata of a resource in the data directory
+type ResourceInfo struct {
+	// Name is the name of the resource e.g "point.sld".
+	Name string
+
+	// Path is the path of the resource, relative to the data directory e.g "styles/point.sld".
+	Path string
+
+	// Type is the type of the resource.
+	Type ResourceType
+
+	// LastModified is when the resource was last modified, it is zero when Geoserver does not report it.
+	LastModified time.Time
+}
+
+// ResourceChild is an entry of a directory in the data directory
+type ResourceChild struct {
+	// Name is the name of the entry.
+	Name string
+
+	// Path is the path of the entry, relative to the data directory.
+	Path string
+
+	// ContentType is the content type Geoserver serves the entry with.
+	ContentType string
+}
+
+// ResourceDirectory is a directory in the data directory and its entries
+type ResourceDirectory struct {
+	ResourceInfo
+
+	// Children are the entries of the directory.
+	Children []*ResourceChild
+}
+
+// GetResourceMetadata gets the metadata of a resource in the data directory, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetResourceMetadata(path string) (info *ResourceInfo, err error) {
+	resourceURL, cleanPath, err := client.resourceURL(path)
+	if err != nil {
+		return
+	}
+
+	restResponse := &restResourceMetadataWrapper{}
+	err = client.getRestResource(resourceURL+"?operation=metadata&format=json", fmt.Sprintf("the metadata of resource '%s'", cleanPath), restResponse)
+	if err != nil {
+		return
+	}
+
+	if restResponse.Metadata == nil {
+		err = fmt.Errorf("unable to get the metadata of resource '%s', Geoserver returned an invalid response", cleanPath)
+		return
+	}
+
+	info = &ResourceInfo{
+		Name:         restResponse.Metadata.Name,
+		Path:         cleanPath,
+		Type:         restResponse.Metadata.Type,
+		LastModified: parseResourceTime(restResponse.Metadata.LastModified),
+	}
+	return
+}
+
+// ListResourceDirectory lists a directory in the data directory, returning an error if it is not possible.
+// The data directory itself is listed when the path is "/".
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) ListResourceDirectory(path string) (directory *ResourceDirectory, err error) {
+	resourceURL, cleanPath, err := client.resourceDirectoryURL(path)
+	if err != nil {
+		return
+	}
+
+	restResponse := &restResourceDirectoryWrapper{}
+	err = client.getRestResource(resourceURL+"?operation=default&format=json", fmt.Sprintf("directory '%s'", cleanPath), restResponse)
+	if err != nil {
+		return
+	}
+
+	if restResponse.Directory == nil {
+		err = fmt.Errorf("unable to list directory '%s', it is not a directory", cleanPath)
+		return
+	}
+
+	directory = &ResourceDirectory{
+		ResourceInfo: ResourceInfo{
+			Name:         restResponse.Directory.Name,
+			Path:         cleanPath,
+			Type:         ResourceTypeDirectory,
+			LastModified: parseResourceTime(restResponse.Directory.LastModified),
+		},
+		Children: make([]*ResourceChild, 0),
+	}
+	for _, child := range restResponse.Directory.Children.Children {
+		resourceChild := &ResourceChild{Name: child.Name, Path: joinResourcePath(cleanPath, child.Name)}
+		if child.Link != nil {
+			resourceChild.ContentType = child.Link.Type
+		}
+		directory.Children = append(directory.Children, resourceChild)
+	}
+	return
+}
+
+// DownloadResource streams a file in the data directory to the destination, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DownloadResource(path string, destination io.Writer) (err error) {
+	resourceURL, cleanPath, err := client.resourceURL(path)
+	if err != nil {
+		return
+	}
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Downloading a resource from the Geoserver data directory",
+		urlKey, resourceURL,
+	)
+
+	req, err := client.createAuthRequest(http.MethodGet, resourceURL, applicationOctetStream, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Del(acceptHeader)
+
+	response, err := client.httpClient.Do(req)
+	if err != nil {
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "Could not communicate with Geoserver",
+			urlKey, resourceURL,
+			errorKey, err.Error(),
+		)
+		return
+	}
+	defer response.Body.Close()
+
+	if httpCodeOK != response.StatusCode {
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		client.logUnexpectedResponse("Unable to download resource", resourceURL, response.StatusCode, responseBody)
+		err = fmt.Errorf("unable to download resource '%s'", cleanPath)
+		return
+	}
+
+	_, err = io.Copy(destination, response.Body)
+	return
+}
+
+// UploadResource streams content to a file in the data directory, creating or replacing it,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UploadResource(path string, content io.Reader) (err error) {
+	resourceURL, cleanPath, err := client.resourceURL(path)
+	if err != nil {
+		return
+	}
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Uploading a resource to the Geoserver data directory",
+		urlKey, resourceURL,
+	)
+
+	req, err := client.createAuthRequest(http.MethodPut, resourceURL, applicationOctetStream, content)
+	if err != nil {
+		return
+	}
+
+	statusCode, responseBody, err := client.doRequest(req)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode || codeCreated == statusCode {
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to upload resource", resourceURL, statusCode, responseBody)
+	err = fmt.Errorf("unable to upload resource '%s'", cleanPath)
+	return
+}
+
+// MoveResource moves a file or directory within the data directory, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) MoveResource(source string, destination string) (err error) {
+	return client.transferResource(resourceOperationMove, source, destination)
+}
+
+// CopyResource copies a file within the data directory, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) CopyResource(source string, destination string) (err error) {
+	return client.transferResource(resourceOperationCopy, source, destination)
+}
+
+// DeleteResource deletes a file or directory in the data directory, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteResource(path string) (err error) {
+	resourceURL, cleanPath, err := client.resourceURL(path)
+	if err != nil {
+		return
+	}
+
+	return client.deleteRestResource(resourceURL, fmt.Sprintf("resource '%s'", cleanPath))
+}
+
+// transferResource moves or copies a resource, which Geoserver does by putting the source path to the destination
+func (client *RestGeoserverClient) transferResource(operation string, source string, destination string) (err error) {
+	_, cleanSource, err := client.resourceURL(source)
+	if err != nil {
+		return
+	}
+
+	resourceURL, cleanDestination, err := client.resourceURL(destination)
+	if err != nil {
+		return
+	}
+	resourceURL += "?operation=" + operation
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Transferring a resource within the Geoserver data directory",
+		urlKey, resourceURL,
+		"source", cleanSource,
+	)
+
+	req, err := client.createAuthRequest(http.MethodPut, resourceURL, textPlain, strings.NewReader("/"+cleanSource))
+	if err != nil {
+		return
+	}
+
+	statusCode, responseBody, err := client.doRequest(req)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode || codeCreated == statusCode {
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to "+operation+" resource", resourceURL, statusCode, responseBody)
+	err = fmt.Errorf("unable to %s resource '%s' to '%s'", operation, cleanSource, cleanDestination)
+	return
+}
+
+// resourceURL creates the URL of a resource in the data directory, returning ErrInvalidResourcePath
+// for paths which are empty or could escape the data directory
+func (client *RestGeoserverClient) resourceURL(path string) (resourceURL string, cleanPath string, err error) {
+	cleanPath, err = cleanResourcePath(path)
+	if err != nil {
+		return
+	}
+
+	if cleanPath == "" {
+		err = ErrInvalidResourcePath
+		return
+	}
+
+	resourceURL = client.geoserverBaseURL + "/rest/resource/" + (&url.URL{Path: cleanPath}).EscapedPath()
+	return
+}
+
+// resourceDirectoryURL creates the URL of a directory in the data directory, which may be the data directory itself
+func (client *RestGeoserverClient) resourceDirectoryURL(path string) (resourceURL string, cleanPath string, err error) {
+	cleanPath, err = cleanResourcePath(path)
+	if err != nil {
+		return
+	}
+
+	resourceURL = client.geoserverBaseURL + "/rest/resource/" + (&url.URL{Path: cleanPath}).EscapedPath()
+	return
+}
+
+// cleanResourcePath removes the leading and trailing slashes of a path, returning ErrInvalidResourcePath when a
+// segment of the path is empty, refers to the current or parent directory, or contains a backslash or NUL,
+// as these could be used to escape the data directory
+func cleanResourcePath(path string) (cleanPath string, err error) {
+	cleanPath = strings.Trim(path, "/")
+	if cleanPath == "" {
+		return
+	}
+
+	// Servlet containers drop path parameters, everything after a ";" in a segment, so "..;" is resolved as ".."
+	for _, segment := range strings.Split(cleanPath, "/") {
+		trimmed := strings.TrimSpace(segment)
+		if trimmed == "" || trimmed == "." || trimmed == ".." || strings.ContainsAny(segment, "\\;\x00") {
+			cleanPath = ""
+			err = ErrInvalidResourcePath
+			return
+		}
+	}
+	return
+}
+
+// joinResourcePath joins the path of a directory and the name of one of its entries
+func joinResourcePath(directory string, name string) string {
+	if directory == "" {
+		return name
+	}
+	return directory + "/" + name
+}
+
+// parseResourceTime parses the modification time of a resource, returning the zero time when it is not recognised
+func parseResourceTime(value string) time.Time {
+	for _, layout := range resourceTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
+
+/**
+ * REST API
+ */
+
+// restResourceMetadataWrapper exists in order to represent the JSON returned by Geoserver for the metadata of a resource
+type restResourceMetadataWrapper struct {
+	Metadata *restResourceMetadata `json:"ResourceMetadata"`
+}
+
+// restResourceMetadata is the metadata of a resource
+type restResourceMetadata struct {
+	Name         string       `json:"name"`
+	LastModified string       `json:"lastModified"`
+	Type         ResourceType `json:"type"`
+}
+
+// restResourceDirectoryWrapper exists in order to represent the JSON returned by Geoserver for a directory
+type restResourceDirectoryWrapper struct {
+	Directory *restResourceDirectory `json:"ResourceDirectory"`
+}
+
+// restResourceDirectory is a directory and its entries
+type restResourceDirectory struct {
+	Name         string                `json:"name"`
+	LastModified string                `json:"lastModified"`
+	Children     restResourceChildList `json:"children"`
+}
+
+// restResourceChildList holds the entries of a directory, Geoserver encodes it as an empty string when there are none
+type restResourceChildList struct {
+	Children restResourceChildren `json:"child"`
+}
+
+// restResourceChildren are the entries of a directory, which Geoserver encodes as a single object when there is only one
+type restResourceChildren []*restResourceChild
+
+// restResourceChild is an entry of a directory
+type restResourceChild struct {
+	Name string `json:"name"`
+	Link *struct {
+		Href string `json:"href"`
+		Type string `json:"type"`
+	} `json:"link"`
+}
+
+// UnmarshalJSON unmarshals the entries of a directory, ignoring the empty string used when there are none
+func (list *restResourceChildList) UnmarshalJSON(data []byte) (err error) {
+	if strings.HasPrefix(string(data), `"`) {
+		return
+	}
+
+	var children struct {
+		Children restResourceChildren `json:"child"`
+	}
+	err = json.Unmarshal(data, &children)
+	list.Children = children.Children
+	return
+}
+
+// UnmarshalJSON unmarshals either an array of entries or a single entry
+func (children *restResourceChildren) UnmarshalJSON(data []byte) (err error) {
+	var many []*restResourceChild
+	if json.Unmarshal(data, &many) == nil {
+		*children = many
+		return
+	}
+
+	single := &restResourceChild{}
+	err = json.Unmarshal(data, single)
+	*children = restResourceChildren{single}
+	return
+}
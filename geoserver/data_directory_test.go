@@ -0,0 +1,75 @@
+package geoserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResourcePathsWhichCouldEscapeTheDataDirectoryAreRejected(t *testing.T) {
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, "http://localhost/geoserver", "admin", "geoserver")
+
+	for _, path := range []string{"", "/", "../etc/passwd", "styles/../../etc/passwd", "styles/./point.sld", "styles//point.sld", "styles\\..\\point.sld",
+		"..;/..;/etc/passwd", "styles/..;jsessionid=1/point.sld", "styles/ .. /point.sld"} {
+		_, _, err := client.resourceURL(path)
+		assert.Equal(t, ErrInvalidResourcePath, err, path)
+	}
+
+	resourceURL, cleanPath, err := client.resourceURL("/styles/my point.sld")
+	assert.NoError(t, err)
+	assert.Equal(t, "styles/my point.sld", cleanPath)
+	assert.Equal(t, "http://localhost/geoserver/rest/resource/styles/my%20point.sld", resourceURL)
+
+	resourceURL, _, err = client.resourceDirectoryURL("/")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost/geoserver/rest/resource/", resourceURL)
+}
+
+func TestResourceDirectoryChildrenCanBeASingleObjectOrEmpty(t *testing.T) {
+	restResponse := &restResourceDirectoryWrapper{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"ResourceDirectory":{"name":"styles","lastModified":"2017-10-16 10:44:05.0 UTC",`+
+		`"children":{"child":{"name":"point.sld","link":{"href":"http://localhost/geoserver/rest/resource/styles/point.sld",`+
+		`"rel":"alternate","type":"application/octet-stream"}}}}}`), restResponse))
+	assert.Len(t, restResponse.Directory.Children.Children, 1)
+	assert.Equal(t, time.Date(2017, 10, 16, 10, 44, 5, 0, time.UTC), parseResourceTime(restResponse.Directory.LastModified))
+
+	restResponse = &restResourceDirectoryWrapper{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"ResourceDirectory":{"name":"empty","children":""}}`), restResponse))
+	assert.Empty(t, restResponse.Directory.Children.Children)
+}
+
+func TestResourcesAreStreamedAndTransferred(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+		if r.Method == http.MethodGet {
+			w.Write([]byte("<svg/>"))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	assert.NoError(t, client.UploadResource("icons/marker.svg", strings.NewReader("<svg/>")))
+	assert.NoError(t, client.MoveResource("icons/marker.svg", "icons/pin.svg"))
+
+	content := &bytes.Buffer{}
+	assert.NoError(t, client.DownloadResource("icons/pin.svg", content))
+	assert.Equal(t, "<svg/>", content.String())
+
+	assert.Equal(t, ErrInvalidResourcePath, client.CopyResource("../secrets", "icons/secrets"))
+	assert.Equal(t, []string{
+		"PUT /rest/resource/icons/marker.svg <svg/>",
+		"PUT /rest/resource/icons/pin.svg?operation=move /icons/marker.svg",
+		"GET /rest/resource/icons/pin.svg ",
+	}, requests)
+}
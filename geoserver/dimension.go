@@ -0,0 +1,143 @@
+package geoserver
+
+const (
+	// timeMetadataKey is the metadata key Geoserver uses for the time dimension
+	timeMetadataKey = "time"
+
+	// elevationMetadataKey is the metadata key Geoserver uses for the elevation dimension
+	elevationMetadataKey = "elevation"
+)
+
+// DimensionPresentation is how the values of a dimension are advertised in the WMS capabilities
+type DimensionPresentation string
+
+const (
+	// PresentationList advertises every value of the dimension
+	PresentationList DimensionPresentation = "LIST"
+
+	// PresentationContinuousInterval advertises the dimension as a range between its minimum and maximum
+	PresentationContinuousInterval DimensionPresentation = "CONTINUOUS_INTERVAL"
+
+	// PresentationDiscreteInterval advertises the dimension as a range with a resolution
+	PresentationDiscreteInterval DimensionPresentation = "DISCRETE_INTERVAL"
+)
+
+// DefaultValueStrategy is how Geoserver chooses the value of a dimension when a request does not provide one
+type DefaultValueStrategy string
+
+const (
+	// StrategyMinimum uses the smallest value of the dimension
+	StrategyMinimum DefaultValueStrategy = "MINIMUM"
+
+	// StrategyMaximum uses the largest value of the dimension
+	StrategyMaximum DefaultValueStrategy = "MAXIMUM"
+
+	// StrategyNearest uses the value of the dimension nearest to the reference value
+	StrategyNearest DefaultValueStrategy = "NEAREST"
+
+	// StrategyFixed uses the reference value
+	StrategyFixed DefaultValueStrategy = "FIXED"
+)
+
+// DimensionInfo is the configuration of a time or elevation dimension
+type DimensionInfo struct {
+	// Enabled is true when the dimension is enabled
+	Enabled bool
+
+	// Attribute is the attribute holding the value, or the start of the range, of the dimension
+	Attribute string
+
+	// EndAttribute is the attribute holding the end of the range of the dimension, it is optional
+	EndAttribute string
+
+	// Presentation is how the values of the dimension are advertised
+	Presentation DimensionPresentation
+
+	// Resolution is the resolution of a DISCRETE_INTERVAL presentation, in milliseconds for time dimensions
+	Resolution float64
+
+	// Units are the units of the dimension e.g "ISO8601" for time or "EPSG:5030" for elevation
+	Units string
+
+	// UnitSymbol is the symbol of the units e.g "m"
+	UnitSymbol string
+
+	// DefaultValue is how the value is chosen when a request does not provide one, Geoserver's default is used when not provided
+	DefaultValue *DimensionDefaultValue
+
+	// NearestMatchEnabled is true when requests for values which do not exist should use the nearest value
+	NearestMatchEnabled bool
+}
+
+// DimensionDefaultValue is how Geoserver chooses the value of a dimension when a request does not provide one
+type DimensionDefaultValue struct {
+	// Strategy is the strategy used to choose the value
+	Strategy DefaultValueStrategy
+
+	// ReferenceValue is the value used by the NEAREST and FIXED strategies
+	ReferenceValue string
+}
+
+/**
+ * REST API
+ */
+
+// restDimensionInfo exists in order to represent the JSON required by Geoserver when configuring a dimension
+type restDimensionInfo struct {
+	Enabled             bool                       `json:"enabled"`
+	Attribute           string                     `json:"attribute,omitempty"`
+	EndAttribute        string                     `json:"endAttribute,omitempty"`
+	Presentation        DimensionPresentation      `json:"presentation,omitempty"`
+	Resolution          float64                    `json:"resolution,omitempty"`
+	Units               string                     `json:"units,omitempty"`
+	UnitSymbol          string                     `json:"unitSymbol,omitempty"`
+	DefaultValue        *restDimensionDefaultValue `json:"defaultValue,omitempty"`
+	NearestMatchEnabled bool                       `json:"nearestMatchEnabled"`
+}
+
+// restDimensionDefaultValue is the default value strategy of a dimension
+type restDimensionDefaultValue struct {
+	Strategy       DefaultValueStrategy `json:"strategy"`
+	ReferenceValue string               `json:"referenceValue,omitempty"`
+}
+
+// newRestDimensionInfo converts a DimensionInfo into a restDimensionInfo
+func newRestDimensionInfo(dimension *DimensionInfo) *restDimensionInfo {
+	result := &restDimensionInfo{
+		Enabled:             dimension.Enabled,
+		Attribute:           dimension.Attribute,
+		EndAttribute:        dimension.EndAttribute,
+		Presentation:        dimension.Presentation,
+		Resolution:          dimension.Resolution,
+		Units:               dimension.Units,
+		UnitSymbol:          dimension.UnitSymbol,
+		NearestMatchEnabled: dimension.NearestMatchEnabled,
+	}
+
+	if dimension.DefaultValue != nil {
+		result.DefaultValue = &restDimensionDefaultValue{
+			Strategy:       dimension.DefaultValue.Strategy,
+			ReferenceValue: dimension.DefaultValue.ReferenceValue,
+		}
+	}
+
+	return result
+}
+
+// newDimensionMetadataEntries creates the metadata entries for the time and elevation dimensions which are provided
+func newDimensionMetadataEntries(time *DimensionInfo, elevation *DimensionInfo) []*restMetadataEntry {
+	entries := make([]*restMetadataEntry, 0)
+	if time != nil {
+		entries = append(entries, &restMetadataEntry{
+			Key:           timeMetadataKey,
+			DimensionInfo: newRestDimensionInfo(time),
+		})
+	}
+	if elevation != nil {
+		entries = append(entries, &restMetadataEntry{
+			Key:           elevationMetadataKey,
+			DimensionInfo: newRestDimensionInfo(elevation),
+		})
+	}
+	return entries
+}
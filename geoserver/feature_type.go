@@ -1,6 +1,9 @@
 package geoserver
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // CreateFeatureTypeRequest is the information required in order to create a feature type.
 type CreateFeatureTypeRequest struct {
@@ -33,6 +36,55 @@ type CreateFeatureTypeRequest struct {
 
 	// Workspace is the name of the workspace to which the feature type belongs.
 	Workspace string
+
+	// Time is the configuration of the time dimension, used by WMS TIME requests. It is optional.
+	Time *DimensionInfo
+
+	// Elevation is the configuration of the elevation dimension, used by WMS ELEVATION requests. It is optional.
+	Elevation *DimensionInfo
+
+	// Keywords are the keywords describing the feature type.
+	Keywords []*Keyword
+
+	// MetadataLinks are the links to metadata records describing the feature type.
+	MetadataLinks []*MetadataLink
+
+	// DataLinks are the links to the data of the feature type.
+	DataLinks []*DataLink
+}
+
+// UpdateFeatureTypeRequest is the information required in order to update a feature type.
+// Only the fields which are provided are updated, everything else about the feature type is left as it is.
+type UpdateFeatureTypeRequest struct {
+	// Name is the name of the feature type to update.
+	Name string
+
+	// DataStore is the name of the datastore to which the feature type belongs.
+	DataStore string
+
+	// Workspace is the name of the workspace to which the feature type belongs.
+	Workspace string
+
+	// Title is the new title of the feature type.
+	Title string
+
+	// Abstract is the new abstract of the feature type.
+	Abstract string
+
+	// Time is the new configuration of the time dimension.
+	Time *DimensionInfo
+
+	// Elevation is the new configuration of the elevation dimension.
+	Elevation *DimensionInfo
+
+	// Keywords replace the keywords of the feature type when provided.
+	Keywords []*Keyword
+
+	// MetadataLinks replace the metadata links of the feature type when provided.
+	MetadataLinks []*MetadataLink
+
+	// DataLinks replace the data links of the feature type when provided.
+	DataLinks []*DataLink
 }
 
 // GetFeatureTypesResponse represents a response for the get feature types request
@@ -52,6 +104,24 @@ type FeatureType struct {
 	Href string
 }
 
+// Attribute is an attribute of a feature type
+type Attribute struct {
+	// Name is the name of the attribute.
+	Name string
+
+	// MinOccurs is the minimum number of times the attribute occurs.
+	MinOccurs int
+
+	// MaxOccurs is the maximum number of times the attribute occurs.
+	MaxOccurs int
+
+	// Nillable is true when the attribute can be null.
+	Nillable bool
+
+	// Binding is the Java class of the attribute's values e.g "java.lang.String".
+	Binding string
+}
+
 // BoundingBox represents a geospatial bounding box
 type BoundingBox struct {
 	// MinX is the minimum X coordinate of the bounding box.
@@ -70,6 +140,45 @@ type BoundingBox struct {
 	CRS string
 }
 
+// GeometryType is the type of a geometry, as named by Geoserver
+type GeometryType string
+
+const (
+	// GeometryTypeGeometry is any type of geometry
+	GeometryTypeGeometry GeometryType = "Geometry"
+
+	// GeometryTypePoint is a point
+	GeometryTypePoint GeometryType = "Point"
+
+	// GeometryTypeLineString is a line string
+	GeometryTypeLineString GeometryType = "LineString"
+
+	// GeometryTypePolygon is a polygon
+	GeometryTypePolygon GeometryType = "Polygon"
+
+	// GeometryTypeMultiPoint is a collection of points
+	GeometryTypeMultiPoint GeometryType = "MultiPoint"
+
+	// GeometryTypeMultiLineString is a collection of line strings
+	GeometryTypeMultiLineString GeometryType = "MultiLineString"
+
+	// GeometryTypeMultiPolygon is a collection of polygons
+	GeometryTypeMultiPolygon GeometryType = "MultiPolygon"
+
+	// GeometryTypeGeometryCollection is a collection of geometries of any type
+	GeometryTypeGeometryCollection GeometryType = "GeometryCollection"
+)
+
+// isValid returns true if the geometry type is known to Geoserver
+func (geometryType GeometryType) isValid() bool {
+	switch geometryType {
+	case GeometryTypeGeometry, GeometryTypePoint, GeometryTypeLineString, GeometryTypePolygon,
+		GeometryTypeMultiPoint, GeometryTypeMultiLineString, GeometryTypeMultiPolygon, GeometryTypeGeometryCollection:
+		return true
+	}
+	return false
+}
+
 /**
  * REST API
  */
@@ -95,7 +204,14 @@ type restFeatureType struct {
 	// Asbtract is the abstract of the feature type.
 	Abstract string `json:"abstract"`
 
-	// TODO: Keywords
+	// Keywords are the keywords describing the feature type.
+	Keywords *restKeywords `json:"keywords,omitempty"`
+
+	// MetadataLinks are the links to metadata records describing the feature type.
+	MetadataLinks *restMetadataLinks `json:"metadataLinks,omitempty"`
+
+	// DataLinks are the links to the data of the feature type.
+	DataLinks *restDataLinks `json:"dataLinks,omitempty"`
 
 	// TODO: NativeCRS
 
@@ -112,7 +228,10 @@ type restFeatureType struct {
 	ProjectionPolicy string `json:"projectionPolicy"`
 
 	// restAttributes is the restAttributes belonging to the feature type.
-	Attributes *restAttributes `json:"attributes"`
+	Attributes *restAttributes `json:"attributes,omitempty"`
+
+	// Metadata is the metadata of the feature type e.g SQL view definitions.
+	Metadata *restMetadata `json:"metadata,omitempty"`
 
 	// Enabled is whether of not the layer is enabled.
 	Enabled bool `json:"enabled"`
@@ -144,6 +263,27 @@ type restAttribute struct {
 	Binding string `json:"binding"`
 }
 
+// restMetadata exists in order to represent the JSON required by Geoserver for the metadata of a feature type.
+type restMetadata struct {
+	// Entry are the metadata entries.
+	Entry []*restMetadataEntry `json:"entry"`
+}
+
+// restMetadataEntry is a feature type metadata entry, which holds either a simple value or a structured one.
+type restMetadataEntry struct {
+	// Key is the key of the entry.
+	Key string `json:"@key"`
+
+	// Value is the value of a simple entry.
+	Value string `json:"$,omitempty"`
+
+	// VirtualTable is the definition of an SQL view.
+	VirtualTable *restVirtualTable `json:"virtualTable,omitempty"`
+
+	// DimensionInfo is the configuration of a time or elevation dimension.
+	DimensionInfo *restDimensionInfo `json:"dimensionInfo,omitempty"`
+}
+
 // createFeatureTypeRestRequest exists in order to represent the JSON required by Geoserver when creating a feature type.
 type createFeatureTypeRestRequest struct {
 	FeatureType *restFeatureType `json:"featureType"`
@@ -166,12 +306,13 @@ type restStore struct {
 	Name string `json:"name"`
 }
 
-// newCreateFeatureTypeRestRequest creates a new CreateFeatureTypeRequest.
-func newCreateFeatureTypeRestRequest(request *CreateFeatureTypeRequest) *createFeatureTypeRestRequest {
+// newCreateFeatureTypeRestRequest creates a new CreateFeatureTypeRequest, using the geometry bindings of the Geoserver version.
+func newCreateFeatureTypeRestRequest(request *CreateFeatureTypeRequest, version *ServerVersion) *createFeatureTypeRestRequest {
 	return &createFeatureTypeRestRequest{&restFeatureType{
 		Name:       request.Name,
 		NativeName: request.NativeName,
 		Title:      request.Title,
+		Abstract:   request.Abstract,
 		Namespace: &restNamespace{
 			Name: request.Workspace,
 		},
@@ -181,19 +322,90 @@ func newCreateFeatureTypeRestRequest(request *CreateFeatureTypeRequest) *createF
 		Enabled:            true,
 		Store:              newStore(request.Workspace, request.DataStore),
 		ProjectionPolicy:   "REPROJECT_TO_DECLARED", // TODO
+		Metadata:           newFeatureTypeRestMetadata(request),
+		Keywords:           newRestKeywords(request.Keywords),
+		MetadataLinks:      newRestMetadataLinks(request.MetadataLinks),
+		DataLinks:          newRestDataLinks(request.DataLinks),
 		Attributes: &restAttributes{[]*restAttribute{// TODO: Make dynamic
 			{
 				Name:      "Geometry",
 				MinOccurs: 0,
 				MaxOccurs: 1,
 				Nillable:  true,
-				Binding:   "com.vividsolutions.jts.geom.Point",
+				Binding:   version.jtsGeometryPackage() + ".Point",
 			},
 		},
 		},
 	}}
 }
 
+// newFeatureTypeRestMetadata creates the metadata for a new feature type, or nil if it has none.
+func newFeatureTypeRestMetadata(request *CreateFeatureTypeRequest) *restMetadata {
+	entries := newDimensionMetadataEntries(request.Time, request.Elevation)
+	if len(entries) == 0 {
+		return nil
+	}
+	return &restMetadata{entries}
+}
+
+// applyFeatureTypeUpdate applies the fields provided in the update request to the JSON representation of a feature type.
+func applyFeatureTypeUpdate(featureType map[string]interface{}, request *UpdateFeatureTypeRequest) error {
+	if request.Title != "" {
+		featureType["title"] = request.Title
+	}
+	if request.Abstract != "" {
+		featureType["abstract"] = request.Abstract
+	}
+
+	applyResourceInfoUpdate(featureType, request.Keywords, request.MetadataLinks, request.DataLinks)
+
+	for _, entry := range newDimensionMetadataEntries(request.Time, request.Elevation) {
+		if err := setMetadataEntry(featureType, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setMetadataEntry adds a metadata entry to the JSON representation of a feature type or coverage, replacing any with the same key.
+// Geoserver represents a single entry as an object rather than an array, and no entries as an empty string.
+func setMetadataEntry(resource map[string]interface{}, entry *restMetadataEntry) error {
+	entryJSONBytes, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	var newEntry interface{}
+	if err = json.Unmarshal(entryJSONBytes, &newEntry); err != nil {
+		return err
+	}
+
+	entries := make([]interface{}, 0)
+	if metadata, ok := resource["metadata"].(map[string]interface{}); ok {
+		switch existing := metadata["entry"].(type) {
+		case []interface{}:
+			entries = existing
+		case map[string]interface{}:
+			entries = append(entries, existing)
+		}
+	}
+
+	replaced := false
+	for i, existing := range entries {
+		if existingEntry, ok := existing.(map[string]interface{}); ok && existingEntry["@key"] == entry.Key {
+			entries[i] = newEntry
+			replaced = true
+		}
+	}
+	if !replaced {
+		entries = append(entries, newEntry)
+	}
+
+	resource["metadata"] = map[string]interface{}{
+		"entry": entries,
+	}
+	return nil
+}
+
 type getFeatureTypeRestResponse struct {
 	FeatureTypes restFeatureTypes `json:"featureTypes"`
 }
@@ -233,3 +445,50 @@ func newStore(workspace string, name string) *restStore {
 		Name:  fmt.Sprintf("%s:%s", workspace, name),
 	}
 }
+
+// getFeatureTypeAttributesRestResponse exists in order to represent the JSON returned by Geoserver for the attributes of a feature type
+type getFeatureTypeAttributesRestResponse struct {
+	FeatureType *struct {
+		Attributes json.RawMessage `json:"attributes"`
+	} `json:"featureType"`
+}
+
+// restAttributesToAttributes converts the attributes of a feature type into Attributes. Geoserver encodes a single
+// attribute as an object rather than an array, and no attributes as an empty string.
+func restAttributesToAttributes(rawAttributes json.RawMessage) (attributes []*Attribute, err error) {
+	attributes = make([]*Attribute, 0)
+	if len(rawAttributes) == 0 || rawAttributes[0] == '"' || string(rawAttributes) == "null" {
+		return
+	}
+
+	var wrapper struct {
+		Attribute json.RawMessage `json:"attribute"`
+	}
+	err = json.Unmarshal(rawAttributes, &wrapper)
+	if err != nil || len(wrapper.Attribute) == 0 {
+		return
+	}
+
+	restAttributes := make([]*restAttribute, 0)
+	if wrapper.Attribute[0] == '{' {
+		single := &restAttribute{}
+		err = json.Unmarshal(wrapper.Attribute, single)
+		restAttributes = append(restAttributes, single)
+	} else {
+		err = json.Unmarshal(wrapper.Attribute, &restAttributes)
+	}
+	if err != nil {
+		return
+	}
+
+	for _, attribute := range restAttributes {
+		attributes = append(attributes, &Attribute{
+			Name:      attribute.Name,
+			MinOccurs: attribute.MinOccurs,
+			MaxOccurs: attribute.MaxOccurs,
+			Nillable:  attribute.Nillable,
+			Binding:   attribute.Binding,
+		})
+	}
+	return
+}
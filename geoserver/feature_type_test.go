@@ -0,0 +1,52 @@
+package geoserver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetMetadataEntryReplacesEntriesWithTheSameKey(t *testing.T) {
+	featureType := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"entry": []interface{}{
+				map[string]interface{}{"@key": "cachingEnabled", "$": "false"},
+				map[string]interface{}{"@key": "time", "dimensionInfo": map[string]interface{}{"enabled": false}},
+			},
+		},
+	}
+
+	err := setMetadataEntry(featureType, &restMetadataEntry{
+		Key:           timeMetadataKey,
+		DimensionInfo: newRestDimensionInfo(&DimensionInfo{Enabled: true, Attribute: "observed"}),
+	})
+	assert.NoError(t, err)
+
+	entries := featureType["metadata"].(map[string]interface{})["entry"].([]interface{})
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "cachingEnabled", entries[0].(map[string]interface{})["@key"])
+
+	dimension := entries[1].(map[string]interface{})["dimensionInfo"].(map[string]interface{})
+	assert.Equal(t, true, dimension["enabled"])
+	assert.Equal(t, "observed", dimension["attribute"])
+}
+
+func TestSetMetadataEntryHandlesSingleAndMissingEntries(t *testing.T) {
+	singleEntry := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"entry": map[string]interface{}{"@key": "cachingEnabled", "$": "false"},
+		},
+	}
+	assert.NoError(t, setMetadataEntry(singleEntry, &restMetadataEntry{Key: "elevation", DimensionInfo: &restDimensionInfo{}}))
+	assert.Equal(t, 2, len(singleEntry["metadata"].(map[string]interface{})["entry"].([]interface{})))
+
+	noEntries := map[string]interface{}{
+		"metadata": "",
+	}
+	assert.NoError(t, setMetadataEntry(noEntries, &restMetadataEntry{Key: "elevation", DimensionInfo: &restDimensionInfo{}}))
+	assert.Equal(t, 1, len(noEntries["metadata"].(map[string]interface{})["entry"].([]interface{})))
+}
+
+func TestNewCreateFeatureTypeRestRequestIncludesTheAbstract(t *testing.T) {
+	request := newCreateFeatureTypeRestRequest(&CreateFeatureTypeRequest{Name: "rivers", Abstract: "The rivers of the state"}, nil)
+	assert.Equal(t, "The rivers of the state", request.FeatureType.Abstract)
+}
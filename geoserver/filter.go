@@ -0,0 +1,761 @@
+package geoserver
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter is a type-safe representation of an OGC filter.
+// It can be rendered as ECQL using ToECQL, or as OGC Filter Encoding XML using ToFilterXML.
+type Filter interface {
+	// writeECQL writes the filter as ECQL
+	writeECQL(buffer *bytes.Buffer) error
+
+	// writeXML writes the filter as OGC Filter Encoding XML
+	writeXML(writer *filterXMLWriter) error
+}
+
+// ComparisonOperator is an operator used to compare a property with a literal value
+type ComparisonOperator string
+
+const (
+	// OpEqualTo matches when the property is equal to the value
+	OpEqualTo ComparisonOperator = "="
+
+	// OpNotEqualTo matches when the property is not equal to the value
+	OpNotEqualTo ComparisonOperator = "<>"
+
+	// OpLessThan matches when the property is less than the value
+	OpLessThan ComparisonOperator = "<"
+
+	// OpLessThanOrEqualTo matches when the property is less than or equal to the value
+	OpLessThanOrEqualTo ComparisonOperator = "<="
+
+	// OpGreaterThan matches when the property is greater than the value
+	OpGreaterThan ComparisonOperator = ">"
+
+	// OpGreaterThanOrEqualTo matches when the property is greater than or equal to the value
+	OpGreaterThanOrEqualTo ComparisonOperator = ">="
+)
+
+// SpatialOperator is an operator used to relate a geometry property with a geometry
+type SpatialOperator string
+
+const (
+	// OpIntersects matches when the geometries intersect
+	OpIntersects SpatialOperator = "INTERSECTS"
+
+	// OpDisjoint matches when the geometries do not intersect
+	OpDisjoint SpatialOperator = "DISJOINT"
+
+	// OpContains matches when the property contains the geometry
+	OpContains SpatialOperator = "CONTAINS"
+
+	// OpWithin matches when the property is within the geometry
+	OpWithin SpatialOperator = "WITHIN"
+
+	// OpTouches matches when the geometries touch
+	OpTouches SpatialOperator = "TOUCHES"
+
+	// OpCrosses matches when the geometries cross
+	OpCrosses SpatialOperator = "CROSSES"
+
+	// OpOverlaps matches when the geometries overlap
+	OpOverlaps SpatialOperator = "OVERLAPS"
+
+	// OpEquals matches when the geometries are spatially equal
+	OpEquals SpatialOperator = "EQUALS"
+)
+
+// DistanceOperator is an operator used to compare the distance between a geometry property and a geometry
+type DistanceOperator string
+
+const (
+	// OpDWithin matches when the property is within the distance of the geometry
+	OpDWithin DistanceOperator = "DWITHIN"
+
+	// OpBeyond matches when the property is beyond the distance of the geometry
+	OpBeyond DistanceOperator = "BEYOND"
+)
+
+// TemporalOperator is an operator used to relate a temporal property with an instant or period of time
+type TemporalOperator string
+
+const (
+	// OpBefore matches when the property is before the instant
+	OpBefore TemporalOperator = "BEFORE"
+
+	// OpAfter matches when the property is after the instant
+	OpAfter TemporalOperator = "AFTER"
+
+	// OpDuring matches when the property is within the period
+	OpDuring TemporalOperator = "DURING"
+
+	// OpTEquals matches when the property is equal to the instant
+	OpTEquals TemporalOperator = "TEQUALS"
+)
+
+// defaultDistanceUnits are the units used by distance filters when none are provided
+const defaultDistanceUnits = "meters"
+
+// ComparisonFilter compares a property with a literal value
+type ComparisonFilter struct {
+	// Property is the name of the property
+	Property string
+
+	// Operator is the comparison to perform
+	Operator ComparisonOperator
+
+	// Value is the literal value, one of string, int64, float64 or bool
+	Value interface{}
+}
+
+// BetweenFilter matches when a property is between two literal values, inclusively
+type BetweenFilter struct {
+	// Property is the name of the property
+	Property string
+
+	// Lower is the lower boundary
+	Lower interface{}
+
+	// Upper is the upper boundary
+	Upper interface{}
+}
+
+// LikeFilter matches a property against a pattern,
+// where '%' matches any number of characters, '_' matches a single character and '\' escapes either
+type LikeFilter struct {
+	// Property is the name of the property
+	Property string
+
+	// Pattern is the pattern to match against
+	Pattern string
+
+	// MatchCase is false when the match should be case insensitive
+	MatchCase bool
+}
+
+// NullFilter matches when a property is null
+type NullFilter struct {
+	// Property is the name of the property
+	Property string
+}
+
+// InFilter matches when a property is equal to any of the values
+type InFilter struct {
+	// Property is the name of the property
+	Property string
+
+	// Values are the literal values
+	Values []interface{}
+}
+
+// AndFilter matches when all of its filters match
+type AndFilter struct {
+	Filters []Filter
+}
+
+// OrFilter matches when any of its filters match
+type OrFilter struct {
+	Filters []Filter
+}
+
+// NotFilter matches when its filter does not match
+type NotFilter struct {
+	Filter Filter
+}
+
+// BBoxFilter matches when a geometry property intersects a bounding box
+type BBoxFilter struct {
+	// Property is the name of the geometry property
+	Property string
+
+	// MinX is the minimum X coordinate of the bounding box
+	MinX float64
+
+	// MinY is the minimum Y coordinate of the bounding box
+	MinY float64
+
+	// MaxX is the maximum X coordinate of the bounding box
+	MaxX float64
+
+	// MaxY is the maximum Y coordinate of the bounding box
+	MaxY float64
+
+	// CRS is the CRS of the bounding box e.g "EPSG:4326", the native CRS is used when not provided
+	CRS string
+}
+
+// SpatialFilter relates a geometry property with a geometry
+type SpatialFilter struct {
+	// Operator is the spatial relationship to test
+	Operator SpatialOperator
+
+	// Property is the name of the geometry property
+	Property string
+
+	// Geometry is the geometry as WKT
+	Geometry string
+}
+
+// DistanceFilter compares the distance between a geometry property and a geometry
+type DistanceFilter struct {
+	// Operator is the distance comparison to perform
+	Operator DistanceOperator
+
+	// Property is the name of the geometry property
+	Property string
+
+	// Geometry is the geometry as WKT
+	Geometry string
+
+	// Distance is the distance from the geometry
+	Distance float64
+
+	// Units are the units of the distance e.g "meters"
+	Units string
+}
+
+// TemporalFilter relates a temporal property with an instant or a period of time
+type TemporalFilter struct {
+	// Operator is the temporal relationship to test
+	Operator TemporalOperator
+
+	// Property is the name of the temporal property
+	Property string
+
+	// Time is the instant, or the beginning of the period for OpDuring
+	Time time.Time
+
+	// End is the end of the period for OpDuring
+	End time.Time
+}
+
+// Equal creates a filter matching when the property is equal to the value
+func Equal(property string, value interface{}) *ComparisonFilter {
+	return newComparisonFilter(property, OpEqualTo, value)
+}
+
+// NotEqual creates a filter matching when the property is not equal to the value
+func NotEqual(property string, value interface{}) *ComparisonFilter {
+	return newComparisonFilter(property, OpNotEqualTo, value)
+}
+
+// LessThan creates a filter matching when the property is less than the value
+func LessThan(property string, value interface{}) *ComparisonFilter {
+	return newComparisonFilter(property, OpLessThan, value)
+}
+
+// LessThanOrEqual creates a filter matching when the property is less than or equal to the value
+func LessThanOrEqual(property string, value interface{}) *ComparisonFilter {
+	return newComparisonFilter(property, OpLessThanOrEqualTo, value)
+}
+
+// GreaterThan creates a filter matching when the property is greater than the value
+func GreaterThan(property string, value interface{}) *ComparisonFilter {
+	return newComparisonFilter(property, OpGreaterThan, value)
+}
+
+// GreaterThanOrEqual creates a filter matching when the property is greater than or equal to the value
+func GreaterThanOrEqual(property string, value interface{}) *ComparisonFilter {
+	return newComparisonFilter(property, OpGreaterThanOrEqualTo, value)
+}
+
+// Between creates a filter matching when the property is between the lower and upper values, inclusively
+func Between(property string, lower interface{}, upper interface{}) *BetweenFilter {
+	return &BetweenFilter{
+		Property: property,
+		Lower:    normaliseLiteral(lower),
+		Upper:    normaliseLiteral(upper),
+	}
+}
+
+// Like creates a case sensitive filter matching the property against a pattern
+func Like(property string, pattern string) *LikeFilter {
+	return &LikeFilter{
+		Property:  property,
+		Pattern:   pattern,
+		MatchCase: true,
+	}
+}
+
+// ILike creates a case insensitive filter matching the property against a pattern
+func ILike(property string, pattern string) *LikeFilter {
+	return &LikeFilter{
+		Property: property,
+		Pattern:  pattern,
+	}
+}
+
+// IsNull creates a filter matching when the property is null
+func IsNull(property string) *NullFilter {
+	return &NullFilter{
+		Property: property,
+	}
+}
+
+// In creates a filter matching when the property is equal to any of the values
+func In(property string, values ...interface{}) *InFilter {
+	normalised := make([]interface{}, 0)
+	for _, value := range values {
+		normalised = append(normalised, normaliseLiteral(value))
+	}
+	return &InFilter{
+		Property: property,
+		Values:   normalised,
+	}
+}
+
+// And creates a filter matching when all of the filters match
+func And(filters ...Filter) *AndFilter {
+	return &AndFilter{
+		Filters: filters,
+	}
+}
+
+// Or creates a filter matching when any of the filters match
+func Or(filters ...Filter) *OrFilter {
+	return &OrFilter{
+		Filters: filters,
+	}
+}
+
+// Not creates a filter matching when the filter does not match
+func Not(filter Filter) *NotFilter {
+	return &NotFilter{
+		Filter: filter,
+	}
+}
+
+// BBox creates a filter matching when the geometry property intersects the bounding box
+func BBox(property string, minX float64, minY float64, maxX float64, maxY float64, crs string) *BBoxFilter {
+	return &BBoxFilter{
+		Property: property,
+		MinX:     minX,
+		MinY:     minY,
+		MaxX:     maxX,
+		MaxY:     maxY,
+		CRS:      crs,
+	}
+}
+
+// Intersects creates a filter matching when the geometry property intersects the WKT geometry
+func Intersects(property string, wkt string) *SpatialFilter {
+	return newSpatialFilter(OpIntersects, property, wkt)
+}
+
+// Disjoint creates a filter matching when the geometry property does not intersect the WKT geometry
+func Disjoint(property string, wkt string) *SpatialFilter {
+	return newSpatialFilter(OpDisjoint, property, wkt)
+}
+
+// Contains creates a filter matching when the geometry property contains the WKT geometry
+func Contains(property string, wkt string) *SpatialFilter {
+	return newSpatialFilter(OpContains, property, wkt)
+}
+
+// Within creates a filter matching when the geometry property is within the WKT geometry
+func Within(property string, wkt string) *SpatialFilter {
+	return newSpatialFilter(OpWithin, property, wkt)
+}
+
+// Touches creates a filter matching when the geometry property touches the WKT geometry
+func Touches(property string, wkt string) *SpatialFilter {
+	return newSpatialFilter(OpTouches, property, wkt)
+}
+
+// Crosses creates a filter matching when the geometry property crosses the WKT geometry
+func Crosses(property string, wkt string) *SpatialFilter {
+	return newSpatialFilter(OpCrosses, property, wkt)
+}
+
+// Overlaps creates a filter matching when the geometry property overlaps the WKT geometry
+func Overlaps(property string, wkt string) *SpatialFilter {
+	return newSpatialFilter(OpOverlaps, property, wkt)
+}
+
+// SpatialEquals creates a filter matching when the geometry property is spatially equal to the WKT geometry
+func SpatialEquals(property string, wkt string) *SpatialFilter {
+	return newSpatialFilter(OpEquals, property, wkt)
+}
+
+// DWithin creates a filter matching when the geometry property is within the distance of the WKT geometry.
+// The units default to meters when not provided.
+func DWithin(property string, wkt string, distance float64, units string) *DistanceFilter {
+	return newDistanceFilter(OpDWithin, property, wkt, distance, units)
+}
+
+// Beyond creates a filter matching when the geometry property is beyond the distance of the WKT geometry.
+// The units default to meters when not provided.
+func Beyond(property string, wkt string, distance float64, units string) *DistanceFilter {
+	return newDistanceFilter(OpBeyond, property, wkt, distance, units)
+}
+
+// Before creates a filter matching when the temporal property is before the instant
+func Before(property string, instant time.Time) *TemporalFilter {
+	return &TemporalFilter{Operator: OpBefore, Property: property, Time: instant}
+}
+
+// After creates a filter matching when the temporal property is after the instant
+func After(property string, instant time.Time) *TemporalFilter {
+	return &TemporalFilter{Operator: OpAfter, Property: property, Time: instant}
+}
+
+// During creates a filter matching when the temporal property is within the period
+func During(property string, begin time.Time, end time.Time) *TemporalFilter {
+	return &TemporalFilter{Operator: OpDuring, Property: property, Time: begin, End: end}
+}
+
+// TEquals creates a filter matching when the temporal property is equal to the instant
+func TEquals(property string, instant time.Time) *TemporalFilter {
+	return &TemporalFilter{Operator: OpTEquals, Property: property, Time: instant}
+}
+
+// ToECQL renders the filter as an ECQL string, returning an error if the filter is invalid.
+func ToECQL(filter Filter) (string, error) {
+	if filter == nil {
+		return "", errors.New("unable to render a nil filter as ECQL")
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := filter.writeECQL(buffer); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// newComparisonFilter creates a new ComparisonFilter
+func newComparisonFilter(property string, operator ComparisonOperator, value interface{}) *ComparisonFilter {
+	return &ComparisonFilter{
+		Property: property,
+		Operator: operator,
+		Value:    normaliseLiteral(value),
+	}
+}
+
+// newSpatialFilter creates a new SpatialFilter, normalising the WKT when it is valid
+func newSpatialFilter(operator SpatialOperator, property string, wkt string) *SpatialFilter {
+	return &SpatialFilter{
+		Operator: operator,
+		Property: property,
+		Geometry: normaliseWKT(wkt),
+	}
+}
+
+// newDistanceFilter creates a new DistanceFilter, normalising the WKT when it is valid
+func newDistanceFilter(operator DistanceOperator, property string, wkt string, distance float64, units string) *DistanceFilter {
+	if units == "" {
+		units = defaultDistanceUnits
+	}
+	return &DistanceFilter{
+		Operator: operator,
+		Property: property,
+		Geometry: normaliseWKT(wkt),
+		Distance: distance,
+		Units:    units,
+	}
+}
+
+// normaliseLiteral converts the value to one of the literal types used by filters: string, int64, float64 or bool
+func normaliseLiteral(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string, int64, float64, bool:
+		return v
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case float32:
+		return float64(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// normaliseWKT returns the WKT in its canonical form, or unchanged if it cannot be parsed
+func normaliseWKT(wkt string) string {
+	geometry, err := parseWKT(wkt)
+	if err != nil {
+		return wkt
+	}
+	return geometry.String()
+}
+
+// formatFloat formats a float without trailing zeros
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// formatTime formats a time as used by filters
+func formatTime(value time.Time) string {
+	return value.UTC().Format(time.RFC3339Nano)
+}
+
+/**
+ * ECQL
+ */
+
+// ecqlKeywords are the words which must be quoted when used as property names in ECQL
+var ecqlKeywords = map[string]bool{
+	"AND": true, "OR": true, "NOT": true, "LIKE": true, "ILIKE": true, "IS": true, "NULL": true,
+	"BETWEEN": true, "IN": true, "TRUE": true, "FALSE": true, "INCLUDE": true, "EXCLUDE": true,
+	"BBOX": true, "INTERSECTS": true, "DISJOINT": true, "CONTAINS": true, "WITHIN": true,
+	"TOUCHES": true, "CROSSES": true, "OVERLAPS": true, "EQUALS": true, "DWITHIN": true, "BEYOND": true,
+	"BEFORE": true, "AFTER": true, "DURING": true, "TEQUALS": true,
+}
+
+// writeECQLProperty writes a property name, quoting it when it is not a plain identifier
+func writeECQLProperty(buffer *bytes.Buffer, property string) error {
+	if property == "" {
+		return errors.New("filter property name must not be empty")
+	}
+
+	if isECQLIdentifier(property) && !ecqlKeywords[strings.ToUpper(property)] {
+		buffer.WriteString(property)
+		return nil
+	}
+
+	buffer.WriteString(`"` + strings.Replace(property, `"`, `""`, -1) + `"`)
+	return nil
+}
+
+// isECQLIdentifier returns true when the value can be used as a property name in ECQL without quoting
+func isECQLIdentifier(value string) bool {
+	for i, r := range value {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_'
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && (i == 0 || (!isDigit && r != ':' && r != '.')) {
+			return false
+		}
+	}
+	return true
+}
+
+// writeECQLLiteral writes a literal value, quoting and escaping strings
+func writeECQLLiteral(buffer *bytes.Buffer, value interface{}) error {
+	switch v := normaliseLiteral(value).(type) {
+	case string:
+		buffer.WriteString(quoteECQLString(v))
+	case int64:
+		buffer.WriteString(strconv.FormatInt(v, 10))
+	case float64:
+		buffer.WriteString(formatFloat(v))
+	case bool:
+		buffer.WriteString(strings.ToUpper(strconv.FormatBool(v)))
+	default:
+		return fmt.Errorf("unsupported filter literal '%v'", value)
+	}
+	return nil
+}
+
+// quoteECQLString quotes a string for ECQL, escaping single quotes by doubling them
+func quoteECQLString(value string) string {
+	return "'" + strings.Replace(value, "'", "''", -1) + "'"
+}
+
+// writeECQLOperands writes the filters of a logical filter separated by the operator
+func writeECQLOperands(buffer *bytes.Buffer, operator string, filters []Filter) error {
+	if len(filters) == 0 {
+		return fmt.Errorf("%s filter requires at least one filter", operator)
+	}
+
+	for i, filter := range filters {
+		if i > 0 {
+			buffer.WriteString(" " + operator + " ")
+		}
+
+		_, isAnd := filter.(*AndFilter)
+		_, isOr := filter.(*OrFilter)
+		isNested := (isAnd || isOr) && len(filters) > 1
+		if isNested {
+			buffer.WriteString("(")
+		}
+		if err := filter.writeECQL(buffer); err != nil {
+			return err
+		}
+		if isNested {
+			buffer.WriteString(")")
+		}
+	}
+	return nil
+}
+
+// writeECQLGeometry writes a WKT geometry in its canonical form
+func writeECQLGeometry(buffer *bytes.Buffer, wkt string) error {
+	geometry, err := parseWKT(wkt)
+	if err != nil {
+		return err
+	}
+	buffer.WriteString(geometry.String())
+	return nil
+}
+
+func (filter *ComparisonFilter) writeECQL(buffer *bytes.Buffer) error {
+	if _, ok := comparisonElements[filter.Operator]; !ok {
+		return fmt.Errorf("unsupported comparison operator '%s'", filter.Operator)
+	}
+	if err := writeECQLProperty(buffer, filter.Property); err != nil {
+		return err
+	}
+	buffer.WriteString(" " + string(filter.Operator) + " ")
+	return writeECQLLiteral(buffer, filter.Value)
+}
+
+func (filter *BetweenFilter) writeECQL(buffer *bytes.Buffer) error {
+	if err := writeECQLProperty(buffer, filter.Property); err != nil {
+		return err
+	}
+	buffer.WriteString(" BETWEEN ")
+	if err := writeECQLLiteral(buffer, filter.Lower); err != nil {
+		return err
+	}
+	buffer.WriteString(" AND ")
+	return writeECQLLiteral(buffer, filter.Upper)
+}
+
+func (filter *LikeFilter) writeECQL(buffer *bytes.Buffer) error {
+	if err := writeECQLProperty(buffer, filter.Property); err != nil {
+		return err
+	}
+	if filter.MatchCase {
+		buffer.WriteString(" LIKE ")
+	} else {
+		buffer.WriteString(" ILIKE ")
+	}
+	buffer.WriteString(quoteECQLString(filter.Pattern))
+	return nil
+}
+
+func (filter *NullFilter) writeECQL(buffer *bytes.Buffer) error {
+	if err := writeECQLProperty(buffer, filter.Property); err != nil {
+		return err
+	}
+	buffer.WriteString(" IS NULL")
+	return nil
+}
+
+func (filter *InFilter) writeECQL(buffer *bytes.Buffer) error {
+	if len(filter.Values) == 0 {
+		return errors.New("IN filter requires at least one value")
+	}
+	if err := writeECQLProperty(buffer, filter.Property); err != nil {
+		return err
+	}
+	buffer.WriteString(" IN (")
+	for i, value := range filter.Values {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		if err := writeECQLLiteral(buffer, value); err != nil {
+			return err
+		}
+	}
+	buffer.WriteString(")")
+	return nil
+}
+
+func (filter *AndFilter) writeECQL(buffer *bytes.Buffer) error {
+	return writeECQLOperands(buffer, "AND", filter.Filters)
+}
+
+func (filter *OrFilter) writeECQL(buffer *bytes.Buffer) error {
+	return writeECQLOperands(buffer, "OR", filter.Filters)
+}
+
+func (filter *NotFilter) writeECQL(buffer *bytes.Buffer) error {
+	if filter.Filter == nil {
+		return errors.New("NOT filter requires a filter")
+	}
+	buffer.WriteString("NOT (")
+	if err := filter.Filter.writeECQL(buffer); err != nil {
+		return err
+	}
+	buffer.WriteString(")")
+	return nil
+}
+
+func (filter *BBoxFilter) writeECQL(buffer *bytes.Buffer) error {
+	buffer.WriteString("BBOX(")
+	if err := writeECQLProperty(buffer, filter.Property); err != nil {
+		return err
+	}
+	for _, coordinate := range []float64{filter.MinX, filter.MinY, filter.MaxX, filter.MaxY} {
+		buffer.WriteString(", " + formatFloat(coordinate))
+	}
+	if filter.CRS != "" {
+		buffer.WriteString(", " + quoteECQLString(filter.CRS))
+	}
+	buffer.WriteString(")")
+	return nil
+}
+
+func (filter *SpatialFilter) writeECQL(buffer *bytes.Buffer) error {
+	if _, ok := spatialElements[filter.Operator]; !ok {
+		return fmt.Errorf("unsupported spatial operator '%s'", filter.Operator)
+	}
+	buffer.WriteString(string(filter.Operator) + "(")
+	if err := writeECQLProperty(buffer, filter.Property); err != nil {
+		return err
+	}
+	buffer.WriteString(", ")
+	if err := writeECQLGeometry(buffer, filter.Geometry); err != nil {
+		return err
+	}
+	buffer.WriteString(")")
+	return nil
+}
+
+func (filter *DistanceFilter) writeECQL(buffer *bytes.Buffer) error {
+	if _, ok := distanceElements[filter.Operator]; !ok {
+		return fmt.Errorf("unsupported distance operator '%s'", filter.Operator)
+	}
+	buffer.WriteString(string(filter.Operator) + "(")
+	if err := writeECQLProperty(buffer, filter.Property); err != nil {
+		return err
+	}
+	buffer.WriteString(", ")
+	if err := writeECQLGeometry(buffer, filter.Geometry); err != nil {
+		return err
+	}
+	units := filter.Units
+	if units == "" {
+		units = defaultDistanceUnits
+	}
+	buffer.WriteString(", " + formatFloat(filter.Distance) + ", " + units + ")")
+	return nil
+}
+
+func (filter *TemporalFilter) writeECQL(buffer *bytes.Buffer) error {
+	if _, ok := temporalElements[filter.Operator]; !ok {
+		return fmt.Errorf("unsupported temporal operator '%s'", filter.Operator)
+	}
+	if err := writeECQLProperty(buffer, filter.Property); err != nil {
+		return err
+	}
+	buffer.WriteString(" " + string(filter.Operator) + " " + formatTime(filter.Time))
+	if filter.Operator == OpDuring {
+		buffer.WriteString("/" + formatTime(filter.End))
+	}
+	return nil
+}
@@ -0,0 +1,508 @@
+package geoserver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseECQL parses an ECQL string into a Filter, returning an error if it is not possible.
+// It supports the subset of ECQL which can be represented by the filter types in this package.
+func ParseECQL(ecql string) (Filter, error) {
+	parser := &ecqlParser{input: ecql}
+
+	filter, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	parser.skipSpace()
+	if parser.pos != len(parser.input) {
+		return nil, parser.errorf("unexpected '%s'", parser.input[parser.pos:])
+	}
+	return filter, nil
+}
+
+// ecqlParser is a recursive descent parser for ECQL
+type ecqlParser struct {
+	input string
+	pos   int
+}
+
+// errorf creates an error which includes the position of the parser
+func (parser *ecqlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid ECQL at position %d: %s", parser.pos, fmt.Sprintf(format, args...))
+}
+
+// skipSpace skips any whitespace
+func (parser *ecqlParser) skipSpace() {
+	for parser.pos < len(parser.input) && strings.ContainsRune(" \t\r\n", rune(parser.input[parser.pos])) {
+		parser.pos++
+	}
+}
+
+// peekWord returns the next word without consuming it
+func (parser *ecqlParser) peekWord() string {
+	parser.skipSpace()
+	end := parser.pos
+	for end < len(parser.input) && isASCIILetter(parser.input[end]) {
+		end++
+	}
+	return strings.ToUpper(parser.input[parser.pos:end])
+}
+
+// acceptKeyword consumes the keyword if it is next, returning true if it was
+func (parser *ecqlParser) acceptKeyword(keyword string) bool {
+	if parser.peekWord() != keyword {
+		return false
+	}
+	end := parser.pos + len(keyword)
+	if end < len(parser.input) && isECQLIdentifierCharacter(parser.input[end]) {
+		return false
+	}
+	parser.pos = end
+	return true
+}
+
+// expectKeyword consumes the keyword, returning an error if it is not next
+func (parser *ecqlParser) expectKeyword(keyword string) error {
+	if !parser.acceptKeyword(keyword) {
+		return parser.errorf("expected %s", keyword)
+	}
+	return nil
+}
+
+// accept consumes the symbol if it is next, returning true if it was
+func (parser *ecqlParser) accept(symbol string) bool {
+	parser.skipSpace()
+	if strings.HasPrefix(parser.input[parser.pos:], symbol) {
+		parser.pos += len(symbol)
+		return true
+	}
+	return false
+}
+
+// expect consumes the symbol, returning an error if it is not next
+func (parser *ecqlParser) expect(symbol string) error {
+	if !parser.accept(symbol) {
+		return parser.errorf("expected '%s'", symbol)
+	}
+	return nil
+}
+
+// parseOr parses a disjunction
+func (parser *ecqlParser) parseOr() (Filter, error) {
+	filters := make([]Filter, 0)
+	for {
+		filter, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+		if !parser.acceptKeyword("OR") {
+			break
+		}
+	}
+	if len(filters) == 1 {
+		return filters[0], nil
+	}
+	return Or(filters...), nil
+}
+
+// parseAnd parses a conjunction
+func (parser *ecqlParser) parseAnd() (Filter, error) {
+	filters := make([]Filter, 0)
+	for {
+		filter, err := parser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+		if !parser.acceptKeyword("AND") {
+			break
+		}
+	}
+	if len(filters) == 1 {
+		return filters[0], nil
+	}
+	return And(filters...), nil
+}
+
+// parseNot parses a negation, or a predicate
+func (parser *ecqlParser) parseNot() (Filter, error) {
+	if parser.acceptKeyword("NOT") {
+		filter, err := parser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return Not(filter), nil
+	}
+	return parser.parsePrimary()
+}
+
+// parsePrimary parses a parenthesised filter, a spatial function or a property predicate
+func (parser *ecqlParser) parsePrimary() (Filter, error) {
+	if parser.accept("(") {
+		filter, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return filter, parser.expect(")")
+	}
+
+	word := parser.peekWord()
+	if word == "BBOX" && parser.isFunctionCall(word) {
+		return parser.parseBBox()
+	}
+	for operator := range spatialElements {
+		if word == string(operator) && parser.isFunctionCall(word) {
+			return parser.parseSpatial(operator)
+		}
+	}
+	for operator := range distanceElements {
+		if word == string(operator) && parser.isFunctionCall(word) {
+			return parser.parseDistance(operator)
+		}
+	}
+
+	property, err := parser.parseProperty()
+	if err != nil {
+		return nil, err
+	}
+	return parser.parsePredicate(property)
+}
+
+// isFunctionCall returns true if the word is followed by an opening parenthesis
+func (parser *ecqlParser) isFunctionCall(word string) bool {
+	rest := strings.TrimLeft(parser.input[parser.pos+len(word):], " \t\r\n")
+	return strings.HasPrefix(rest, "(")
+}
+
+// parsePredicate parses the predicate applied to a property
+func (parser *ecqlParser) parsePredicate(property string) (Filter, error) {
+	if parser.acceptKeyword("IS") {
+		negated := parser.acceptKeyword("NOT")
+		if err := parser.expectKeyword("NULL"); err != nil {
+			return nil, err
+		}
+		return negate(IsNull(property), negated), nil
+	}
+
+	negated := parser.acceptKeyword("NOT")
+
+	switch {
+	case parser.acceptKeyword("BETWEEN"):
+		lower, err := parser.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		if err := parser.expectKeyword("AND"); err != nil {
+			return nil, err
+		}
+		upper, err := parser.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return negate(Between(property, lower, upper), negated), nil
+	case parser.acceptKeyword("LIKE"):
+		pattern, err := parser.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return negate(Like(property, pattern), negated), nil
+	case parser.acceptKeyword("ILIKE"):
+		pattern, err := parser.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return negate(ILike(property, pattern), negated), nil
+	case parser.acceptKeyword("IN"):
+		values, err := parser.parseLiteralList()
+		if err != nil {
+			return nil, err
+		}
+		return negate(In(property, values...), negated), nil
+	case negated:
+		return nil, parser.errorf("expected BETWEEN, LIKE, ILIKE or IN after NOT")
+	}
+
+	for operator := range temporalElements {
+		if parser.acceptKeyword(string(operator)) {
+			return parser.parseTemporal(operator, property)
+		}
+	}
+
+	for _, operator := range []ComparisonOperator{OpLessThanOrEqualTo, OpGreaterThanOrEqualTo, OpNotEqualTo, OpEqualTo, OpLessThan, OpGreaterThan} {
+		if parser.accept(string(operator)) {
+			value, err := parser.parseLiteral()
+			if err != nil {
+				return nil, err
+			}
+			return newComparisonFilter(property, operator, value), nil
+		}
+	}
+
+	return nil, parser.errorf("expected a predicate for property '%s'", property)
+}
+
+// negate wraps the filter in a NotFilter when negated is true
+func negate(filter Filter, negated bool) Filter {
+	if negated {
+		return Not(filter)
+	}
+	return filter
+}
+
+// parseTemporal parses the instant or period of a temporal predicate
+func (parser *ecqlParser) parseTemporal(operator TemporalOperator, property string) (Filter, error) {
+	begin, err := parser.parseTime()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &TemporalFilter{
+		Operator: operator,
+		Property: property,
+		Time:     begin,
+	}
+
+	if operator == OpDuring {
+		if err := parser.expect("/"); err != nil {
+			return nil, err
+		}
+		if filter.End, err = parser.parseTime(); err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+// parseBBox parses a BBOX function
+func (parser *ecqlParser) parseBBox() (Filter, error) {
+	parser.acceptKeyword("BBOX")
+	if err := parser.expect("("); err != nil {
+		return nil, err
+	}
+	property, err := parser.parseProperty()
+	if err != nil {
+		return nil, err
+	}
+
+	coordinates := make([]float64, 4)
+	for i := range coordinates {
+		if err := parser.expect(","); err != nil {
+			return nil, err
+		}
+		if coordinates[i], err = parser.parseNumber(); err != nil {
+			return nil, err
+		}
+	}
+
+	crs := ""
+	if parser.accept(",") {
+		if crs, err = parser.parseString(); err != nil {
+			return nil, err
+		}
+	}
+
+	return BBox(property, coordinates[0], coordinates[1], coordinates[2], coordinates[3], crs), parser.expect(")")
+}
+
+// parseSpatial parses a spatial function
+func (parser *ecqlParser) parseSpatial(operator SpatialOperator) (Filter, error) {
+	parser.acceptKeyword(string(operator))
+	if err := parser.expect("("); err != nil {
+		return nil, err
+	}
+	property, err := parser.parseProperty()
+	if err != nil {
+		return nil, err
+	}
+	if err := parser.expect(","); err != nil {
+		return nil, err
+	}
+	wkt, err := parser.parseGeometry()
+	if err != nil {
+		return nil, err
+	}
+	return newSpatialFilter(operator, property, wkt), parser.expect(")")
+}
+
+// parseDistance parses a distance function
+func (parser *ecqlParser) parseDistance(operator DistanceOperator) (Filter, error) {
+	parser.acceptKeyword(string(operator))
+	if err := parser.expect("("); err != nil {
+		return nil, err
+	}
+	property, err := parser.parseProperty()
+	if err != nil {
+		return nil, err
+	}
+	if err := parser.expect(","); err != nil {
+		return nil, err
+	}
+	wkt, err := parser.parseGeometry()
+	if err != nil {
+		return nil, err
+	}
+	if err := parser.expect(","); err != nil {
+		return nil, err
+	}
+	distance, err := parser.parseNumber()
+	if err != nil {
+		return nil, err
+	}
+	if err := parser.expect(","); err != nil {
+		return nil, err
+	}
+
+	parser.skipSpace()
+	start := parser.pos
+	for parser.pos < len(parser.input) && parser.input[parser.pos] != ')' {
+		parser.pos++
+	}
+	units := strings.TrimSpace(parser.input[start:parser.pos])
+
+	return newDistanceFilter(operator, property, wkt, distance, units), parser.expect(")")
+}
+
+// parseGeometry parses a WKT geometry, returning it in its canonical form
+func (parser *ecqlParser) parseGeometry() (string, error) {
+	parser.skipSpace()
+	wktParser := &wktParser{input: parser.input, pos: parser.pos}
+	geometry, err := wktParser.parseGeometry()
+	if err != nil {
+		return "", parser.errorf("invalid geometry: %s", err)
+	}
+	parser.pos = wktParser.pos
+	return geometry.String(), nil
+}
+
+// parseProperty parses a plain or double quoted property name
+func (parser *ecqlParser) parseProperty() (string, error) {
+	parser.skipSpace()
+	if parser.accept(`"`) {
+		value, err := parser.parseQuoted('"')
+		if err != nil {
+			return "", err
+		}
+		return value, nil
+	}
+
+	start := parser.pos
+	for parser.pos < len(parser.input) && isECQLIdentifierCharacter(parser.input[parser.pos]) {
+		parser.pos++
+	}
+	property := parser.input[start:parser.pos]
+	if property == "" || !isECQLIdentifier(property) {
+		parser.pos = start
+		return "", parser.errorf("expected a property name")
+	}
+	return property, nil
+}
+
+// parseQuoted parses the remainder of a quoted value, where the quote is escaped by doubling it
+func (parser *ecqlParser) parseQuoted(quote byte) (string, error) {
+	value := make([]byte, 0)
+	for parser.pos < len(parser.input) {
+		character := parser.input[parser.pos]
+		parser.pos++
+		if character != quote {
+			value = append(value, character)
+			continue
+		}
+		if parser.pos < len(parser.input) && parser.input[parser.pos] == quote {
+			value = append(value, quote)
+			parser.pos++
+			continue
+		}
+		return string(value), nil
+	}
+	return "", parser.errorf("unterminated quoted value")
+}
+
+// parseString parses a single quoted string
+func (parser *ecqlParser) parseString() (string, error) {
+	if !parser.accept("'") {
+		return "", parser.errorf("expected a string")
+	}
+	return parser.parseQuoted('\'')
+}
+
+// parseLiteral parses a string, number or boolean literal
+func (parser *ecqlParser) parseLiteral() (interface{}, error) {
+	parser.skipSpace()
+	switch {
+	case parser.acceptKeyword("TRUE"):
+		return true, nil
+	case parser.acceptKeyword("FALSE"):
+		return false, nil
+	case strings.HasPrefix(parser.input[parser.pos:], "'"):
+		return parser.parseString()
+	}
+
+	start := parser.pos
+	number, err := parser.parseNumber()
+	if err != nil {
+		return nil, err
+	}
+	if value, err := strconv.ParseInt(parser.input[start:parser.pos], 10, 64); err == nil {
+		return value, nil
+	}
+	return number, nil
+}
+
+// parseLiteralList parses a parenthesised list of literals
+func (parser *ecqlParser) parseLiteralList() ([]interface{}, error) {
+	if err := parser.expect("("); err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, 0)
+	for {
+		value, err := parser.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		if !parser.accept(",") {
+			break
+		}
+	}
+	return values, parser.expect(")")
+}
+
+// parseNumber parses a number
+func (parser *ecqlParser) parseNumber() (float64, error) {
+	parser.skipSpace()
+	start := parser.pos
+	for parser.pos < len(parser.input) && strings.ContainsRune("0123456789.-+eE", rune(parser.input[parser.pos])) {
+		parser.pos++
+	}
+	value, err := strconv.ParseFloat(parser.input[start:parser.pos], 64)
+	if err != nil {
+		parser.pos = start
+		return 0, parser.errorf("expected a number")
+	}
+	return value, nil
+}
+
+// parseTime parses an ISO 8601 date time
+func (parser *ecqlParser) parseTime() (time.Time, error) {
+	parser.skipSpace()
+	start := parser.pos
+	for parser.pos < len(parser.input) && strings.ContainsRune("0123456789-:.TZ+", rune(parser.input[parser.pos])) {
+		parser.pos++
+	}
+	value, err := parseFilterTime(parser.input[start:parser.pos])
+	if err != nil {
+		parser.pos = start
+		return time.Time{}, parser.errorf("expected an ISO 8601 date time")
+	}
+	return value, nil
+}
+
+// isECQLIdentifierCharacter returns true if the character can be part of an unquoted property name
+func isECQLIdentifierCharacter(character byte) bool {
+	return isASCIILetter(character) || (character >= '0' && character <= '9') || character == '_' || character == ':' || character == '.'
+}
@@ -0,0 +1,116 @@
+package geoserver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// newRoundTripFilter creates a filter using every operator which can be expressed in all encodings
+func newRoundTripFilter() Filter {
+	return And(
+		Equal("name", "O'Brien"),
+		NotEqual("population", 0),
+		Or(
+			LessThan("area", 10.5),
+			GreaterThanOrEqual("area", 100),
+		),
+		Between("elevation", -10, 2500),
+		Like("title", `100\% of _a%`),
+		ILike("description", "%river%"),
+		Not(IsNull("id")),
+		BBox("the_geom", -180, -90, 180, 90, "EPSG:4326"),
+		Intersects("the_geom", "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 4 2, 4 4, 2 2))"),
+		Within("the_geom", "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((5 5, 6 5, 6 6, 5 5)))"),
+		Crosses("the_geom", "MULTILINESTRING ((0 0, 1 1), (2 2, 3 3))"),
+		DWithin("the_geom", "POINT (-1.5 52.25)", 1000, "meters"),
+		Beyond("the_geom", "LINESTRING (0 0, 1 1)", 5, "kilometers"),
+	)
+}
+
+func TestFilterRoundTripsThroughECQL(t *testing.T) {
+	filter := And(
+		newRoundTripFilter(),
+		In("type", "river", "lake"),
+		During("observed", time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2018, 2, 1, 12, 30, 0, 0, time.UTC)),
+		Before("observed", time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)),
+	)
+
+	ecql, err := ToECQL(filter)
+	assert.NoError(t, err)
+
+	parsed, err := ParseECQL(ecql)
+	assert.NoError(t, err)
+	assert.Equal(t, filter, parsed)
+}
+
+func TestFilterRoundTripsThroughFilterEncoding11(t *testing.T) {
+	filter := newRoundTripFilter()
+
+	filterXML, err := ToFilterXML(filter, FilterEncoding11)
+	assert.NoError(t, err)
+	assert.Contains(t, filterXML, `<ogc:PropertyName>name</ogc:PropertyName>`)
+
+	parsed, err := ParseFilterXML(filterXML)
+	assert.NoError(t, err)
+	assert.Equal(t, filter, parsed)
+}
+
+func TestFilterRoundTripsThroughFilterEncoding20(t *testing.T) {
+	filter := And(
+		newRoundTripFilter(),
+		After("observed", time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)),
+		During("observed", time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2018, 2, 1, 0, 0, 0, 0, time.UTC)),
+	)
+
+	filterXML, err := ToFilterXML(filter, FilterEncoding20)
+	assert.NoError(t, err)
+	assert.Contains(t, filterXML, `<fes:ValueReference>name</fes:ValueReference>`)
+
+	parsed, err := ParseFilterXML(filterXML)
+	assert.NoError(t, err)
+	assert.Equal(t, filter, parsed)
+}
+
+func TestToECQLQuotesStringsAndPropertyNames(t *testing.T) {
+	ecql, err := ToECQL(And(
+		Equal("name", "O'Brien"),
+		Equal("my attribute", "x"),
+		Equal("like", true),
+	))
+	assert.NoError(t, err)
+	assert.Equal(t, `name = 'O''Brien' AND "my attribute" = 'x' AND "like" = TRUE`, ecql)
+}
+
+func TestToFilterXMLEscapesLiteralsAndExpandsIn(t *testing.T) {
+	filterXML, err := ToFilterXML(In("name", "<a>", "b&c"), FilterEncoding11)
+	assert.NoError(t, err)
+	assert.Contains(t, filterXML, `<ogc:Or><ogc:PropertyIsEqualTo><ogc:PropertyName>name</ogc:PropertyName><ogc:Literal>&lt;a&gt;</ogc:Literal></ogc:PropertyIsEqualTo>`)
+	assert.Contains(t, filterXML, `<ogc:Literal>b&amp;c</ogc:Literal>`)
+}
+
+func TestToFilterXMLWritesTheEscapeCharAttributeOfLikeFilters(t *testing.T) {
+	for _, version := range []FilterEncodingVersion{FilterEncoding11, FilterEncoding20} {
+		filterXML, err := ToFilterXML(Like("title", "river%"), version)
+		assert.NoError(t, err)
+		assert.Contains(t, filterXML, `PropertyIsLike wildCard="%" singleChar="_" escapeChar="\" matchCase="true"`, string(version))
+		assert.NotContains(t, filterXML, ` escape="`, string(version))
+	}
+}
+
+func TestToFilterXMLRejectsTemporalOperatorsInFilterEncoding11(t *testing.T) {
+	_, err := ToFilterXML(Before("observed", time.Now()), FilterEncoding11)
+	assert.Error(t, err)
+}
+
+func TestToECQLRejectsInvalidGeometries(t *testing.T) {
+	_, err := ToECQL(Intersects("the_geom", "POLYGON ((0 0, 1 1"))
+	assert.Error(t, err)
+}
+
+func TestParseECQLRejectsInvalidInput(t *testing.T) {
+	for _, ecql := range []string{"name = ", "name = 'unterminated", "BBOX(the_geom, 1, 2)", "name LIKE 5", "(a = 1"} {
+		_, err := ParseECQL(ecql)
+		assert.Error(t, err, ecql)
+	}
+}
@@ -0,0 +1,815 @@
+package geoserver
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterEncodingVersion is a version of the OGC Filter Encoding standard
+type FilterEncodingVersion string
+
+const (
+	// FilterEncoding11 is OGC Filter Encoding 1.1, as used by WFS 1.1 and WMS
+	FilterEncoding11 FilterEncodingVersion = "1.1.0"
+
+	// FilterEncoding20 is OGC Filter Encoding 2.0, as used by WFS 2.0
+	FilterEncoding20 FilterEncodingVersion = "2.0.0"
+)
+
+const (
+	// ogcNamespace is the XML namespace of Filter Encoding 1.1
+	ogcNamespace = "http://www.opengis.net/ogc"
+
+	// fesNamespace is the XML namespace of Filter Encoding 2.0
+	fesNamespace = "http://www.opengis.net/fes/2.0"
+
+	// gml3Namespace is the XML namespace of GML 3.1.1, used with Filter Encoding 1.1
+	gml3Namespace = "http://www.opengis.net/gml"
+
+	// gml32Namespace is the XML namespace of GML 3.2, used with Filter Encoding 2.0
+	gml32Namespace = "http://www.opengis.net/gml/3.2"
+)
+
+// comparisonElements are the Filter Encoding elements for each comparison operator
+var comparisonElements = map[ComparisonOperator]string{
+	OpEqualTo:              "PropertyIsEqualTo",
+	OpNotEqualTo:           "PropertyIsNotEqualTo",
+	OpLessThan:             "PropertyIsLessThan",
+	OpLessThanOrEqualTo:    "PropertyIsLessThanOrEqualTo",
+	OpGreaterThan:          "PropertyIsGreaterThan",
+	OpGreaterThanOrEqualTo: "PropertyIsGreaterThanOrEqualTo",
+}
+
+// spatialElements are the Filter Encoding elements for each spatial operator
+var spatialElements = map[SpatialOperator]string{
+	OpIntersects: "Intersects",
+	OpDisjoint:   "Disjoint",
+	OpContains:   "Contains",
+	OpWithin:     "Within",
+	OpTouches:    "Touches",
+	OpCrosses:    "Crosses",
+	OpOverlaps:   "Overlaps",
+	OpEquals:     "Equals",
+}
+
+// distanceElements are the Filter Encoding elements for each distance operator
+var distanceElements = map[DistanceOperator]string{
+	OpDWithin: "DWithin",
+	OpBeyond:  "Beyond",
+}
+
+// temporalElements are the Filter Encoding 2.0 elements for each temporal operator
+var temporalElements = map[TemporalOperator]string{
+	OpBefore:  "Before",
+	OpAfter:   "After",
+	OpDuring:  "During",
+	OpTEquals: "TEquals",
+}
+
+// ToFilterXML renders the filter as OGC Filter Encoding XML of the provided version,
+// returning an error if the filter is invalid or cannot be expressed in that version.
+func ToFilterXML(filter Filter, version FilterEncodingVersion) (string, error) {
+	if filter == nil {
+		return "", errors.New("unable to render a nil filter as XML")
+	}
+
+	writer, err := newFilterXMLWriter(version)
+	if err != nil {
+		return "", err
+	}
+
+	if version == FilterEncoding11 {
+		writer.buffer.WriteString(`<ogc:Filter xmlns:ogc="` + ogcNamespace + `" xmlns:gml="` + gml3Namespace + `">`)
+	} else {
+		writer.buffer.WriteString(`<fes:Filter xmlns:fes="` + fesNamespace + `" xmlns:gml="` + gml32Namespace + `">`)
+	}
+
+	if err := filter.writeXML(writer); err != nil {
+		return "", err
+	}
+
+	writer.close("Filter")
+	return writer.buffer.String(), nil
+}
+
+// ParseFilterXML parses OGC Filter Encoding 1.1 or 2.0 XML into a Filter, returning an error if it is not possible.
+// Literals are parsed as int64, float64 or bool when they can be, and as strings otherwise.
+func ParseFilterXML(filterXML string) (Filter, error) {
+	root := &filterXMLNode{}
+	if err := xml.Unmarshal([]byte(filterXML), root); err != nil {
+		return nil, err
+	}
+
+	if root.XMLName.Local != "Filter" {
+		return nil, fmt.Errorf("expected a Filter element but found '%s'", root.XMLName.Local)
+	}
+	if len(root.Children) != 1 {
+		return nil, fmt.Errorf("expected a Filter with a single operator but found %d", len(root.Children))
+	}
+
+	return root.Children[0].toFilter()
+}
+
+// filterXMLWriter writes Filter Encoding XML for a specific version of the standard
+type filterXMLWriter struct {
+	buffer  *bytes.Buffer
+	version FilterEncodingVersion
+	prefix  string
+	gmlID   int
+}
+
+// newFilterXMLWriter creates a new filterXMLWriter for the version
+func newFilterXMLWriter(version FilterEncodingVersion) (*filterXMLWriter, error) {
+	writer := &filterXMLWriter{
+		buffer:  &bytes.Buffer{},
+		version: version,
+	}
+
+	switch version {
+	case FilterEncoding11:
+		writer.prefix = "ogc:"
+	case FilterEncoding20:
+		writer.prefix = "fes:"
+	default:
+		return nil, fmt.Errorf("unsupported filter encoding version '%s'", version)
+	}
+	return writer, nil
+}
+
+// open writes the start of a filter element, attributes are provided as name value pairs
+func (writer *filterXMLWriter) open(name string, attributes ...string) {
+	writer.openElement(writer.prefix+name, attributes...)
+}
+
+// close writes the end of a filter element
+func (writer *filterXMLWriter) close(name string) {
+	writer.buffer.WriteString("</" + writer.prefix + name + ">")
+}
+
+// openElement writes the start of an element, attributes are provided as name value pairs
+func (writer *filterXMLWriter) openElement(name string, attributes ...string) {
+	writer.buffer.WriteString("<" + name)
+	for i := 0; i+1 < len(attributes); i += 2 {
+		writer.buffer.WriteString(" " + attributes[i] + `="`)
+		writer.text(attributes[i+1])
+		writer.buffer.WriteString(`"`)
+	}
+	writer.buffer.WriteString(">")
+}
+
+// element writes an element containing only text
+func (writer *filterXMLWriter) element(name string, text string) {
+	writer.openElement(name)
+	writer.text(text)
+	writer.buffer.WriteString("</" + name + ">")
+}
+
+// text writes escaped text
+func (writer *filterXMLWriter) text(text string) {
+	xml.EscapeText(writer.buffer, []byte(text)) // nolint: errcheck
+}
+
+// property writes a property name, which is a PropertyName in 1.1 and a ValueReference in 2.0
+func (writer *filterXMLWriter) property(property string) error {
+	if property == "" {
+		return errors.New("filter property name must not be empty")
+	}
+
+	name := "ValueReference"
+	if writer.version == FilterEncoding11 {
+		name = "PropertyName"
+	}
+	writer.element(writer.prefix+name, property)
+	return nil
+}
+
+// literal writes a literal value
+func (writer *filterXMLWriter) literal(value interface{}) error {
+	var text string
+	switch v := normaliseLiteral(value).(type) {
+	case string:
+		text = v
+	case int64:
+		text = strconv.FormatInt(v, 10)
+	case float64:
+		text = formatFloat(v)
+	case bool:
+		text = strconv.FormatBool(v)
+	default:
+		return fmt.Errorf("unsupported filter literal '%v'", value)
+	}
+	writer.element(writer.prefix+"Literal", text)
+	return nil
+}
+
+// nextGMLID returns a unique gml:id for the document, as required by GML 3.2 temporal objects
+func (writer *filterXMLWriter) nextGMLID() string {
+	writer.gmlID++
+	return fmt.Sprintf("t%d", writer.gmlID)
+}
+
+func (filter *ComparisonFilter) writeXML(writer *filterXMLWriter) error {
+	name, ok := comparisonElements[filter.Operator]
+	if !ok {
+		return fmt.Errorf("unsupported comparison operator '%s'", filter.Operator)
+	}
+	writer.open(name)
+	if err := writer.property(filter.Property); err != nil {
+		return err
+	}
+	if err := writer.literal(filter.Value); err != nil {
+		return err
+	}
+	writer.close(name)
+	return nil
+}
+
+func (filter *BetweenFilter) writeXML(writer *filterXMLWriter) error {
+	writer.open("PropertyIsBetween")
+	if err := writer.property(filter.Property); err != nil {
+		return err
+	}
+	writer.open("LowerBoundary")
+	if err := writer.literal(filter.Lower); err != nil {
+		return err
+	}
+	writer.close("LowerBoundary")
+	writer.open("UpperBoundary")
+	if err := writer.literal(filter.Upper); err != nil {
+		return err
+	}
+	writer.close("UpperBoundary")
+	writer.close("PropertyIsBetween")
+	return nil
+}
+
+func (filter *LikeFilter) writeXML(writer *filterXMLWriter) error {
+	writer.open("PropertyIsLike",
+		"wildCard", "%",
+		"singleChar", "_",
+		"escapeChar", `\`,
+		"matchCase", strconv.FormatBool(filter.MatchCase),
+	)
+	if err := writer.property(filter.Property); err != nil {
+		return err
+	}
+	if err := writer.literal(filter.Pattern); err != nil {
+		return err
+	}
+	writer.close("PropertyIsLike")
+	return nil
+}
+
+func (filter *NullFilter) writeXML(writer *filterXMLWriter) error {
+	writer.open("PropertyIsNull")
+	if err := writer.property(filter.Property); err != nil {
+		return err
+	}
+	writer.close("PropertyIsNull")
+	return nil
+}
+
+func (filter *InFilter) writeXML(writer *filterXMLWriter) error {
+	if len(filter.Values) == 0 {
+		return errors.New("IN filter requires at least one value")
+	}
+
+	// Filter Encoding has no IN operator, so it is expressed as a disjunction of equalities
+	equalities := make([]Filter, 0)
+	for _, value := range filter.Values {
+		equalities = append(equalities, Equal(filter.Property, value))
+	}
+	return writeXMLOperands(writer, "Or", equalities)
+}
+
+func (filter *AndFilter) writeXML(writer *filterXMLWriter) error {
+	return writeXMLOperands(writer, "And", filter.Filters)
+}
+
+func (filter *OrFilter) writeXML(writer *filterXMLWriter) error {
+	return writeXMLOperands(writer, "Or", filter.Filters)
+}
+
+// writeXMLOperands writes a logical operator, a single filter is written without the operator
+func writeXMLOperands(writer *filterXMLWriter, name string, filters []Filter) error {
+	if len(filters) == 0 {
+		return fmt.Errorf("%s filter requires at least one filter", strings.ToUpper(name))
+	}
+	if len(filters) == 1 {
+		return filters[0].writeXML(writer)
+	}
+
+	writer.open(name)
+	for _, filter := range filters {
+		if err := filter.writeXML(writer); err != nil {
+			return err
+		}
+	}
+	writer.close(name)
+	return nil
+}
+
+func (filter *NotFilter) writeXML(writer *filterXMLWriter) error {
+	if filter.Filter == nil {
+		return errors.New("NOT filter requires a filter")
+	}
+	writer.open("Not")
+	if err := filter.Filter.writeXML(writer); err != nil {
+		return err
+	}
+	writer.close("Not")
+	return nil
+}
+
+func (filter *BBoxFilter) writeXML(writer *filterXMLWriter) error {
+	writer.open("BBOX")
+	if err := writer.property(filter.Property); err != nil {
+		return err
+	}
+	if filter.CRS != "" {
+		writer.openElement("gml:Envelope", "srsName", filter.CRS)
+	} else {
+		writer.openElement("gml:Envelope")
+	}
+	writer.element("gml:lowerCorner", formatFloat(filter.MinX)+" "+formatFloat(filter.MinY))
+	writer.element("gml:upperCorner", formatFloat(filter.MaxX)+" "+formatFloat(filter.MaxY))
+	writer.buffer.WriteString("</gml:Envelope>")
+	writer.close("BBOX")
+	return nil
+}
+
+func (filter *SpatialFilter) writeXML(writer *filterXMLWriter) error {
+	name, ok := spatialElements[filter.Operator]
+	if !ok {
+		return fmt.Errorf("unsupported spatial operator '%s'", filter.Operator)
+	}
+	geometry, err := parseWKT(filter.Geometry)
+	if err != nil {
+		return err
+	}
+
+	writer.open(name)
+	if err := writer.property(filter.Property); err != nil {
+		return err
+	}
+	geometry.writeGML(writer.buffer)
+	writer.close(name)
+	return nil
+}
+
+func (filter *DistanceFilter) writeXML(writer *filterXMLWriter) error {
+	name, ok := distanceElements[filter.Operator]
+	if !ok {
+		return fmt.Errorf("unsupported distance operator '%s'", filter.Operator)
+	}
+	geometry, err := parseWKT(filter.Geometry)
+	if err != nil {
+		return err
+	}
+
+	units := filter.Units
+	if units == "" {
+		units = defaultDistanceUnits
+	}
+	unitsAttribute := "uom"
+	if writer.version == FilterEncoding11 {
+		unitsAttribute = "units"
+	}
+
+	writer.open(name)
+	if err := writer.property(filter.Property); err != nil {
+		return err
+	}
+	geometry.writeGML(writer.buffer)
+	writer.open("Distance", unitsAttribute, units)
+	writer.text(formatFloat(filter.Distance))
+	writer.close("Distance")
+	writer.close(name)
+	return nil
+}
+
+func (filter *TemporalFilter) writeXML(writer *filterXMLWriter) error {
+	name, ok := temporalElements[filter.Operator]
+	if !ok {
+		return fmt.Errorf("unsupported temporal operator '%s'", filter.Operator)
+	}
+	if writer.version == FilterEncoding11 {
+		return fmt.Errorf("temporal operator '%s' requires filter encoding %s", filter.Operator, FilterEncoding20)
+	}
+
+	writer.open(name)
+	if err := writer.property(filter.Property); err != nil {
+		return err
+	}
+	if filter.Operator == OpDuring {
+		writer.openElement("gml:TimePeriod", "gml:id", writer.nextGMLID())
+		writer.element("gml:beginPosition", formatTime(filter.Time))
+		writer.element("gml:endPosition", formatTime(filter.End))
+		writer.buffer.WriteString("</gml:TimePeriod>")
+	} else {
+		writer.openElement("gml:TimeInstant", "gml:id", writer.nextGMLID())
+		writer.element("gml:timePosition", formatTime(filter.Time))
+		writer.buffer.WriteString("</gml:TimeInstant>")
+	}
+	writer.close(name)
+	return nil
+}
+
+/**
+ * Parsing
+ */
+
+// filterXMLNode is a generic XML element, used to parse Filter Encoding XML
+type filterXMLNode struct {
+	XMLName    xml.Name
+	Attributes []xml.Attr
+	Children   []*filterXMLNode
+	Text       string
+}
+
+// UnmarshalXML unmarshals an element along with all of its attributes and children
+func (node *filterXMLNode) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	node.XMLName = start.Name
+	node.Attributes = start.Attr
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			child := &filterXMLNode{}
+			if err := child.UnmarshalXML(decoder, t); err != nil {
+				return err
+			}
+			node.Children = append(node.Children, child)
+		case xml.CharData:
+			node.Text += string(t)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// attribute returns the value of the attribute with the local name, or an empty string
+func (node *filterXMLNode) attribute(name string) string {
+	for _, attribute := range node.Attributes {
+		if attribute.Name.Local == name {
+			return attribute.Value
+		}
+	}
+	return ""
+}
+
+// child returns the first child with the local name, or nil
+func (node *filterXMLNode) child(name string) *filterXMLNode {
+	for _, child := range node.Children {
+		if child.XMLName.Local == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// children returns all children with the local name
+func (node *filterXMLNode) children(name string) []*filterXMLNode {
+	result := make([]*filterXMLNode, 0)
+	for _, child := range node.Children {
+		if child.XMLName.Local == name {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// property returns the property name of an operator, from either a PropertyName or a ValueReference
+func (node *filterXMLNode) property() (string, error) {
+	for _, child := range node.Children {
+		if child.XMLName.Local == "PropertyName" || child.XMLName.Local == "ValueReference" {
+			return strings.TrimSpace(child.Text), nil
+		}
+	}
+	return "", fmt.Errorf("'%s' has no property name", node.XMLName.Local)
+}
+
+// literal returns the literal of an operator
+func (node *filterXMLNode) literal() (interface{}, error) {
+	literal := node.child("Literal")
+	if literal == nil {
+		return nil, fmt.Errorf("'%s' has no literal", node.XMLName.Local)
+	}
+	return parseLiteralText(literal.Text), nil
+}
+
+// geometry returns the GML geometry of a spatial operator as WKT
+func (node *filterXMLNode) geometry() (string, error) {
+	for _, child := range node.Children {
+		switch child.XMLName.Local {
+		case "PropertyName", "ValueReference", "Literal", "Distance":
+			continue
+		}
+
+		geometry, err := parseGML(child)
+		if err != nil {
+			return "", err
+		}
+		return geometry.String(), nil
+	}
+	return "", fmt.Errorf("'%s' has no geometry", node.XMLName.Local)
+}
+
+// toFilter converts the element into a Filter
+func (node *filterXMLNode) toFilter() (Filter, error) {
+	name := node.XMLName.Local
+
+	for operator, element := range comparisonElements {
+		if element == name {
+			return node.toComparisonFilter(operator)
+		}
+	}
+	for operator, element := range spatialElements {
+		if element == name {
+			return node.toSpatialFilter(operator)
+		}
+	}
+	for operator, element := range distanceElements {
+		if element == name {
+			return node.toDistanceFilter(operator)
+		}
+	}
+	for operator, element := range temporalElements {
+		if element == name {
+			return node.toTemporalFilter(operator)
+		}
+	}
+
+	switch name {
+	case "PropertyIsBetween":
+		return node.toBetweenFilter()
+	case "PropertyIsLike":
+		return node.toLikeFilter()
+	case "PropertyIsNull":
+		property, err := node.property()
+		if err != nil {
+			return nil, err
+		}
+		return IsNull(property), nil
+	case "And", "Or":
+		filters, err := node.toFilters()
+		if err != nil {
+			return nil, err
+		}
+		if name == "And" {
+			return And(filters...), nil
+		}
+		return Or(filters...), nil
+	case "Not":
+		if len(node.Children) != 1 {
+			return nil, errors.New("'Not' must contain a single filter")
+		}
+		filter, err := node.Children[0].toFilter()
+		if err != nil {
+			return nil, err
+		}
+		return Not(filter), nil
+	case "BBOX":
+		return node.toBBoxFilter()
+	}
+
+	return nil, fmt.Errorf("unsupported filter operator '%s'", name)
+}
+
+// toFilters converts all the children of the element into filters
+func (node *filterXMLNode) toFilters() ([]Filter, error) {
+	filters := make([]Filter, 0)
+	for _, child := range node.Children {
+		filter, err := child.toFilter()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+func (node *filterXMLNode) toComparisonFilter(operator ComparisonOperator) (Filter, error) {
+	property, err := node.property()
+	if err != nil {
+		return nil, err
+	}
+	value, err := node.literal()
+	if err != nil {
+		return nil, err
+	}
+	return newComparisonFilter(property, operator, value), nil
+}
+
+func (node *filterXMLNode) toBetweenFilter() (Filter, error) {
+	property, err := node.property()
+	if err != nil {
+		return nil, err
+	}
+
+	lowerBoundary := node.child("LowerBoundary")
+	upperBoundary := node.child("UpperBoundary")
+	if lowerBoundary == nil || upperBoundary == nil {
+		return nil, errors.New("'PropertyIsBetween' requires a lower and an upper boundary")
+	}
+
+	lower, err := lowerBoundary.literal()
+	if err != nil {
+		return nil, err
+	}
+	upper, err := upperBoundary.literal()
+	if err != nil {
+		return nil, err
+	}
+	return Between(property, lower, upper), nil
+}
+
+func (node *filterXMLNode) toLikeFilter() (Filter, error) {
+	property, err := node.property()
+	if err != nil {
+		return nil, err
+	}
+	literal := node.child("Literal")
+	if literal == nil {
+		return nil, errors.New("'PropertyIsLike' has no pattern")
+	}
+
+	escape := node.attribute("escapeChar")
+	if escape == "" {
+		escape = node.attribute("escape")
+	}
+	pattern := translateLikePattern(literal.Text, node.attribute("wildCard"), node.attribute("singleChar"), escape)
+
+	return &LikeFilter{
+		Property:  property,
+		Pattern:   pattern,
+		MatchCase: node.attribute("matchCase") != "false",
+	}, nil
+}
+
+// translateLikePattern converts a pattern using arbitrary wild card characters into one using '%', '_' and '\'
+func translateLikePattern(pattern string, wildCard string, singleChar string, escape string) string {
+	if (wildCard == "" || wildCard == "%") && (singleChar == "" || singleChar == "_") && (escape == "" || escape == `\`) {
+		return pattern
+	}
+
+	buffer := &bytes.Buffer{}
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		character := string(runes[i])
+		switch {
+		case character == escape && i+1 < len(runes):
+			i++
+			next := string(runes[i])
+			if next == "%" || next == "_" || next == `\` {
+				buffer.WriteString(`\`)
+			}
+			buffer.WriteString(next)
+		case character == wildCard:
+			buffer.WriteString("%")
+		case character == singleChar:
+			buffer.WriteString("_")
+		case character == "%" || character == "_" || character == `\`:
+			buffer.WriteString(`\` + character)
+		default:
+			buffer.WriteString(character)
+		}
+	}
+	return buffer.String()
+}
+
+func (node *filterXMLNode) toBBoxFilter() (Filter, error) {
+	property, err := node.property()
+	if err != nil {
+		return nil, err
+	}
+
+	envelope := node.child("Envelope")
+	if envelope == nil {
+		return nil, errors.New("'BBOX' requires a gml:Envelope")
+	}
+
+	lowerCorner := envelope.child("lowerCorner")
+	upperCorner := envelope.child("upperCorner")
+	if lowerCorner == nil || upperCorner == nil {
+		return nil, errors.New("gml:Envelope requires a lower and an upper corner")
+	}
+
+	lower, err := parseCoordinates(lowerCorner.Text)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := parseCoordinates(upperCorner.Text)
+	if err != nil {
+		return nil, err
+	}
+	if len(lower) != 1 || len(upper) != 1 {
+		return nil, errors.New("gml:Envelope corners must each contain a single position")
+	}
+
+	return BBox(property, lower[0][0], lower[0][1], upper[0][0], upper[0][1], envelope.attribute("srsName")), nil
+}
+
+func (node *filterXMLNode) toSpatialFilter(operator SpatialOperator) (Filter, error) {
+	property, err := node.property()
+	if err != nil {
+		return nil, err
+	}
+	geometry, err := node.geometry()
+	if err != nil {
+		return nil, err
+	}
+	return newSpatialFilter(operator, property, geometry), nil
+}
+
+func (node *filterXMLNode) toDistanceFilter(operator DistanceOperator) (Filter, error) {
+	property, err := node.property()
+	if err != nil {
+		return nil, err
+	}
+	geometry, err := node.geometry()
+	if err != nil {
+		return nil, err
+	}
+
+	distanceNode := node.child("Distance")
+	if distanceNode == nil {
+		return nil, fmt.Errorf("'%s' has no distance", node.XMLName.Local)
+	}
+	distance, err := strconv.ParseFloat(strings.TrimSpace(distanceNode.Text), 64)
+	if err != nil {
+		return nil, err
+	}
+
+	units := distanceNode.attribute("uom")
+	if units == "" {
+		units = distanceNode.attribute("units")
+	}
+	return newDistanceFilter(operator, property, geometry, distance, units), nil
+}
+
+func (node *filterXMLNode) toTemporalFilter(operator TemporalOperator) (Filter, error) {
+	property, err := node.property()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &TemporalFilter{
+		Operator: operator,
+		Property: property,
+	}
+
+	if period := node.child("TimePeriod"); period != nil {
+		begin := period.child("beginPosition")
+		end := period.child("endPosition")
+		if begin == nil || end == nil {
+			return nil, errors.New("gml:TimePeriod requires a begin and an end position")
+		}
+		if filter.Time, err = parseFilterTime(begin.Text); err != nil {
+			return nil, err
+		}
+		if filter.End, err = parseFilterTime(end.Text); err != nil {
+			return nil, err
+		}
+		return filter, nil
+	}
+
+	instant := node.child("TimeInstant")
+	if instant == nil || instant.child("timePosition") == nil {
+		return nil, fmt.Errorf("'%s' requires a gml:TimeInstant or gml:TimePeriod", node.XMLName.Local)
+	}
+	if filter.Time, err = parseFilterTime(instant.child("timePosition").Text); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// parseLiteralText parses the text of a literal into an int64, float64 or bool if possible, or a string otherwise
+func parseLiteralText(text string) interface{} {
+	trimmed := strings.TrimSpace(text)
+	if value, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+		return value
+	}
+	if value, err := strconv.ParseFloat(trimmed, 64); err == nil {
+		return value
+	}
+	if value, err := strconv.ParseBool(trimmed); err == nil && (trimmed == "true" || trimmed == "false") {
+		return value
+	}
+	return text
+}
+
+// parseFilterTime parses a time used by a temporal filter
+func parseFilterTime(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, strings.TrimSpace(value))
+}
@@ -0,0 +1,461 @@
+package geoserver
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+)
+
+// ParameterFilterType is the kind of a GeoWebCache parameter filter
+type ParameterFilterType string
+
+const (
+	// ParameterFilterTypeString only allows the parameter to take one of a list of values
+	ParameterFilterTypeString ParameterFilterType = "stringParameterFilter"
+
+	// ParameterFilterTypeRegex only allows values of the parameter which match a regular expression
+	ParameterFilterTypeRegex ParameterFilterType = "regexParameterFilter"
+
+	// ParameterFilterTypeFloat only allows the parameter to take one of a list of decimal values
+	ParameterFilterTypeFloat ParameterFilterType = "floatParameterFilter"
+
+	// ParameterFilterTypeInteger only allows the parameter to take one of a list of integer values
+	ParameterFilterTypeInteger ParameterFilterType = "integerParameterFilter"
+
+	// ParameterFilterTypeStyle only allows the STYLES parameter to take one of the styles of the layer
+	ParameterFilterTypeStyle ParameterFilterType = "styleParameterFilter"
+)
+
+// TileLayer is the GeoWebCache configuration of a layer, which determines how its tiles are cached
+type TileLayer struct {
+	// ID is the identifier GeoWebCache uses for the layer, it is assigned by GeoWebCache when empty.
+	ID string
+
+	// Name is the name of the layer, including its workspace e.g "topp:states".
+	Name string
+
+	// Enabled is true when tiles are cached for the layer.
+	Enabled bool
+
+	// MimeFormats are the image formats tiles are cached in e.g "image/png".
+	MimeFormats []string
+
+	// GridSubsets are the gridsets tiles are cached for, optionally restricted to an extent and range of zoom levels.
+	GridSubsets []*GridSubset
+
+	// MetaTilingX is the number of tiles across in each meta tile.
+	MetaTilingX int
+
+	// MetaTilingY is the number of tiles down in each meta tile.
+	MetaTilingY int
+
+	// Gutter is the number of pixels added around each meta tile, to avoid labels being cut at tile edges.
+	Gutter int
+
+	// ExpireCache is the number of seconds before a cached tile expires, 0 means never.
+	ExpireCache int
+
+	// ExpireClients is the number of seconds clients are told to cache tiles for, 0 uses the layer's default.
+	ExpireClients int
+
+	// ParameterFilters are the request parameters, other than those defining the tile, which tiles are cached for.
+	ParameterFilters []*ParameterFilter
+
+	// BlobStoreID is the blob store tiles are cached in, the default blob store is used when empty.
+	BlobStoreID string
+
+	// InMemoryCached is true when tiles are also cached in memory.
+	InMemoryCached bool
+}
+
+// GridSubset restricts the tiles cached for a gridset
+type GridSubset struct {
+	// GridSetName is the name of the gridset e.g "EPSG:4326".
+	GridSetName string
+
+	// Extent restricts the tiles to those intersecting it, in the SRS of the gridset. It is optional.
+	Extent *BoundingBox
+
+	// ZoomStart is the first zoom level tiles are available for. It is optional.
+	ZoomStart *int
+
+	// ZoomStop is the last zoom level tiles are available for. It is optional.
+	ZoomStop *int
+
+	// MinCachedLevel is the first zoom level tiles are cached for. It is optional.
+	MinCachedLevel *int
+
+	// MaxCachedLevel is the last zoom level tiles are cached for. It is optional.
+	MaxCachedLevel *int
+}
+
+// ParameterFilter allows tiles to be cached for a request parameter other than those defining the tile
+type ParameterFilter struct {
+	// Type is the kind of parameter filter.
+	Type ParameterFilterType
+
+	// Key is the name of the parameter e.g "STYLES".
+	Key string
+
+	// DefaultValue is the value used when the parameter is not provided.
+	DefaultValue string
+
+	// Values are the values the parameter can take, used by all but regular expression filters.
+	Values []string
+
+	// Regex is the regular expression values must match, used by regular expression filters.
+	Regex string
+}
+
+// ListTileLayersResponse is the response to ListTileLayers
+type ListTileLayersResponse struct {
+	// Layers are the names of the tile layers
+	Layers []string
+}
+
+// ListTileLayers lists the layers GeoWebCache caches tiles for, returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) ListTileLayers() (response *ListTileLayersResponse, err error) {
+	url := client.geoserverBaseURL + "/gwc/rest/layers.xml"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying GeoWebCache for tile layers",
+		urlKey, url,
+	)
+
+	statusCode, responseBody, err := client.doXMLRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Unable to list tile layers", url, statusCode, responseBody)
+		err = fmt.Errorf("unable to list tile layers")
+		return
+	}
+
+	restResponse := &restTileLayers{}
+	err = xml.Unmarshal(responseBody, restResponse)
+	if err != nil {
+		client.logUnexpectedResponse("GeoWebCache returned an invalid response", url, statusCode, responseBody)
+		return
+	}
+
+	response = &ListTileLayersResponse{
+		Layers: make([]string, 0),
+	}
+	for _, layer := range restResponse.Layers {
+		response.Layers = append(response.Layers, layer.Name)
+	}
+	return
+}
+
+// GetTileLayer gets the GeoWebCache configuration of a layer, returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) GetTileLayer(layer string) (tileLayer *TileLayer, err error) {
+	url := client.geoserverBaseURL + "/gwc/rest/layers/" + layer + ".xml"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying GeoWebCache for a tile layer",
+		urlKey, url,
+		"layer", layer,
+	)
+
+	statusCode, responseBody, err := client.doXMLRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Unable to get tile layer", url, statusCode, responseBody)
+		err = fmt.Errorf("unable to get tile layer '%s'", layer)
+		return
+	}
+
+	restResponse := &restTileLayer{}
+	err = xml.Unmarshal(responseBody, restResponse)
+	if err != nil {
+		client.logUnexpectedResponse("GeoWebCache returned an invalid response", url, statusCode, responseBody)
+		return
+	}
+
+	tileLayer = restTileLayerToTileLayer(restResponse)
+	return
+}
+
+// PutTileLayer creates or replaces the GeoWebCache configuration of a layer, returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) PutTileLayer(tileLayer *TileLayer) (err error) {
+	url := client.geoserverBaseURL + "/gwc/rest/layers/" + tileLayer.Name + ".xml"
+
+	restRequest := newRestTileLayer(tileLayer)
+
+	var requestXMLBytes []byte
+	requestXMLBytes, err = xml.Marshal(restRequest)
+	if err != nil {
+		return
+	}
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Putting a GeoWebCache tile layer",
+		urlKey, url,
+		requestKey, string(requestXMLBytes),
+	)
+
+	statusCode, responseBody, err := client.doXMLRequest(http.MethodPut, url, restRequest)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode || codeCreated == statusCode {
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "Tile layer put successfully",
+			urlKey, url,
+			"layer", tileLayer.Name,
+		)
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to put tile layer", url, statusCode, responseBody)
+	err = fmt.Errorf("unable to put tile layer '%s'", tileLayer.Name)
+	return
+}
+
+// DeleteTileLayer deletes the GeoWebCache configuration of a layer, along with its cached tiles, returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) DeleteTileLayer(layer string) (err error) {
+	url := client.geoserverBaseURL + "/gwc/rest/layers/" + layer + ".xml"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Deleting a GeoWebCache tile layer",
+		urlKey, url,
+		"layer", layer,
+	)
+
+	statusCode, responseBody, err := client.doXMLRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode {
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "Tile layer deleted successfully",
+			urlKey, url,
+			"layer", layer,
+		)
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to delete tile layer", url, statusCode, responseBody)
+	err = fmt.Errorf("unable to delete tile layer '%s'", layer)
+	return
+}
+
+/**
+ * REST API
+ */
+
+// restTileLayers exists in order to represent the XML returned by GeoWebCache when listing layers
+type restTileLayers struct {
+	XMLName xml.Name `xml:"layers"`
+	Layers  []*struct {
+		Name string `xml:"name"`
+	} `xml:"layer"`
+}
+
+// restTileLayer exists in order to represent the XML used by GeoWebCache for a layer
+type restTileLayer struct {
+	XMLName          xml.Name              `xml:"GeoServerLayer"`
+	ID               string                `xml:"id,omitempty"`
+	Enabled          bool                  `xml:"enabled"`
+	InMemoryCached   bool                  `xml:"inMemoryCached"`
+	Name             string                `xml:"name"`
+	MimeFormats      []string              `xml:"mimeFormats>string"`
+	GridSubsets      []*restGridSubset     `xml:"gridSubsets>gridSubset"`
+	MetaWidthHeight  []int                 `xml:"metaWidthHeight>int"`
+	ExpireCache      int                   `xml:"expireCache"`
+	ExpireClients    int                   `xml:"expireClients"`
+	ParameterFilters *restParameterFilters `xml:"parameterFilters,omitempty"`
+	Gutter           int                   `xml:"gutter"`
+	BlobStoreID      string                `xml:"blobStoreId,omitempty"`
+}
+
+// restGridSubset is a grid subset of a layer
+type restGridSubset struct {
+	GridSetName    string      `xml:"gridSetName"`
+	Extent         *restCoords `xml:"extent,omitempty"`
+	ZoomStart      *int        `xml:"zoomStart,omitempty"`
+	ZoomStop       *int        `xml:"zoomStop,omitempty"`
+	MinCachedLevel *int        `xml:"minCachedLevel,omitempty"`
+	MaxCachedLevel *int        `xml:"maxCachedLevel,omitempty"`
+}
+
+// restCoords is an extent, represented by GeoWebCache as the list minx, miny, maxx, maxy
+type restCoords struct {
+	Coords []float64 `xml:"coords>double"`
+}
+
+// restParameterFilters are the parameter filters of a layer, grouped by type
+type restParameterFilters struct {
+	StringFilters  []*restValuesParameterFilter `xml:"stringParameterFilter"`
+	RegexFilters   []*restRegexParameterFilter  `xml:"regexParameterFilter"`
+	FloatFilters   []*restFloatParameterFilter  `xml:"floatParameterFilter"`
+	IntegerFilters []*restIntParameterFilter    `xml:"integerParameterFilter"`
+	StyleFilters   []*restStyleParameterFilter  `xml:"styleParameterFilter"`
+}
+
+// restValuesParameterFilter is a parameter filter which allows a list of string values
+type restValuesParameterFilter struct {
+	Key          string   `xml:"key"`
+	DefaultValue string   `xml:"defaultValue"`
+	Values       []string `xml:"values>string"`
+}
+
+// restRegexParameterFilter is a parameter filter which allows values matching a regular expression
+type restRegexParameterFilter struct {
+	Key          string `xml:"key"`
+	DefaultValue string `xml:"defaultValue"`
+	Regex        string `xml:"regex"`
+}
+
+// restFloatParameterFilter is a parameter filter which allows a list of decimal values
+type restFloatParameterFilter struct {
+	Key          string   `xml:"key"`
+	DefaultValue string   `xml:"defaultValue"`
+	Values       []string `xml:"values>float"`
+}
+
+// restIntParameterFilter is a parameter filter which allows a list of integer values
+type restIntParameterFilter struct {
+	Key          string   `xml:"key"`
+	DefaultValue string   `xml:"defaultValue"`
+	Values       []string `xml:"values>int"`
+}
+
+// restStyleParameterFilter is a parameter filter which allows the styles of the layer
+type restStyleParameterFilter struct {
+	Key          string   `xml:"key"`
+	DefaultValue string   `xml:"defaultValue"`
+	Values       []string `xml:"allowedStyles>string"`
+}
+
+// newRestTileLayer converts a TileLayer into a restTileLayer
+func newRestTileLayer(tileLayer *TileLayer) *restTileLayer {
+	restLayer := &restTileLayer{
+		ID:             tileLayer.ID,
+		Enabled:        tileLayer.Enabled,
+		InMemoryCached: tileLayer.InMemoryCached,
+		Name:           tileLayer.Name,
+		MimeFormats:    tileLayer.MimeFormats,
+		ExpireCache:    tileLayer.ExpireCache,
+		ExpireClients:  tileLayer.ExpireClients,
+		Gutter:         tileLayer.Gutter,
+		BlobStoreID:    tileLayer.BlobStoreID,
+	}
+
+	if tileLayer.MetaTilingX > 0 && tileLayer.MetaTilingY > 0 {
+		restLayer.MetaWidthHeight = []int{tileLayer.MetaTilingX, tileLayer.MetaTilingY}
+	}
+
+	for _, subset := range tileLayer.GridSubsets {
+		restSubset := &restGridSubset{
+			GridSetName:    subset.GridSetName,
+			ZoomStart:      subset.ZoomStart,
+			ZoomStop:       subset.ZoomStop,
+			MinCachedLevel: subset.MinCachedLevel,
+			MaxCachedLevel: subset.MaxCachedLevel,
+		}
+		if subset.Extent != nil {
+			restSubset.Extent = &restCoords{
+				Coords: []float64{subset.Extent.MinX, subset.Extent.MinY, subset.Extent.MaxX, subset.Extent.MaxY},
+			}
+		}
+		restLayer.GridSubsets = append(restLayer.GridSubsets, restSubset)
+	}
+
+	if len(tileLayer.ParameterFilters) > 0 {
+		restLayer.ParameterFilters = &restParameterFilters{}
+	}
+	for _, filter := range tileLayer.ParameterFilters {
+		filters := restLayer.ParameterFilters
+		switch filter.Type {
+		case ParameterFilterTypeRegex:
+			filters.RegexFilters = append(filters.RegexFilters, &restRegexParameterFilter{filter.Key, filter.DefaultValue, filter.Regex})
+		case ParameterFilterTypeFloat:
+			filters.FloatFilters = append(filters.FloatFilters, &restFloatParameterFilter{filter.Key, filter.DefaultValue, filter.Values})
+		case ParameterFilterTypeInteger:
+			filters.IntegerFilters = append(filters.IntegerFilters, &restIntParameterFilter{filter.Key, filter.DefaultValue, filter.Values})
+		case ParameterFilterTypeStyle:
+			filters.StyleFilters = append(filters.StyleFilters, &restStyleParameterFilter{filter.Key, filter.DefaultValue, filter.Values})
+		default:
+			filters.StringFilters = append(filters.StringFilters, &restValuesParameterFilter{filter.Key, filter.DefaultValue, filter.Values})
+		}
+	}
+
+	return restLayer
+}
+
+// restTileLayerToTileLayer converts a restTileLayer into a TileLayer
+func restTileLayerToTileLayer(restLayer *restTileLayer) *TileLayer {
+	tileLayer := &TileLayer{
+		ID:             restLayer.ID,
+		Name:           restLayer.Name,
+		Enabled:        restLayer.Enabled,
+		MimeFormats:    restLayer.MimeFormats,
+		Gutter:         restLayer.Gutter,
+		ExpireCache:    restLayer.ExpireCache,
+		ExpireClients:  restLayer.ExpireClients,
+		BlobStoreID:    restLayer.BlobStoreID,
+		InMemoryCached: restLayer.InMemoryCached,
+	}
+
+	if len(restLayer.MetaWidthHeight) == 2 {
+		tileLayer.MetaTilingX = restLayer.MetaWidthHeight[0]
+		tileLayer.MetaTilingY = restLayer.MetaWidthHeight[1]
+	}
+
+	for _, restSubset := range restLayer.GridSubsets {
+		subset := &GridSubset{
+			GridSetName:    restSubset.GridSetName,
+			ZoomStart:      restSubset.ZoomStart,
+			ZoomStop:       restSubset.ZoomStop,
+			MinCachedLevel: restSubset.MinCachedLevel,
+			MaxCachedLevel: restSubset.MaxCachedLevel,
+		}
+		if restSubset.Extent != nil && len(restSubset.Extent.Coords) == 4 {
+			subset.Extent = &BoundingBox{
+				MinX: restSubset.Extent.Coords[0],
+				MinY: restSubset.Extent.Coords[1],
+				MaxX: restSubset.Extent.Coords[2],
+				MaxY: restSubset.Extent.Coords[3],
+			}
+		}
+		tileLayer.GridSubsets = append(tileLayer.GridSubsets, subset)
+	}
+
+	if filters := restLayer.ParameterFilters; filters != nil {
+		for _, filter := range filters.StringFilters {
+			tileLayer.ParameterFilters = append(tileLayer.ParameterFilters, &ParameterFilter{Type: ParameterFilterTypeString, Key: filter.Key, DefaultValue: filter.DefaultValue, Values: filter.Values})
+		}
+		for _, filter := range filters.RegexFilters {
+			tileLayer.ParameterFilters = append(tileLayer.ParameterFilters, &ParameterFilter{Type: ParameterFilterTypeRegex, Key: filter.Key, DefaultValue: filter.DefaultValue, Regex: filter.Regex})
+		}
+		for _, filter := range filters.FloatFilters {
+			tileLayer.ParameterFilters = append(tileLayer.ParameterFilters, &ParameterFilter{Type: ParameterFilterTypeFloat, Key: filter.Key, DefaultValue: filter.DefaultValue, Values: filter.Values})
+		}
+		for _, filter := range filters.IntegerFilters {
+			tileLayer.ParameterFilters = append(tileLayer.ParameterFilters, &ParameterFilter{Type: ParameterFilterTypeInteger, Key: filter.Key, DefaultValue: filter.DefaultValue, Values: filter.Values})
+		}
+		for _, filter := range filters.StyleFilters {
+			tileLayer.ParameterFilters = append(tileLayer.ParameterFilters, &ParameterFilter{Type: ParameterFilterTypeStyle, Key: filter.Key, DefaultValue: filter.DefaultValue, Values: filter.Values})
+		}
+	}
+
+	return tileLayer
+}
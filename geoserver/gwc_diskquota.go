@@ -0,0 +1,272 @@
+package geoserver
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ExpirationPolicy determines which tiles GeoWebCache removes when a disk quota is exceeded
+type ExpirationPolicy string
+
+const (
+	// ExpirationPolicyLFU removes the least frequently used tiles first
+	ExpirationPolicyLFU ExpirationPolicy = "LFU"
+
+	// ExpirationPolicyLRU removes the least recently used tiles first
+	ExpirationPolicyLRU ExpirationPolicy = "LRU"
+)
+
+// StorageUnit is a unit of storage used by disk quotas
+type StorageUnit string
+
+const (
+	// StorageUnitB is bytes
+	StorageUnitB StorageUnit = "B"
+
+	// StorageUnitKiB is kibibytes
+	StorageUnitKiB StorageUnit = "KiB"
+
+	// StorageUnitMiB is mebibytes
+	StorageUnitMiB StorageUnit = "MiB"
+
+	// StorageUnitGiB is gibibytes
+	StorageUnitGiB StorageUnit = "GiB"
+
+	// StorageUnitTiB is tebibytes
+	StorageUnitTiB StorageUnit = "TiB"
+)
+
+// cleanUpUnits are the time units GeoWebCache uses for the clean up frequency, largest first
+var cleanUpUnits = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"DAYS", 24 * time.Hour},
+	{"HOURS", time.Hour},
+	{"MINUTES", time.Minute},
+	{"SECONDS", time.Second},
+}
+
+// Quota is an amount of storage
+type Quota struct {
+	// Value is the amount of storage, in Units.
+	Value float64
+
+	// Units are the units of the value.
+	Units StorageUnit
+}
+
+// LayerQuota is the disk quota of a single layer
+type LayerQuota struct {
+	// Layer is the name of the layer, including its workspace e.g "topp:states".
+	Layer string
+
+	// ExpirationPolicy determines which tiles are removed when the quota is exceeded.
+	ExpirationPolicy ExpirationPolicy
+
+	// Quota is the storage the layer's tiles may use.
+	Quota *Quota
+}
+
+// DiskQuota is the GeoWebCache disk quota configuration, which limits the storage used by cached tiles
+type DiskQuota struct {
+	// Enabled is true when disk quotas are enforced.
+	Enabled bool
+
+	// CleanUpFrequency is how often GeoWebCache checks whether quotas are exceeded, it is rounded down to the second.
+	CleanUpFrequency time.Duration
+
+	// MaxConcurrentCleanUps is the number of layers which can be cleaned up at once.
+	MaxConcurrentCleanUps int
+
+	// GlobalExpirationPolicy determines which tiles are removed when the global quota is exceeded.
+	GlobalExpirationPolicy ExpirationPolicy
+
+	// GlobalQuota is the storage the tiles of all layers may use.
+	GlobalQuota *Quota
+
+	// LayerQuotas are quotas for individual layers. They are optional.
+	LayerQuotas []*LayerQuota
+}
+
+// GetDiskQuota gets the GeoWebCache disk quota configuration, returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) GetDiskQuota() (diskQuota *DiskQuota, err error) {
+	url := client.geoserverBaseURL + "/gwc/rest/diskquota.xml"
+
+	restResponse := &restDiskQuota{}
+	err = client.getGWCResource(url, "disk quota configuration", restResponse)
+	if err != nil {
+		return
+	}
+
+	diskQuota, err = restDiskQuotaToDiskQuota(restResponse)
+	return
+}
+
+// SetDiskQuota sets the GeoWebCache disk quota configuration, returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) SetDiskQuota(diskQuota *DiskQuota) (err error) {
+	url := client.geoserverBaseURL + "/gwc/rest/diskquota.xml"
+	return client.putGWCResource(url, "disk quota configuration", newRestDiskQuota(diskQuota))
+}
+
+// MassTruncateLayer removes all the cached tiles of a layer, for every gridset, format and parameter,
+// returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) MassTruncateLayer(layer string) (err error) {
+	return client.massTruncate(&restTruncateLayer{LayerName: layer}, fmt.Sprintf("the tiles of layer '%s'", layer))
+}
+
+// MassTruncateOrphans removes the cached tiles of a layer which were rendered for parameter sets that the layer's
+// parameter filters no longer allow, returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) MassTruncateOrphans(layer string) (err error) {
+	return client.massTruncate(&restTruncateOrphans{LayerName: layer}, fmt.Sprintf("the orphaned tiles of layer '%s'", layer))
+}
+
+// massTruncate sends a mass truncate request to GeoWebCache
+func (client *RestGeoserverClient) massTruncate(request interface{}, description string) (err error) {
+	url := client.geoserverBaseURL + "/gwc/rest/masstruncate"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Truncating "+description,
+		urlKey, url,
+	)
+
+	statusCode, responseBody, err := client.doXMLRequest(http.MethodPost, url, request)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode {
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "Truncated "+description+" successfully",
+			urlKey, url,
+		)
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to truncate "+description, url, statusCode, responseBody)
+	err = fmt.Errorf("unable to truncate %s", description)
+	return
+}
+
+/**
+ * REST API
+ */
+
+// restDiskQuota exists in order to represent the XML used by GeoWebCache for the disk quota configuration
+type restDiskQuota struct {
+	XMLName                    xml.Name          `xml:"gwcQuotaConfiguration"`
+	Enabled                    bool              `xml:"enabled"`
+	CacheCleanUpFrequency      int64             `xml:"cacheCleanUpFrequency"`
+	CacheCleanUpUnits          string            `xml:"cacheCleanUpUnits"`
+	MaxConcurrentCleanUps      int               `xml:"maxConcurrentCleanUps"`
+	GlobalExpirationPolicyName ExpirationPolicy  `xml:"globalExpirationPolicyName,omitempty"`
+	GlobalQuota                *restQuota        `xml:"globalQuota,omitempty"`
+	LayerQuotas                []*restLayerQuota `xml:"layerQuotas>LayerQuota"`
+}
+
+// restQuota is an amount of storage
+type restQuota struct {
+	Value float64     `xml:"value"`
+	Units StorageUnit `xml:"units"`
+}
+
+// restLayerQuota is the disk quota of a layer
+type restLayerQuota struct {
+	Layer                string           `xml:"layer"`
+	ExpirationPolicyName ExpirationPolicy `xml:"expirationPolicyName"`
+	Quota                *restQuota       `xml:"quota"`
+}
+
+// restTruncateLayer exists in order to represent the XML required by GeoWebCache to truncate a layer
+type restTruncateLayer struct {
+	XMLName   xml.Name `xml:"truncateLayer"`
+	LayerName string   `xml:"layerName"`
+}
+
+// restTruncateOrphans exists in order to represent the XML required by GeoWebCache to truncate the tiles of a layer
+// cached for parameter sets which are no longer allowed
+type restTruncateOrphans struct {
+	XMLName   xml.Name `xml:"truncateOrphans"`
+	LayerName string   `xml:"layerName"`
+}
+
+// newRestQuota converts a Quota into a restQuota, or nil if there is none
+func newRestQuota(quota *Quota) *restQuota {
+	if quota == nil {
+		return nil
+	}
+	return &restQuota{Value: quota.Value, Units: quota.Units}
+}
+
+// restQuotaToQuota converts a restQuota into a Quota, or nil if there is none
+func restQuotaToQuota(quota *restQuota) *Quota {
+	if quota == nil {
+		return nil
+	}
+	return &Quota{Value: quota.Value, Units: quota.Units}
+}
+
+// newRestDiskQuota converts a DiskQuota into a restDiskQuota
+func newRestDiskQuota(diskQuota *DiskQuota) *restDiskQuota {
+	restRequest := &restDiskQuota{
+		Enabled:                    diskQuota.Enabled,
+		MaxConcurrentCleanUps:      diskQuota.MaxConcurrentCleanUps,
+		GlobalExpirationPolicyName: diskQuota.GlobalExpirationPolicy,
+		GlobalQuota:                newRestQuota(diskQuota.GlobalQuota),
+	}
+
+	for _, unit := range cleanUpUnits {
+		if diskQuota.CleanUpFrequency%unit.duration == 0 || unit.duration == time.Second {
+			restRequest.CacheCleanUpFrequency = int64(diskQuota.CleanUpFrequency / unit.duration)
+			restRequest.CacheCleanUpUnits = unit.name
+			break
+		}
+	}
+
+	for _, layerQuota := range diskQuota.LayerQuotas {
+		restRequest.LayerQuotas = append(restRequest.LayerQuotas, &restLayerQuota{
+			Layer:                layerQuota.Layer,
+			ExpirationPolicyName: layerQuota.ExpirationPolicy,
+			Quota:                newRestQuota(layerQuota.Quota),
+		})
+	}
+
+	return restRequest
+}
+
+// restDiskQuotaToDiskQuota converts a restDiskQuota into a DiskQuota
+func restDiskQuotaToDiskQuota(restResponse *restDiskQuota) (diskQuota *DiskQuota, err error) {
+	diskQuota = &DiskQuota{
+		Enabled:                restResponse.Enabled,
+		MaxConcurrentCleanUps:  restResponse.MaxConcurrentCleanUps,
+		GlobalExpirationPolicy: restResponse.GlobalExpirationPolicyName,
+		GlobalQuota:            restQuotaToQuota(restResponse.GlobalQuota),
+	}
+
+	for _, unit := range cleanUpUnits {
+		if unit.name == restResponse.CacheCleanUpUnits {
+			diskQuota.CleanUpFrequency = time.Duration(restResponse.CacheCleanUpFrequency) * unit.duration
+		}
+	}
+	if diskQuota.CleanUpFrequency == 0 && restResponse.CacheCleanUpFrequency != 0 {
+		err = fmt.Errorf("unable to read the disk quota clean up frequency, '%s' is not a supported unit", restResponse.CacheCleanUpUnits)
+		return
+	}
+
+	for _, layerQuota := range restResponse.LayerQuotas {
+		diskQuota.LayerQuotas = append(diskQuota.LayerQuotas, &LayerQuota{
+			Layer:            layerQuota.Layer,
+			ExpirationPolicy: layerQuota.ExpirationPolicyName,
+			Quota:            restQuotaToQuota(layerQuota.Quota),
+		})
+	}
+	return
+}
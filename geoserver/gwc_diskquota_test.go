@@ -0,0 +1,60 @@
+package geoserver
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDiskQuotasRoundTripThroughTheGeoWebCacheRepresentation(t *testing.T) {
+	diskQuota := &DiskQuota{
+		Enabled:                true,
+		CleanUpFrequency:       90 * time.Minute,
+		MaxConcurrentCleanUps:  2,
+		GlobalExpirationPolicy: ExpirationPolicyLFU,
+		GlobalQuota:            &Quota{Value: 500, Units: StorageUnitMiB},
+		LayerQuotas: []*LayerQuota{
+			{Layer: "topp:states", ExpirationPolicy: ExpirationPolicyLRU, Quota: &Quota{Value: 1.5, Units: StorageUnitGiB}},
+		},
+	}
+
+	body, err := xml.Marshal(newRestDiskQuota(diskQuota))
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `<cacheCleanUpFrequency>90</cacheCleanUpFrequency><cacheCleanUpUnits>MINUTES</cacheCleanUpUnits>`)
+	assert.Contains(t, string(body), `<layerQuotas><LayerQuota><layer>topp:states</layer><expirationPolicyName>LRU</expirationPolicyName><quota><value>1.5</value><units>GiB</units></quota></LayerQuota></layerQuotas>`)
+
+	parsed := &restDiskQuota{}
+	assert.NoError(t, xml.Unmarshal(body, parsed))
+
+	roundTripped, err := restDiskQuotaToDiskQuota(parsed)
+	assert.NoError(t, err)
+	assert.Equal(t, diskQuota, roundTripped)
+}
+
+func TestDiskQuotaCleanUpFrequencyIsRoundedToTheSecond(t *testing.T) {
+	restRequest := newRestDiskQuota(&DiskQuota{CleanUpFrequency: 1500 * time.Millisecond})
+	assert.Equal(t, int64(1), restRequest.CacheCleanUpFrequency)
+	assert.Equal(t, "SECONDS", restRequest.CacheCleanUpUnits)
+}
+
+func TestMassTruncateSendsTheLayerOrOrphansRequest(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, r.Method+" "+r.URL.Path+" "+string(body))
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	assert.NoError(t, client.MassTruncateLayer("topp:states"))
+	assert.NoError(t, client.MassTruncateOrphans("topp:states"))
+	assert.Equal(t, []string{
+		"POST /gwc/rest/masstruncate <truncateLayer><layerName>topp:states</layerName></truncateLayer>",
+		"POST /gwc/rest/masstruncate <truncateOrphans><layerName>topp:states</layerName></truncateOrphans>",
+	}, bodies)
+}
@@ -0,0 +1,644 @@
+package geoserver
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// epsgPrefix prefixes the SRS of gridsets
+	epsgPrefix = "EPSG:"
+
+	// defaultPixelSize is the size of a pixel in metres assumed by OGC standards, used to calculate scale denominators
+	defaultPixelSize = 0.00028
+)
+
+// GridSet is a GeoWebCache gridset, which defines how a coordinate reference system is divided into tiles
+type GridSet struct {
+	// Name is the name of the gridset e.g "EPSG:4326".
+	Name string
+
+	// Description describes the gridset.
+	Description string
+
+	// SRS is the coordinate reference system of the gridset e.g "EPSG:4326".
+	SRS string
+
+	// Extent is the area covered by the gridset, in its SRS.
+	Extent *BoundingBox
+
+	// AlignTopLeft is true when tiles are aligned to the top left of the extent rather than the bottom left.
+	AlignTopLeft bool
+
+	// Resolutions are the resolutions of each zoom level, in units per pixel.
+	// Either Resolutions or ScaleDenominators should be provided, see ResolutionsForExtent.
+	Resolutions []float64
+
+	// ScaleDenominators are the scale denominators of each zoom level.
+	ScaleDenominators []float64
+
+	// ScaleNames are the names of each zoom level. They are optional.
+	ScaleNames []string
+
+	// MetersPerUnit is the number of metres in a unit of the SRS, it is 1 for projected SRSs.
+	MetersPerUnit float64
+
+	// PixelSize is the size of a pixel in metres, the OGC standard of 0.28mm is used when it is zero.
+	PixelSize float64
+
+	// TileWidth is the width of tiles in pixels.
+	TileWidth int
+
+	// TileHeight is the height of tiles in pixels.
+	TileHeight int
+
+	// YCoordinateFirst is true when the SRS has the Y axis first, as EPSG:4326 does.
+	YCoordinateFirst bool
+}
+
+// S3Access is the access granted to the tiles stored in an S3 bucket
+type S3Access string
+
+const (
+	// S3AccessPublic makes the tiles publicly readable
+	S3AccessPublic S3Access = "PUBLIC"
+
+	// S3AccessPrivate keeps the tiles private to the bucket owner
+	S3AccessPrivate S3Access = "PRIVATE"
+)
+
+// BlobStore is a GeoWebCache blob store, in which tiles are cached. It is either a *FileBlobStore or a *S3BlobStore.
+type BlobStore interface {
+	// Info returns the configuration shared by every kind of blob store.
+	Info() *BlobStoreInfo
+}
+
+// BlobStoreInfo is the configuration shared by every kind of blob store
+type BlobStoreInfo struct {
+	// ID is the name of the blob store.
+	ID string
+
+	// Enabled is true when tiles can be stored in the blob store.
+	Enabled bool
+
+	// Default is true when tiles of layers without a blob store are stored in it.
+	Default bool
+}
+
+// FileBlobStore is a blob store which stores tiles in a directory
+type FileBlobStore struct {
+	BlobStoreInfo
+
+	// BaseDirectory is the directory tiles are stored in.
+	BaseDirectory string
+
+	// FileSystemBlockSize is the block size of the file system, used to estimate disk usage.
+	FileSystemBlockSize int
+}
+
+// S3BlobStore is a blob store which stores tiles in an Amazon S3, or S3 compatible, bucket
+type S3BlobStore struct {
+	BlobStoreInfo
+
+	// Bucket is the name of the bucket tiles are stored in.
+	Bucket string
+
+	// Prefix is prepended to the keys of the tiles, so that several blob stores can share a bucket.
+	Prefix string
+
+	// Region is the AWS region of the bucket, it is optional.
+	Region string
+
+	// AWSAccessKey is the access key used to authenticate with S3.
+	AWSAccessKey string
+
+	// AWSSecretKey is the secret key used to authenticate with S3.
+	AWSSecretKey string
+
+	// Endpoint is the URL of an S3 compatible service, Amazon S3 is used when it is empty.
+	Endpoint string
+
+	// Access is the access granted to the tiles, GeoWebCache's default is used when it is empty.
+	Access S3Access
+
+	// MaxConnections is the maximum number of concurrent connections to S3, GeoWebCache's default is used when it is zero.
+	MaxConnections int
+
+	// UseHTTPS is true when S3 is accessed using HTTPS.
+	UseHTTPS bool
+
+	// UseGzip is true when the tiles are compressed.
+	UseGzip bool
+}
+
+// Info returns the configuration shared by every kind of blob store
+func (info *BlobStoreInfo) Info() *BlobStoreInfo {
+	return info
+}
+
+// ListGridSetsResponse is the response to ListGridSets
+type ListGridSetsResponse struct {
+	// GridSets are the names of the gridsets
+	GridSets []string
+}
+
+// ListBlobStoresResponse is the response to ListBlobStores
+type ListBlobStoresResponse struct {
+	// BlobStores are the names of the blob stores
+	BlobStores []string
+}
+
+// ResolutionsForExtent calculates the resolutions of a number of zoom levels, such that the first zoom level covers
+// the extent with a single tile and each following zoom level doubles the number of tiles across and down.
+func ResolutionsForExtent(extent *BoundingBox, tileWidth int, tileHeight int, zoomLevels int) []float64 {
+	resolutions := make([]float64, 0, zoomLevels)
+	if zoomLevels <= 0 || tileWidth <= 0 || tileHeight <= 0 {
+		return resolutions
+	}
+
+	resolution := math.Max(
+		(extent.MaxX-extent.MinX)/float64(tileWidth),
+		(extent.MaxY-extent.MinY)/float64(tileHeight),
+	)
+	for i := 0; i < zoomLevels; i++ {
+		resolutions = append(resolutions, resolution)
+		resolution = resolution / 2
+	}
+	return resolutions
+}
+
+// ListGridSets lists the GeoWebCache gridsets, returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) ListGridSets() (response *ListGridSetsResponse, err error) {
+	url := client.geoserverBaseURL + "/gwc/rest/gridsets.xml"
+
+	restResponse := &restGridSets{}
+	err = client.getGWCResource(url, "gridsets", restResponse)
+	if err != nil {
+		return
+	}
+
+	response = &ListGridSetsResponse{
+		GridSets: make([]string, 0),
+	}
+	for _, gridSet := range restResponse.GridSets {
+		response.GridSets = append(response.GridSets, gridSet.Name)
+	}
+	return
+}
+
+// GetGridSet gets a GeoWebCache gridset, returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) GetGridSet(name string) (gridSet *GridSet, err error) {
+	url := client.geoserverBaseURL + "/gwc/rest/gridsets/" + name + ".xml"
+
+	restResponse := &restGridSet{}
+	err = client.getGWCResource(url, fmt.Sprintf("gridset '%s'", name), restResponse)
+	if err != nil {
+		return
+	}
+
+	gridSet = restGridSetToGridSet(restResponse)
+	return
+}
+
+// PutGridSet creates or replaces a GeoWebCache gridset, returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) PutGridSet(gridSet *GridSet) (err error) {
+	url := client.geoserverBaseURL + "/gwc/rest/gridsets/" + gridSet.Name + ".xml"
+
+	restRequest, err := newRestGridSet(gridSet)
+	if err != nil {
+		return
+	}
+
+	return client.putGWCResource(url, fmt.Sprintf("gridset '%s'", gridSet.Name), restRequest)
+}
+
+// DeleteGridSet deletes a GeoWebCache gridset, returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) DeleteGridSet(name string) (err error) {
+	url := client.geoserverBaseURL + "/gwc/rest/gridsets/" + name + ".xml"
+	return client.deleteGWCResource(url, fmt.Sprintf("gridset '%s'", name))
+}
+
+// ListBlobStores lists the GeoWebCache blob stores, returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) ListBlobStores() (response *ListBlobStoresResponse, err error) {
+	url := client.geoserverBaseURL + "/gwc/rest/blobstores.xml"
+
+	restResponse := &restBlobStores{}
+	err = client.getGWCResource(url, "blob stores", restResponse)
+	if err != nil {
+		return
+	}
+
+	response = &ListBlobStoresResponse{
+		BlobStores: make([]string, 0),
+	}
+	for _, blobStore := range restResponse.BlobStores {
+		response.BlobStores = append(response.BlobStores, blobStore.Name)
+	}
+	return
+}
+
+// GetBlobStore gets a GeoWebCache blob store, which is either a *FileBlobStore or a *S3BlobStore,
+// returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) GetBlobStore(id string) (blobStore BlobStore, err error) {
+	url := client.geoserverBaseURL + "/gwc/rest/blobstores/" + id + ".xml"
+
+	restResponse := &restBlobStoreDocument{}
+	err = client.getGWCResource(url, fmt.Sprintf("blob store '%s'", id), restResponse)
+	if err != nil {
+		return
+	}
+
+	blobStore = restResponse.BlobStore
+	return
+}
+
+// PutBlobStore creates or replaces a GeoWebCache blob store, which is either a *FileBlobStore or a *S3BlobStore,
+// returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) PutBlobStore(blobStore BlobStore) (err error) {
+	var restRequest interface{}
+	switch store := blobStore.(type) {
+	case *FileBlobStore:
+		restRequest = newRestFileBlobStore(store)
+	case *S3BlobStore:
+		restRequest = newRestS3BlobStore(store)
+	default:
+		err = fmt.Errorf("unsupported blob store type %T", blobStore)
+		return
+	}
+
+	id := blobStore.Info().ID
+	url := client.geoserverBaseURL + "/gwc/rest/blobstores/" + id + ".xml"
+	return client.putGWCResource(url, fmt.Sprintf("blob store '%s'", id), restRequest)
+}
+
+// DeleteBlobStore deletes a GeoWebCache blob store, returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) DeleteBlobStore(id string) (err error) {
+	url := client.geoserverBaseURL + "/gwc/rest/blobstores/" + id + ".xml"
+	return client.deleteGWCResource(url, fmt.Sprintf("blob store '%s'", id))
+}
+
+// getGWCResource gets a GeoWebCache resource, unmarshalling its XML into the result
+func (client *RestGeoserverClient) getGWCResource(url string, description string, result interface{}) (err error) {
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying GeoWebCache for "+description,
+		urlKey, url,
+	)
+
+	statusCode, responseBody, err := client.doXMLRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Unable to get "+description, url, statusCode, responseBody)
+		err = fmt.Errorf("unable to get %s", description)
+		return
+	}
+
+	err = xml.Unmarshal(responseBody, result)
+	if err != nil {
+		client.logUnexpectedResponse("GeoWebCache returned an invalid response", url, statusCode, responseBody)
+	}
+	return
+}
+
+// putGWCResource creates or replaces a GeoWebCache resource
+func (client *RestGeoserverClient) putGWCResource(url string, description string, payload interface{}) (err error) {
+	var requestXMLBytes []byte
+	requestXMLBytes, err = xml.Marshal(loggablePayload(payload))
+	if err != nil {
+		return
+	}
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Putting GeoWebCache "+description,
+		urlKey, url,
+		requestKey, string(requestXMLBytes),
+	)
+
+	statusCode, responseBody, err := client.doXMLRequest(http.MethodPut, url, payload)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode || codeCreated == statusCode {
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "GeoWebCache "+description+" put successfully",
+			urlKey, url,
+		)
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to put "+description, url, statusCode, responseBody)
+	err = fmt.Errorf("unable to put %s", description)
+	return
+}
+
+// deleteGWCResource deletes a GeoWebCache resource
+func (client *RestGeoserverClient) deleteGWCResource(url string, description string) (err error) {
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Deleting GeoWebCache "+description,
+		urlKey, url,
+	)
+
+	statusCode, responseBody, err := client.doXMLRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode {
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "GeoWebCache "+description+" deleted successfully",
+			urlKey, url,
+		)
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to delete "+description, url, statusCode, responseBody)
+	err = fmt.Errorf("unable to delete %s", description)
+	return
+}
+
+/**
+ * REST API
+ */
+
+// restGridSets exists in order to represent the XML returned by GeoWebCache when listing gridsets
+type restGridSets struct {
+	XMLName  xml.Name `xml:"gridSets"`
+	GridSets []*struct {
+		Name string `xml:"name"`
+	} `xml:"gridSet"`
+}
+
+// restGridSet exists in order to represent the XML used by GeoWebCache for a gridset
+type restGridSet struct {
+	XMLName           xml.Name     `xml:"gridSet"`
+	Name              string       `xml:"name"`
+	Description       string       `xml:"description,omitempty"`
+	SRS               int          `xml:"srs>number"`
+	Extent            *restCoords  `xml:"extent"`
+	AlignTopLeft      bool         `xml:"alignTopLeft"`
+	Resolutions       *restDoubles `xml:"resolutions,omitempty"`
+	ScaleDenominators *restDoubles `xml:"scaleDenominators,omitempty"`
+	MetersPerUnit     float64      `xml:"metersPerUnit,omitempty"`
+	PixelSize         float64      `xml:"pixelSize"`
+	ScaleNames        *restStrings `xml:"scaleNames,omitempty"`
+	TileHeight        int          `xml:"tileHeight"`
+	TileWidth         int          `xml:"tileWidth"`
+	YCoordinateFirst  bool         `xml:"yCoordinateFirst"`
+}
+
+// restDoubles is a list of doubles, which is omitted entirely when empty
+type restDoubles struct {
+	Values []float64 `xml:"double"`
+}
+
+// newRestDoubles converts values into restDoubles, or nil if there are none
+func newRestDoubles(values []float64) *restDoubles {
+	if len(values) == 0 {
+		return nil
+	}
+	return &restDoubles{Values: values}
+}
+
+// values returns the values of the list, which may be nil
+func (list *restDoubles) values() []float64 {
+	if list == nil {
+		return nil
+	}
+	return list.Values
+}
+
+// restStrings is a list of strings, which is omitted entirely when empty
+type restStrings struct {
+	Values []string `xml:"string"`
+}
+
+// newRestStrings converts values into restStrings, or nil if there are none
+func newRestStrings(values []string) *restStrings {
+	if len(values) == 0 {
+		return nil
+	}
+	return &restStrings{Values: values}
+}
+
+// values returns the values of the list, which may be nil
+func (list *restStrings) values() []string {
+	if list == nil {
+		return nil
+	}
+	return list.Values
+}
+
+// restBlobStores exists in order to represent the XML returned by GeoWebCache when listing blob stores
+type restBlobStores struct {
+	XMLName    xml.Name `xml:"blobStores"`
+	BlobStores []*struct {
+		Name string `xml:"name"`
+	} `xml:"blobStore"`
+}
+
+// restBlobStoreDocument exists in order to read the XML returned by GeoWebCache for a blob store, whose root element
+// depends on the kind of blob store
+type restBlobStoreDocument struct {
+	BlobStore BlobStore
+}
+
+// restFileBlobStore exists in order to represent the XML used by GeoWebCache for a file blob store
+type restFileBlobStore struct {
+	XMLName             xml.Name `xml:"FileBlobStore"`
+	Default             bool     `xml:"default,attr"`
+	ID                  string   `xml:"id"`
+	Enabled             bool     `xml:"enabled"`
+	BaseDirectory       string   `xml:"baseDirectory"`
+	FileSystemBlockSize int      `xml:"fileSystemBlockSize,omitempty"`
+}
+
+// restS3BlobStore exists in order to represent the XML used by GeoWebCache for an S3 blob store
+type restS3BlobStore struct {
+	XMLName        xml.Name `xml:"S3BlobStore"`
+	Default        bool     `xml:"default,attr"`
+	ID             string   `xml:"id"`
+	Enabled        bool     `xml:"enabled"`
+	Bucket         string   `xml:"bucket"`
+	Prefix         string   `xml:"prefix,omitempty"`
+	Region         string   `xml:"region,omitempty"`
+	AWSAccessKey   string   `xml:"awsAccessKey,omitempty"`
+	AWSSecretKey   string   `xml:"awsSecretKey,omitempty"`
+	Endpoint       string   `xml:"endpoint,omitempty"`
+	Access         S3Access `xml:"access,omitempty"`
+	MaxConnections int      `xml:"maxConnections,omitempty"`
+	UseHTTPS       bool     `xml:"useHTTPS"`
+	UseGzip        bool     `xml:"useGzip"`
+}
+
+// UnmarshalXML reads a file or S3 blob store depending on the root element
+func (document *restBlobStoreDocument) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) (err error) {
+	switch start.Name.Local {
+	case "FileBlobStore":
+		restStore := &restFileBlobStore{}
+		err = decoder.DecodeElement(restStore, &start)
+		document.BlobStore = restStore.toFileBlobStore()
+	case "S3BlobStore":
+		restStore := &restS3BlobStore{}
+		err = decoder.DecodeElement(restStore, &start)
+		document.BlobStore = restStore.toS3BlobStore()
+	default:
+		err = fmt.Errorf("unsupported blob store type '%s'", start.Name.Local)
+	}
+	return
+}
+
+// redacted returns a copy of the blob store without its secret key, so that it can be logged
+func (restStore *restS3BlobStore) redacted() interface{} {
+	redacted := *restStore
+	if redacted.AWSSecretKey != "" {
+		redacted.AWSSecretKey = redactedValue
+	}
+	return &redacted
+}
+
+// newRestFileBlobStore converts a FileBlobStore into a restFileBlobStore
+func newRestFileBlobStore(blobStore *FileBlobStore) *restFileBlobStore {
+	return &restFileBlobStore{
+		ID:                  blobStore.ID,
+		Enabled:             blobStore.Enabled,
+		Default:             blobStore.Default,
+		BaseDirectory:       blobStore.BaseDirectory,
+		FileSystemBlockSize: blobStore.FileSystemBlockSize,
+	}
+}
+
+// toFileBlobStore converts a restFileBlobStore into a FileBlobStore
+func (restStore *restFileBlobStore) toFileBlobStore() *FileBlobStore {
+	return &FileBlobStore{
+		BlobStoreInfo:       BlobStoreInfo{ID: restStore.ID, Enabled: restStore.Enabled, Default: restStore.Default},
+		BaseDirectory:       restStore.BaseDirectory,
+		FileSystemBlockSize: restStore.FileSystemBlockSize,
+	}
+}
+
+// newRestS3BlobStore converts a S3BlobStore into a restS3BlobStore
+func newRestS3BlobStore(blobStore *S3BlobStore) *restS3BlobStore {
+	return &restS3BlobStore{
+		ID:             blobStore.ID,
+		Enabled:        blobStore.Enabled,
+		Default:        blobStore.Default,
+		Bucket:         blobStore.Bucket,
+		Prefix:         blobStore.Prefix,
+		Region:         blobStore.Region,
+		AWSAccessKey:   blobStore.AWSAccessKey,
+		AWSSecretKey:   blobStore.AWSSecretKey,
+		Endpoint:       blobStore.Endpoint,
+		Access:         blobStore.Access,
+		MaxConnections: blobStore.MaxConnections,
+		UseHTTPS:       blobStore.UseHTTPS,
+		UseGzip:        blobStore.UseGzip,
+	}
+}
+
+// toS3BlobStore converts a restS3BlobStore into a S3BlobStore
+func (restStore *restS3BlobStore) toS3BlobStore() *S3BlobStore {
+	return &S3BlobStore{
+		BlobStoreInfo:  BlobStoreInfo{ID: restStore.ID, Enabled: restStore.Enabled, Default: restStore.Default},
+		Bucket:         restStore.Bucket,
+		Prefix:         restStore.Prefix,
+		Region:         restStore.Region,
+		AWSAccessKey:   restStore.AWSAccessKey,
+		AWSSecretKey:   restStore.AWSSecretKey,
+		Endpoint:       restStore.Endpoint,
+		Access:         restStore.Access,
+		MaxConnections: restStore.MaxConnections,
+		UseHTTPS:       restStore.UseHTTPS,
+		UseGzip:        restStore.UseGzip,
+	}
+}
+
+// newRestGridSet converts a GridSet into a restGridSet
+func newRestGridSet(gridSet *GridSet) (restRequest *restGridSet, err error) {
+	srs, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(gridSet.SRS), epsgPrefix))
+	if err != nil {
+		err = fmt.Errorf("unable to use SRS '%s' for gridset '%s', only EPSG codes are supported", gridSet.SRS, gridSet.Name)
+		return
+	}
+
+	if gridSet.Extent == nil {
+		err = fmt.Errorf("an extent is required for gridset '%s'", gridSet.Name)
+		return
+	}
+
+	if len(gridSet.Resolutions) == 0 && len(gridSet.ScaleDenominators) == 0 {
+		err = fmt.Errorf("either resolutions or scale denominators are required for gridset '%s'", gridSet.Name)
+		return
+	}
+
+	pixelSize := gridSet.PixelSize
+	if pixelSize == 0 {
+		pixelSize = defaultPixelSize
+	}
+
+	restRequest = &restGridSet{
+		Name:        gridSet.Name,
+		Description: gridSet.Description,
+		SRS:         srs,
+		Extent: &restCoords{
+			Coords: []float64{gridSet.Extent.MinX, gridSet.Extent.MinY, gridSet.Extent.MaxX, gridSet.Extent.MaxY},
+		},
+		AlignTopLeft:      gridSet.AlignTopLeft,
+		Resolutions:       newRestDoubles(gridSet.Resolutions),
+		ScaleDenominators: newRestDoubles(gridSet.ScaleDenominators),
+		MetersPerUnit:     gridSet.MetersPerUnit,
+		PixelSize:         pixelSize,
+		ScaleNames:        newRestStrings(gridSet.ScaleNames),
+		TileHeight:        gridSet.TileHeight,
+		TileWidth:         gridSet.TileWidth,
+		YCoordinateFirst:  gridSet.YCoordinateFirst,
+	}
+	return
+}
+
+// restGridSetToGridSet converts a restGridSet into a GridSet
+func restGridSetToGridSet(restResponse *restGridSet) *GridSet {
+	gridSet := &GridSet{
+		Name:              restResponse.Name,
+		Description:       restResponse.Description,
+		SRS:               epsgPrefix + strconv.Itoa(restResponse.SRS),
+		AlignTopLeft:      restResponse.AlignTopLeft,
+		Resolutions:       restResponse.Resolutions.values(),
+		ScaleDenominators: restResponse.ScaleDenominators.values(),
+		ScaleNames:        restResponse.ScaleNames.values(),
+		MetersPerUnit:     restResponse.MetersPerUnit,
+		PixelSize:         restResponse.PixelSize,
+		TileWidth:         restResponse.TileWidth,
+		TileHeight:        restResponse.TileHeight,
+		YCoordinateFirst:  restResponse.YCoordinateFirst,
+	}
+
+	if restResponse.Extent != nil && len(restResponse.Extent.Coords) == 4 {
+		gridSet.Extent = &BoundingBox{
+			MinX: restResponse.Extent.Coords[0],
+			MinY: restResponse.Extent.Coords[1],
+			MaxX: restResponse.Extent.Coords[2],
+			MaxY: restResponse.Extent.Coords[3],
+		}
+	}
+	return gridSet
+}
@@ -0,0 +1,116 @@
+package geoserver
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResolutionsForExtentHalvesTheResolutionAtEachZoomLevel(t *testing.T) {
+	extent := &BoundingBox{MinX: -180, MinY: -90, MaxX: 180, MaxY: 90}
+	assert.Equal(t, []float64{1.40625, 0.703125, 0.3515625}, ResolutionsForExtent(extent, 256, 256, 3))
+	assert.Equal(t, []float64{0.703125}, ResolutionsForExtent(extent, 512, 256, 1))
+	assert.Empty(t, ResolutionsForExtent(extent, 256, 256, 0))
+}
+
+func TestGridSetsRoundTripThroughTheGeoWebCacheRepresentation(t *testing.T) {
+	gridSet := &GridSet{
+		Name:             "EPSG:4326x2",
+		Description:      "Geographic with 512 pixel tiles",
+		SRS:              "EPSG:4326",
+		Extent:           &BoundingBox{MinX: -180, MinY: -90, MaxX: 180, MaxY: 90},
+		AlignTopLeft:     true,
+		Resolutions:      []float64{0.703125, 0.3515625},
+		ScaleNames:       []string{"EPSG:4326x2:0", "EPSG:4326x2:1"},
+		MetersPerUnit:    111319.49079327358,
+		PixelSize:        defaultPixelSize,
+		TileWidth:        512,
+		TileHeight:       512,
+		YCoordinateFirst: true,
+	}
+
+	restRequest, err := newRestGridSet(gridSet)
+	assert.NoError(t, err)
+
+	body, err := xml.Marshal(restRequest)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `<srs><number>4326</number></srs>`)
+	assert.NotContains(t, string(body), `scaleDenominators`)
+
+	parsed := &restGridSet{}
+	assert.NoError(t, xml.Unmarshal(body, parsed))
+	assert.Equal(t, gridSet, restGridSetToGridSet(parsed))
+}
+
+func TestNewRestGridSetRejectsInvalidGridSets(t *testing.T) {
+	extent := &BoundingBox{MinX: -180, MinY: -90, MaxX: 180, MaxY: 90}
+	for _, gridSet := range []*GridSet{
+		{Name: "a", SRS: "CRS:84", Extent: extent, Resolutions: []float64{1}},
+		{Name: "b", SRS: "EPSG:4326", Resolutions: []float64{1}},
+		{Name: "c", SRS: "EPSG:4326", Extent: extent},
+	} {
+		_, err := newRestGridSet(gridSet)
+		assert.Error(t, err, gridSet.Name)
+	}
+}
+
+func TestBlobStoresAreReadAccordingToTheirRootElement(t *testing.T) {
+	fileStore := &restBlobStoreDocument{}
+	assert.NoError(t, xml.Unmarshal([]byte(`<FileBlobStore default="true"><id>defaultCache</id><enabled>true</enabled>`+
+		`<baseDirectory>/var/cache/gwc</baseDirectory><fileSystemBlockSize>4096</fileSystemBlockSize></FileBlobStore>`), fileStore))
+	assert.Equal(t, &FileBlobStore{
+		BlobStoreInfo:       BlobStoreInfo{ID: "defaultCache", Enabled: true, Default: true},
+		BaseDirectory:       "/var/cache/gwc",
+		FileSystemBlockSize: 4096,
+	}, fileStore.BlobStore)
+
+	s3Store := &restBlobStoreDocument{}
+	assert.NoError(t, xml.Unmarshal([]byte(`<S3BlobStore default="false"><id>tiles</id><enabled>true</enabled>`+
+		`<bucket>gwc-tiles</bucket><prefix>prod</prefix><region>eu-west-1</region><awsAccessKey>AKIA</awsAccessKey>`+
+		`<awsSecretKey>secret</awsSecretKey><endpoint>http://minio:9000</endpoint><access>PRIVATE</access>`+
+		`<maxConnections>50</maxConnections><useHTTPS>false</useHTTPS><useGzip>true</useGzip></S3BlobStore>`), s3Store))
+	assert.Equal(t, &S3BlobStore{
+		BlobStoreInfo:  BlobStoreInfo{ID: "tiles", Enabled: true},
+		Bucket:         "gwc-tiles",
+		Prefix:         "prod",
+		Region:         "eu-west-1",
+		AWSAccessKey:   "AKIA",
+		AWSSecretKey:   "secret",
+		Endpoint:       "http://minio:9000",
+		Access:         S3AccessPrivate,
+		MaxConnections: 50,
+		UseGzip:        true,
+	}, s3Store.BlobStore)
+
+	assert.Error(t, xml.Unmarshal([]byte(`<AzureBlobStore><id>azure</id></AzureBlobStore>`), &restBlobStoreDocument{}))
+}
+
+func TestPutS3BlobStoreNeverLogsTheSecretKey(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestBody, _ := ioutil.ReadAll(r.Body)
+		body = string(requestBody)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := NewRestGeoserverClient(logger, &http.Client{}, server.URL, "admin", "geoserver")
+
+	err := client.PutBlobStore(&S3BlobStore{
+		BlobStoreInfo: BlobStoreInfo{ID: "tiles", Enabled: true},
+		Bucket:        "gwc-tiles",
+		AWSAccessKey:  "AKIA",
+		AWSSecretKey:  "s3cr3t-key",
+	})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(body, `<S3BlobStore default="false"><id>tiles</id>`), body)
+	assert.Contains(t, body, "<awsSecretKey>s3cr3t-key</awsSecretKey>")
+	for _, line := range logger.lines {
+		assert.NotContains(t, line, "s3cr3t")
+	}
+}
@@ -0,0 +1,358 @@
+package geoserver
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// seedType is the kind of GeoWebCache seed task
+type seedType string
+
+const (
+	// seedTypeSeed generates tiles which are missing from the cache
+	seedTypeSeed seedType = "seed"
+
+	// seedTypeReseed generates tiles, replacing those already in the cache
+	seedTypeReseed seedType = "reseed"
+
+	// seedTypeTruncate removes tiles from the cache
+	seedTypeTruncate seedType = "truncate"
+)
+
+// SeedTaskStatus is the status of a GeoWebCache seed task
+type SeedTaskStatus int
+
+const (
+	// SeedTaskAborted is the status of a task which was killed or failed
+	SeedTaskAborted SeedTaskStatus = -1
+
+	// SeedTaskPending is the status of a task waiting to run
+	SeedTaskPending SeedTaskStatus = 0
+
+	// SeedTaskRunning is the status of a running task
+	SeedTaskRunning SeedTaskStatus = 1
+
+	// SeedTaskDone is the status of a task which has completed
+	SeedTaskDone SeedTaskStatus = 2
+)
+
+// KillSeedTasksMode determines which seed tasks are killed by KillSeedTasks
+type KillSeedTasksMode string
+
+const (
+	// KillAllSeedTasks kills both running and pending tasks
+	KillAllSeedTasks KillSeedTasksMode = "all"
+
+	// KillRunningSeedTasks kills running tasks
+	KillRunningSeedTasks KillSeedTasksMode = "running"
+
+	// KillPendingSeedTasks kills pending tasks
+	KillPendingSeedTasks KillSeedTasksMode = "pending"
+)
+
+// SeedRequest is a request to seed, reseed or truncate the cached tiles of a layer
+type SeedRequest struct {
+	// Layer is the name of the layer, including its workspace e.g "topp:states".
+	Layer string
+
+	// GridSetID is the gridset of the tiles e.g "EPSG:4326".
+	GridSetID string
+
+	// Bounds restricts the tiles to those intersecting it, in the SRS of the gridset. It is optional.
+	Bounds *BoundingBox
+
+	// ZoomStart is the first zoom level of the tiles.
+	ZoomStart int
+
+	// ZoomStop is the last zoom level of the tiles.
+	ZoomStop int
+
+	// Format is the image format of the tiles e.g "image/png".
+	Format string
+
+	// ThreadCount is the number of tasks used, GeoWebCache uses a single task when it is zero.
+	ThreadCount int
+
+	// Parameters are the values of the layer's parameter filters the tiles are for. They are optional.
+	Parameters map[string]string
+}
+
+// SeedTask is the progress of a GeoWebCache seed task
+type SeedTask struct {
+	// ID is the identifier of the task.
+	ID int64
+
+	// TilesProcessed is the number of tiles the task has processed.
+	TilesProcessed int64
+
+	// TotalTiles is the number of tiles the task will process.
+	TotalTiles int64
+
+	// TimeRemaining is the estimated time until the task completes, it is negative when it cannot be estimated.
+	TimeRemaining time.Duration
+
+	// Status is the status of the task.
+	Status SeedTaskStatus
+}
+
+// GetSeedStatusResponse is the response to GetSeedStatus
+type GetSeedStatusResponse struct {
+	// Tasks are the seed tasks GeoWebCache is running or about to run
+	Tasks []*SeedTask
+}
+
+// IsComplete returns true when none of the tasks are running or pending
+func (response *GetSeedStatusResponse) IsComplete() bool {
+	for _, task := range response.Tasks {
+		if task.Status == SeedTaskRunning || task.Status == SeedTaskPending {
+			return false
+		}
+	}
+	return true
+}
+
+// Seed generates the tiles of a layer which are missing from the cache, returning an error if it is not possible.
+// The tiles are generated asynchronously, use GetSeedStatus or WaitForSeed to follow their progress.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) Seed(request *SeedRequest) (err error) {
+	return client.seed(request, seedTypeSeed)
+}
+
+// Reseed generates the tiles of a layer, replacing those already cached, returning an error if it is not possible.
+// The tiles are generated asynchronously, use GetSeedStatus or WaitForSeed to follow their progress.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) Reseed(request *SeedRequest) (err error) {
+	return client.seed(request, seedTypeReseed)
+}
+
+// Truncate removes the cached tiles of a layer, returning an error if it is not possible.
+// The tiles are removed asynchronously, use GetSeedStatus or WaitForSeed to follow their progress.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) Truncate(request *SeedRequest) (err error) {
+	return client.seed(request, seedTypeTruncate)
+}
+
+// GetSeedStatus gets the progress of the seed tasks of a layer, or of all layers when the layer is empty,
+// returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) GetSeedStatus(layer string) (response *GetSeedStatusResponse, err error) {
+	url := client.seedURL(layer) + ".json"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying GeoWebCache for the status of seed tasks",
+		urlKey, url,
+		"layer", layer,
+	)
+
+	statusCode, responseBody, err := client.doJSONRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Unable to get the status of seed tasks", url, statusCode, responseBody)
+		err = fmt.Errorf("unable to get the status of seed tasks for layer '%s'", layer)
+		return
+	}
+
+	restResponse := &restSeedStatus{}
+	err = json.Unmarshal(responseBody, restResponse)
+	if err != nil {
+		client.logUnexpectedResponse("GeoWebCache returned an invalid response", url, statusCode, responseBody)
+		return
+	}
+
+	response = restSeedStatusToGetSeedStatusResponse(restResponse)
+	return
+}
+
+// KillSeedTasks kills the seed tasks of a layer, or of all layers when the layer is empty,
+// returning an error if it is not possible.
+// It interacts with with GeoWebCache using its REST API.
+func (client *RestGeoserverClient) KillSeedTasks(layer string, mode KillSeedTasksMode) (err error) {
+	url := client.seedURL(layer)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Killing GeoWebCache seed tasks",
+		urlKey, url,
+		"layer", layer,
+		"mode", string(mode),
+	)
+
+	req, err := client.createAuthRequest(http.MethodPost, url, applicationFormURLEncoded, strings.NewReader("kill_all="+queryEscape(string(mode))))
+	if err != nil {
+		return
+	}
+
+	statusCode, responseBody, err := client.doRequest(req)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode {
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "Seed tasks killed successfully",
+			urlKey, url,
+			"layer", layer,
+		)
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to kill seed tasks", url, statusCode, responseBody)
+	err = fmt.Errorf("unable to kill seed tasks for layer '%s'", layer)
+	return
+}
+
+// WaitForSeed polls the status of the seed tasks of a layer, or of all layers when the layer is empty,
+// until none are running or pending, or the context is done.
+func (client *RestGeoserverClient) WaitForSeed(ctx context.Context, layer string, pollInterval time.Duration) (response *GetSeedStatusResponse, err error) {
+	for {
+		response, err = client.GetSeedStatus(layer)
+		if err != nil {
+			return
+		}
+
+		if response.IsComplete() {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// seed sends a seed request of the provided type to GeoWebCache
+func (client *RestGeoserverClient) seed(request *SeedRequest, seedType seedType) (err error) {
+	url := client.seedURL(request.Layer) + ".xml"
+
+	restRequest := newRestSeedRequest(request, seedType)
+
+	var requestXMLBytes []byte
+	requestXMLBytes, err = xml.Marshal(restRequest)
+	if err != nil {
+		return
+	}
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Sending a GeoWebCache seed request",
+		urlKey, url,
+		requestKey, string(requestXMLBytes),
+	)
+
+	statusCode, responseBody, err := client.doXMLRequest(http.MethodPost, url, restRequest)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode {
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "Seed request sent successfully",
+			urlKey, url,
+			"layer", request.Layer,
+			"type", string(seedType),
+		)
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to send seed request", url, statusCode, responseBody)
+	err = fmt.Errorf("unable to %s layer '%s'", seedType, request.Layer)
+	return
+}
+
+// seedURL creates the URL for the seed tasks of a layer, or of all layers when the layer is empty
+func (client *RestGeoserverClient) seedURL(layer string) string {
+	if layer == "" {
+		return client.geoserverBaseURL + "/gwc/rest/seed"
+	}
+	return client.geoserverBaseURL + "/gwc/rest/seed/" + layer
+}
+
+/**
+ * REST API
+ */
+
+// restSeedRequest exists in order to represent the XML required by GeoWebCache to seed a layer
+type restSeedRequest struct {
+	XMLName     xml.Name             `xml:"seedRequest"`
+	Name        string               `xml:"name"`
+	Bounds      *restCoords          `xml:"bounds,omitempty"`
+	GridSetID   string               `xml:"gridSetId"`
+	ZoomStart   int                  `xml:"zoomStart"`
+	ZoomStop    int                  `xml:"zoomStop"`
+	Format      string               `xml:"format"`
+	Type        seedType             `xml:"type"`
+	ThreadCount int                  `xml:"threadCount,omitempty"`
+	Parameters  []*restSeedParameter `xml:"parameters>entry,omitempty"`
+}
+
+// restSeedParameter is a parameter of a seed request, represented by GeoWebCache as a key string followed by a value string
+type restSeedParameter struct {
+	Strings []string `xml:"string"`
+}
+
+// restSeedStatus exists in order to represent the JSON returned by GeoWebCache for the status of seed tasks.
+// Each task is an array of the tiles processed, total tiles, seconds remaining, task ID and task status.
+type restSeedStatus struct {
+	Tasks [][]int64 `json:"long-array-array"`
+}
+
+// newRestSeedRequest converts a SeedRequest into a restSeedRequest
+func newRestSeedRequest(request *SeedRequest, seedType seedType) *restSeedRequest {
+	restRequest := &restSeedRequest{
+		Name:        request.Layer,
+		GridSetID:   request.GridSetID,
+		ZoomStart:   request.ZoomStart,
+		ZoomStop:    request.ZoomStop,
+		Format:      request.Format,
+		Type:        seedType,
+		ThreadCount: request.ThreadCount,
+	}
+
+	if request.Bounds != nil {
+		restRequest.Bounds = &restCoords{
+			Coords: []float64{request.Bounds.MinX, request.Bounds.MinY, request.Bounds.MaxX, request.Bounds.MaxY},
+		}
+	}
+
+	for _, key := range sortedKeys(request.Parameters) {
+		restRequest.Parameters = append(restRequest.Parameters, &restSeedParameter{
+			Strings: []string{key, request.Parameters[key]},
+		})
+	}
+
+	return restRequest
+}
+
+// restSeedStatusToGetSeedStatusResponse converts a restSeedStatus into a GetSeedStatusResponse
+func restSeedStatusToGetSeedStatusResponse(restStatus *restSeedStatus) *GetSeedStatusResponse {
+	response := &GetSeedStatusResponse{
+		Tasks: make([]*SeedTask, 0),
+	}
+	for _, task := range restStatus.Tasks {
+		if len(task) < 5 {
+			continue
+		}
+		response.Tasks = append(response.Tasks, &SeedTask{
+			TilesProcessed: task[0],
+			TotalTiles:     task[1],
+			TimeRemaining:  time.Duration(task[2]) * time.Second,
+			ID:             task[3],
+			Status:         SeedTaskStatus(task[4]),
+		})
+	}
+	return response
+}
@@ -0,0 +1,43 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewRestSeedRequestEncodesBoundsAndParameters(t *testing.T) {
+	body, err := xml.Marshal(newRestSeedRequest(&SeedRequest{
+		Layer:      "topp:states",
+		GridSetID:  "EPSG:4326",
+		Bounds:     &BoundingBox{MinX: -125, MinY: 24, MaxX: -66, MaxY: 50},
+		ZoomStart:  1,
+		ZoomStop:   5,
+		Format:     "image/png",
+		Parameters: map[string]string{"STYLES": "pophatch", "ENV": "a"},
+	}, seedTypeTruncate))
+	assert.NoError(t, err)
+	assert.Equal(t, `<seedRequest><name>topp:states</name>`+
+		`<bounds><coords><double>-125</double><double>24</double><double>-66</double><double>50</double></coords></bounds>`+
+		`<gridSetId>EPSG:4326</gridSetId><zoomStart>1</zoomStart><zoomStop>5</zoomStop><format>image/png</format><type>truncate</type>`+
+		`<parameters><entry><string>ENV</string><string>a</string></entry><entry><string>STYLES</string><string>pophatch</string></entry></parameters>`+
+		`</seedRequest>`, string(body))
+}
+
+func TestSeedStatusIsConvertedIntoTasks(t *testing.T) {
+	restStatus := &restSeedStatus{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"long-array-array":[[17888,44739250,18319,1,1],[17744,44739250,18468,2,0]]}`), restStatus))
+
+	response := restSeedStatusToGetSeedStatusResponse(restStatus)
+	assert.Equal(t, []*SeedTask{
+		{ID: 1, TilesProcessed: 17888, TotalTiles: 44739250, TimeRemaining: 18319 * time.Second, Status: SeedTaskRunning},
+		{ID: 2, TilesProcessed: 17744, TotalTiles: 44739250, TimeRemaining: 18468 * time.Second, Status: SeedTaskPending},
+	}, response.Tasks)
+	assert.False(t, response.IsComplete())
+
+	response.Tasks[0].Status = SeedTaskDone
+	response.Tasks[1].Status = SeedTaskAborted
+	assert.True(t, response.IsComplete())
+}
@@ -0,0 +1,61 @@
+package geoserver
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTileLayersRoundTripThroughTheGeoWebCacheRepresentation(t *testing.T) {
+	zoomStart := 0
+	zoomStop := 12
+	tileLayer := &TileLayer{
+		Name:        "topp:states",
+		Enabled:     true,
+		MimeFormats: []string{"image/png", "image/jpeg"},
+		GridSubsets: []*GridSubset{
+			{GridSetName: "EPSG:900913"},
+			{GridSetName: "EPSG:4326", Extent: &BoundingBox{MinX: -125, MinY: 24, MaxX: -66, MaxY: 50}, ZoomStart: &zoomStart, ZoomStop: &zoomStop},
+		},
+		MetaTilingX:   4,
+		MetaTilingY:   2,
+		Gutter:        5,
+		ExpireCache:   3600,
+		ExpireClients: 60,
+		ParameterFilters: []*ParameterFilter{
+			{Type: ParameterFilterTypeString, Key: "ENV", DefaultValue: "a", Values: []string{"a", "b"}},
+			{Type: ParameterFilterTypeRegex, Key: "CQL_FILTER", Regex: ".*"},
+			{Type: ParameterFilterTypeFloat, Key: "ELEVATION", DefaultValue: "0", Values: []string{"0", "100.5"}},
+			{Type: ParameterFilterTypeInteger, Key: "BUFFER", DefaultValue: "0", Values: []string{"0", "10"}},
+			{Type: ParameterFilterTypeStyle, Key: "STYLES", DefaultValue: "population", Values: []string{"population", "pophatch"}},
+		},
+	}
+
+	body, err := xml.Marshal(newRestTileLayer(tileLayer))
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `<metaWidthHeight><int>4</int><int>2</int></metaWidthHeight>`)
+	assert.Contains(t, string(body), `<extent><coords><double>-125</double><double>24</double><double>-66</double><double>50</double></coords></extent>`)
+
+	parsed := &restTileLayer{}
+	assert.NoError(t, xml.Unmarshal(body, parsed))
+	assert.Equal(t, tileLayer, restTileLayerToTileLayer(parsed))
+}
+
+func TestStyleParameterFiltersAreReadFromTheAllowedStylesOfGeoWebCache(t *testing.T) {
+	body := `<GeoServerLayer><id>LayerInfoImpl--570ae188:124761b8d78:-7fd0</id><enabled>true</enabled><name>topp:states</name>` +
+		`<mimeFormats><string>image/png</string></mimeFormats><parameterFilters><styleParameterFilter><key>STYLES</key>` +
+		`<defaultValue>population</defaultValue><allowedStyles class="sorted-set"><string>pophatch</string><string>population</string></allowedStyles>` +
+		`<availableStyles class="sorted-set"><string>polygon</string><string>pophatch</string><string>population</string></availableStyles>` +
+		`</styleParameterFilter></parameterFilters></GeoServerLayer>`
+
+	parsed := &restTileLayer{}
+	assert.NoError(t, xml.Unmarshal([]byte(body), parsed))
+	assert.Equal(t, []*ParameterFilter{
+		{Type: ParameterFilterTypeStyle, Key: "STYLES", DefaultValue: "population", Values: []string{"pophatch", "population"}},
+	}, restTileLayerToTileLayer(parsed).ParameterFilters)
+
+	marshalled, err := xml.Marshal(newRestTileLayer(restTileLayerToTileLayer(parsed)))
+	assert.NoError(t, err)
+	assert.Contains(t, string(marshalled), `<allowedStyles><string>pophatch</string><string>population</string></allowedStyles>`)
+	assert.NotContains(t, string(marshalled), `availableStyles`)
+}
@@ -0,0 +1,359 @@
+package geoserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TileProtocol is the protocol used to fetch tiles from GeoWebCache
+type TileProtocol string
+
+const (
+	// TileProtocolWMTS fetches tiles using WMTS GetTile requests, which count rows from the top of the gridset
+	TileProtocolWMTS TileProtocol = "wmts"
+
+	// TileProtocolTMS fetches tiles using TMS, which counts rows from the bottom of the gridset
+	TileProtocolTMS TileProtocol = "tms"
+)
+
+// errMissingGridSet is returned when tiles are requested without a gridset
+var errMissingGridSet = errors.New("a gridset is required to locate tiles")
+
+const (
+	// gridEpsilon is the tolerance used when dividing an extent into tiles, to avoid floating point errors adding tiles
+	gridEpsilon = 1e-9
+
+	// defaultMetersPerUnit is used for gridsets which do not state the number of metres in a unit of their SRS
+	defaultMetersPerUnit = 1.0
+)
+
+// TileCoordinate identifies a tile within a gridset, counting rows from the top of the gridset as WMTS does
+type TileCoordinate struct {
+	// Zoom is the zoom level of the tile.
+	Zoom int
+
+	// Row is the row of the tile, counted from the top of the gridset.
+	Row int
+
+	// Column is the column of the tile, counted from the left of the gridset.
+	Column int
+}
+
+// TileRange is a rectangular range of tiles at a zoom level
+type TileRange struct {
+	// Zoom is the zoom level of the tiles.
+	Zoom int
+
+	// MinRow is the first row of the range, counted from the top of the gridset.
+	MinRow int
+
+	// MaxRow is the last row of the range.
+	MaxRow int
+
+	// MinColumn is the first column of the range.
+	MinColumn int
+
+	// MaxColumn is the last column of the range.
+	MaxColumn int
+}
+
+// TileRequest describes the tiles to fetch from GeoWebCache
+type TileRequest struct {
+	// Layer is the name of the layer, including its workspace e.g "topp:states".
+	Layer string
+
+	// GridSet is the gridset of the tiles, as returned by GetGridSet.
+	GridSet *GridSet
+
+	// Format is the image format of the tiles e.g "image/png".
+	Format string
+
+	// Style is the style of the tiles, the default style of the layer is used when empty. It is only used by WMTS.
+	Style string
+
+	// Protocol is the protocol used to fetch the tiles, WMTS is used when empty.
+	Protocol TileProtocol
+}
+
+// Tiles returns the coordinates of every tile in the range, row by row
+func (tileRange *TileRange) Tiles() []*TileCoordinate {
+	tiles := make([]*TileCoordinate, 0)
+	for row := tileRange.MinRow; row <= tileRange.MaxRow; row++ {
+		for column := tileRange.MinColumn; column <= tileRange.MaxColumn; column++ {
+			tiles = append(tiles, &TileCoordinate{Zoom: tileRange.Zoom, Row: row, Column: column})
+		}
+	}
+	return tiles
+}
+
+// TileRange returns the range of tiles at a zoom level which intersect a bounding box, in the SRS of the gridset.
+// An error is returned if the zoom level does not exist or the bounding box does not intersect the gridset.
+func (gridSet *GridSet) TileRange(boundingBox *BoundingBox, zoom int) (tileRange *TileRange, err error) {
+	spanX, spanY, err := gridSet.tileSpan(zoom)
+	if err != nil {
+		return
+	}
+
+	if boundingBox == nil {
+		err = errors.New("a bounding box is required to find a range of tiles")
+		return
+	}
+
+	extent := gridSet.Extent
+	matrixWidth, matrixHeight := gridSet.matrixSize(spanX, spanY)
+	top := gridSet.top(spanY, matrixHeight)
+
+	tileRange = &TileRange{
+		Zoom:      zoom,
+		MinColumn: clamp(int(math.Floor((boundingBox.MinX-extent.MinX)/spanX+gridEpsilon)), 0, matrixWidth-1),
+		MaxColumn: clamp(int(math.Ceil((boundingBox.MaxX-extent.MinX)/spanX-gridEpsilon))-1, 0, matrixWidth-1),
+		MinRow:    clamp(int(math.Floor((top-boundingBox.MaxY)/spanY+gridEpsilon)), 0, matrixHeight-1),
+		MaxRow:    clamp(int(math.Ceil((top-boundingBox.MinY)/spanY-gridEpsilon))-1, 0, matrixHeight-1),
+	}
+
+	if boundingBox.MaxX <= extent.MinX || boundingBox.MinX >= extent.MaxX ||
+		boundingBox.MaxY <= top-float64(matrixHeight)*spanY || boundingBox.MinY >= top {
+		err = fmt.Errorf("the bounding box does not intersect gridset '%s'", gridSet.Name)
+		tileRange = nil
+	}
+	return
+}
+
+// TileURLTemplate returns a URL template for the tiles of the request, as used by web mapping libraries,
+// returning an error if the request has no gridset.
+// WMTS templates use the placeholders {TileMatrix}, {TileRow} and {TileCol}, while TMS templates use {z}, {x} and {y}.
+func (client *RestGeoserverClient) TileURLTemplate(request *TileRequest) (template string, err error) {
+	if request.GridSet == nil {
+		err = errMissingGridSet
+		return
+	}
+
+	if request.Protocol == TileProtocolTMS {
+		extension := formatExtension(request.Format)
+		template = client.geoserverBaseURL + "/gwc/service/tms/1.0.0/" + request.Layer + "@" + request.GridSet.Name + "@" + extension +
+			"/{z}/{x}/{y}." + extension
+		return
+	}
+
+	template = client.geoserverBaseURL + "/gwc/service/wmts?SERVICE=WMTS&REQUEST=GetTile&VERSION=1.0.0" +
+		"&LAYER=" + queryEscape(request.Layer) +
+		"&STYLE=" + queryEscape(request.Style) +
+		"&TILEMATRIXSET=" + queryEscape(request.GridSet.Name) +
+		"&TILEMATRIX={TileMatrix}&TILEROW={TileRow}&TILECOL={TileCol}" +
+		"&FORMAT=" + queryEscape(request.Format)
+	return
+}
+
+// TileURL returns the URL of a tile, returning an error if the tile's zoom level does not exist in the gridset.
+func (client *RestGeoserverClient) TileURL(request *TileRequest, tile *TileCoordinate) (url string, err error) {
+	spanX, spanY, err := request.GridSet.tileSpan(tile.Zoom)
+	if err != nil {
+		return
+	}
+
+	url, err = client.TileURLTemplate(request)
+	if err != nil {
+		return
+	}
+
+	if request.Protocol == TileProtocolTMS {
+		_, matrixHeight := request.GridSet.matrixSize(spanX, spanY)
+		url = strings.NewReplacer(
+			"{z}", strconv.Itoa(tile.Zoom),
+			"{x}", strconv.Itoa(tile.Column),
+			"{y}", strconv.Itoa(matrixHeight-1-tile.Row),
+		).Replace(url)
+		return
+	}
+
+	url = strings.NewReplacer(
+		"{TileMatrix}", queryEscape(request.GridSet.tileMatrixName(tile.Zoom)),
+		"{TileRow}", strconv.Itoa(tile.Row),
+		"{TileCol}", strconv.Itoa(tile.Column),
+	).Replace(url)
+	return
+}
+
+// GetTile fetches a tile from GeoWebCache, returning an error if it is not possible.
+// It interacts with with GeoWebCache using its WMTS or TMS API.
+func (client *RestGeoserverClient) GetTile(request *TileRequest, tile *TileCoordinate) (image []byte, err error) {
+	return client.getTile(context.Background(), request, tile)
+}
+
+// DownloadTiles fetches tiles from GeoWebCache, fetching at most parallelism tiles at once, and passes each one to
+// the handler as it arrives. The handler may be called concurrently. Downloading stops at the first error,
+// from either GeoWebCache or the handler, or when the context is done, and that error is returned.
+func (client *RestGeoserverClient) DownloadTiles(ctx context.Context, request *TileRequest, tiles []*TileCoordinate, parallelism int, handler func(tile *TileCoordinate, image []byte) error) (err error) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var errOnce sync.Once
+	fail := func(tileErr error) {
+		errOnce.Do(func() {
+			err = tileErr
+			cancel()
+		})
+	}
+
+	pending := make(chan *TileCoordinate)
+	var workers sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for tile := range pending {
+				image, tileErr := client.getTile(ctx, request, tile)
+				if tileErr == nil {
+					tileErr = handler(tile, image)
+				}
+				if tileErr != nil {
+					fail(tileErr)
+				}
+			}
+		}()
+	}
+
+sendTiles:
+	for _, tile := range tiles {
+		select {
+		case pending <- tile:
+		case <-ctx.Done():
+			fail(ctx.Err())
+			break sendTiles
+		}
+	}
+	close(pending)
+	workers.Wait()
+	return
+}
+
+// getTile fetches a tile from GeoWebCache, abandoning the request when the context is done
+func (client *RestGeoserverClient) getTile(ctx context.Context, request *TileRequest, tile *TileCoordinate) (image []byte, err error) {
+	url, err := client.TileURL(request, tile)
+	if err != nil {
+		return
+	}
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Fetching a tile from GeoWebCache",
+		urlKey, url,
+	)
+
+	req, err := client.createAuthRequest(http.MethodGet, url, request.Format, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Del(contentTypeHeader)
+
+	statusCode, responseBody, err := client.doRequest(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Unable to fetch tile", url, statusCode, responseBody)
+		err = fmt.Errorf("unable to fetch tile %d/%d/%d of layer '%s'", tile.Zoom, tile.Row, tile.Column, request.Layer)
+		return
+	}
+
+	image = responseBody
+	return
+}
+
+// tileSpan returns the width and height of a tile at a zoom level, in the units of the gridset's SRS
+func (gridSet *GridSet) tileSpan(zoom int) (spanX float64, spanY float64, err error) {
+	if gridSet == nil {
+		err = errMissingGridSet
+		return
+	}
+
+	var resolution float64
+	switch {
+	case zoom >= 0 && zoom < len(gridSet.Resolutions):
+		resolution = gridSet.Resolutions[zoom]
+	case zoom >= 0 && zoom < len(gridSet.ScaleDenominators):
+		pixelSize := gridSet.PixelSize
+		if pixelSize == 0 {
+			pixelSize = defaultPixelSize
+		}
+		metersPerUnit := gridSet.MetersPerUnit
+		if metersPerUnit == 0 {
+			metersPerUnit = defaultMetersPerUnit
+		}
+		resolution = gridSet.ScaleDenominators[zoom] * pixelSize / metersPerUnit
+	default:
+		err = fmt.Errorf("zoom level %d does not exist in gridset '%s'", zoom, gridSet.Name)
+		return
+	}
+
+	if gridSet.Extent == nil || gridSet.TileWidth <= 0 || gridSet.TileHeight <= 0 || resolution <= 0 {
+		err = fmt.Errorf("gridset '%s' does not have an extent, tile size and resolution", gridSet.Name)
+		return
+	}
+
+	spanX = float64(gridSet.TileWidth) * resolution
+	spanY = float64(gridSet.TileHeight) * resolution
+	return
+}
+
+// matrixSize returns the number of tiles across and down the gridset for tiles of the provided span
+func (gridSet *GridSet) matrixSize(spanX float64, spanY float64) (width int, height int) {
+	width = int(math.Ceil((gridSet.Extent.MaxX-gridSet.Extent.MinX)/spanX - gridEpsilon))
+	height = int(math.Ceil((gridSet.Extent.MaxY-gridSet.Extent.MinY)/spanY - gridEpsilon))
+	return
+}
+
+// top returns the Y coordinate of the top of the first row of tiles. Grids grow upwards from the bottom left of
+// the extent, unless the gridset is aligned to the top left.
+func (gridSet *GridSet) top(spanY float64, matrixHeight int) float64 {
+	if gridSet.AlignTopLeft {
+		return gridSet.Extent.MaxY
+	}
+	return gridSet.Extent.MinY + float64(matrixHeight)*spanY
+}
+
+// tileMatrixName returns the name WMTS uses for a zoom level of the gridset
+func (gridSet *GridSet) tileMatrixName(zoom int) string {
+	if zoom < len(gridSet.ScaleNames) {
+		return gridSet.ScaleNames[zoom]
+	}
+	return gridSet.Name + ":" + strconv.Itoa(zoom)
+}
+
+// formatExtension returns the extension GeoWebCache uses for an image format in TMS URLs e.g "png" for "image/png"
+func formatExtension(format string) string {
+	switch format {
+	case "application/vnd.mapbox-vector-tile":
+		return "pbf"
+	case "application/json;type=geojson":
+		return "geojson"
+	}
+
+	extension := format[strings.Index(format, "/")+1:]
+	if separator := strings.IndexAny(extension, ";+"); separator >= 0 {
+		extension = extension[:separator]
+	}
+	return extension
+}
+
+// clamp restricts a value to a range
+func clamp(value int, min int, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
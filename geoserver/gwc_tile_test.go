@@ -0,0 +1,160 @@
+package geoserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestGlobalCRS84GridSet creates a gridset like GeoWebCache's EPSG:4326 gridset, with two tiles at zoom level 0
+func newTestGlobalCRS84GridSet() *GridSet {
+	return &GridSet{
+		Name:        "EPSG:4326",
+		SRS:         "EPSG:4326",
+		Extent:      &BoundingBox{MinX: -180, MinY: -90, MaxX: 180, MaxY: 90},
+		Resolutions: []float64{0.703125, 0.3515625, 0.17578125},
+		TileWidth:   256,
+		TileHeight:  256,
+	}
+}
+
+func TestTileRangeCoversTheBoundingBox(t *testing.T) {
+	gridSet := newTestGlobalCRS84GridSet()
+
+	tileRange, err := gridSet.TileRange(&BoundingBox{MinX: -180, MinY: -90, MaxX: 180, MaxY: 90}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, &TileRange{Zoom: 0, MinRow: 0, MaxRow: 0, MinColumn: 0, MaxColumn: 1}, tileRange)
+
+	tileRange, err = gridSet.TileRange(&BoundingBox{MinX: 10, MinY: 10, MaxX: 100, MaxY: 80}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &TileRange{Zoom: 2, MinRow: 0, MaxRow: 1, MinColumn: 4, MaxColumn: 6}, tileRange)
+	assert.Len(t, tileRange.Tiles(), 6)
+
+	_, err = gridSet.TileRange(&BoundingBox{MinX: 200, MinY: 0, MaxX: 210, MaxY: 10}, 2)
+	assert.Error(t, err)
+
+	_, err = gridSet.TileRange(&BoundingBox{MinX: 0, MinY: 0, MaxX: 10, MaxY: 10}, 3)
+	assert.Error(t, err)
+}
+
+func TestTileRangeUsesScaleDenominatorsAndBottomLeftAlignment(t *testing.T) {
+	gridSet := &GridSet{
+		Name:              "square",
+		Extent:            &BoundingBox{MinX: 0, MinY: 0, MaxX: 1000, MaxY: 1500},
+		ScaleDenominators: []float64{1000 / (256 * defaultPixelSize)},
+		TileWidth:         256,
+		TileHeight:        256,
+	}
+
+	// Tiles are 1000 units wide, so there are two rows growing upwards from y=0 and the top row reaches y=2000
+	tileRange, err := gridSet.TileRange(&BoundingBox{MinX: 0, MinY: 1200, MaxX: 10, MaxY: 1300}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, &TileRange{Zoom: 0, MinRow: 0, MaxRow: 0, MinColumn: 0, MaxColumn: 0}, tileRange)
+
+	tileRange, err = gridSet.TileRange(&BoundingBox{MinX: 0, MinY: 900, MaxX: 10, MaxY: 1300}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, tileRange.MaxRow)
+}
+
+func TestTileURLsAreCreatedForWMTSAndTMS(t *testing.T) {
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, "http://localhost:8080/geoserver", "", "")
+	request := &TileRequest{Layer: "topp:states", GridSet: newTestGlobalCRS84GridSet(), Format: "image/png"}
+
+	url, err := client.TileURL(request, &TileCoordinate{Zoom: 1, Row: 0, Column: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/geoserver/gwc/service/wmts?SERVICE=WMTS&REQUEST=GetTile&VERSION=1.0.0"+
+		"&LAYER=topp%3Astates&STYLE=&TILEMATRIXSET=EPSG%3A4326&TILEMATRIX=EPSG%3A4326%3A1&TILEROW=0&TILECOL=3&FORMAT=image%2Fpng", url)
+
+	request.Protocol = TileProtocolTMS
+	url, err = client.TileURL(request, &TileCoordinate{Zoom: 1, Row: 0, Column: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/geoserver/gwc/service/tms/1.0.0/topp:states@EPSG:4326@png/1/3/1.png", url)
+}
+
+func TestTilesWithoutAGridSetOrBoundingBoxReturnErrors(t *testing.T) {
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, "http://localhost:8080/geoserver", "", "")
+	request := &TileRequest{Layer: "topp:states", Format: "image/png"}
+
+	_, err := client.TileURLTemplate(request)
+	assert.Error(t, err)
+
+	_, err = client.TileURL(request, &TileCoordinate{Zoom: 1, Row: 0, Column: 3})
+	assert.Error(t, err)
+
+	var gridSet *GridSet
+	_, err = gridSet.TileRange(&BoundingBox{MinX: 0, MinY: 0, MaxX: 10, MaxY: 10}, 0)
+	assert.Error(t, err)
+
+	_, err = newTestGlobalCRS84GridSet().TileRange(nil, 0)
+	assert.Error(t, err)
+}
+
+func TestGetTileAcceptsTheFormatWithoutAContentType(t *testing.T) {
+	var accept, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept, contentType = r.Header.Get(acceptHeader), r.Header.Get(contentTypeHeader)
+		w.Write([]byte("png"))
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "", "")
+	request := &TileRequest{Layer: "topp:states", GridSet: newTestGlobalCRS84GridSet(), Format: "image/png"}
+
+	image, err := client.GetTile(request, &TileCoordinate{Zoom: 0, Row: 0, Column: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "png", string(image))
+	assert.Equal(t, "image/png", accept)
+	assert.Equal(t, "", contentType)
+}
+
+func TestDownloadTilesBoundsParallelismAndStopsAtTheFirstError(t *testing.T) {
+	var running, maxRunning int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			previous := atomic.LoadInt32(&maxRunning)
+			if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		fmt.Fprint(w, r.URL.Query().Get("TILECOL"))
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "", "")
+	gridSet := newTestGlobalCRS84GridSet()
+	request := &TileRequest{Layer: "topp:states", GridSet: gridSet, Format: "image/png"}
+	tileRange, err := gridSet.TileRange(gridSet.Extent, 2)
+	assert.NoError(t, err)
+
+	var lock sync.Mutex
+	downloaded := make(map[int]string)
+	err = client.DownloadTiles(context.Background(), request, tileRange.Tiles(), 3, func(tile *TileCoordinate, image []byte) error {
+		lock.Lock()
+		defer lock.Unlock()
+		downloaded[tile.Row*100+tile.Column] = string(image)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, downloaded, 32)
+	assert.Equal(t, "7", downloaded[307])
+	assert.True(t, atomic.LoadInt32(&maxRunning) <= 3)
+
+	handlerErr := errors.New("disk full")
+	var handled int32
+	err = client.DownloadTiles(context.Background(), request, tileRange.Tiles(), 2, func(tile *TileCoordinate, image []byte) error {
+		atomic.AddInt32(&handled, 1)
+		return handlerErr
+	})
+	assert.Equal(t, handlerErr, err)
+	assert.True(t, atomic.LoadInt32(&handled) < 32)
+}
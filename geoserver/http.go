@@ -10,9 +10,30 @@ const (
 	// applicationJSON is the value for the HTTP header Content-Type which indicates the payload is/should be JSON.
 	applicationJSON = "application/json"
 
+	// applicationXML is the value for the HTTP header Content-Type which indicates the payload is/should be XML.
+	applicationXML = "application/xml"
+
+	// applicationFormURLEncoded is the value for the HTTP header Content-Type which indicates the payload is an HTML form.
+	applicationFormURLEncoded = "application/x-www-form-urlencoded"
+
+	// textPlain is the value for the HTTP header Content-Type which indicates the payload is plain text.
+	textPlain = "text/plain"
+
+	// applicationOctetStream is the value for the HTTP header Content-Type which indicates the payload is binary.
+	applicationOctetStream = "application/octet-stream"
+
 	// codeCreated is the HTTP code used when an entity has been successfully created
 	codeCreated = 201
 
 	// httpCodeOK is the HTTP code used when the all is well
 	httpCodeOK = 200
+
+	// httpCodeNoContent is the HTTP code used when the all is well and there is nothing to return
+	httpCodeNoContent = 204
+
+	// httpCodeUnauthorized is the HTTP code used when the credentials provided are wrong
+	httpCodeUnauthorized = 401
+
+	// httpCodeNotFound is the HTTP code used when an entity does not exist
+	httpCodeNotFound = 404
 )
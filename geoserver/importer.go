@@ -0,0 +1,675 @@
+package geoserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ImportState is the state of an import
+type ImportState string
+
+const (
+	// ImportInit is the state of an import whose data is being inspected
+	ImportInit ImportState = "INIT"
+
+	// ImportInitError is the state of an import whose data could not be inspected
+	ImportInitError ImportState = "INIT_ERROR"
+
+	// ImportPending is the state of an import whose tasks need attention before it can run
+	ImportPending ImportState = "PENDING"
+
+	// ImportReady is the state of an import which is ready to run
+	ImportReady ImportState = "READY"
+
+	// ImportRunning is the state of a running import
+	ImportRunning ImportState = "RUNNING"
+
+	// ImportIncomplete is the state of an import where some tasks did not complete
+	ImportIncomplete ImportState = "INCOMPLETE"
+
+	// ImportComplete is the state of an import where every task completed
+	ImportComplete ImportState = "COMPLETE"
+)
+
+// ImportTaskState is the state of an import task
+type ImportTaskState string
+
+const (
+	// ImportTaskPending is the state of a task which needs attention before it can run
+	ImportTaskPending ImportTaskState = "PENDING"
+
+	// ImportTaskReady is the state of a task which is ready to run
+	ImportTaskReady ImportTaskState = "READY"
+
+	// ImportTaskRunning is the state of a running task
+	ImportTaskRunning ImportTaskState = "RUNNING"
+
+	// ImportTaskNoCRS is the state of a task whose data has no coordinate reference system, set one with UpdateImportTask
+	ImportTaskNoCRS ImportTaskState = "NO_CRS"
+
+	// ImportTaskNoBounds is the state of a task whose bounds could not be calculated
+	ImportTaskNoBounds ImportTaskState = "NO_BOUNDS"
+
+	// ImportTaskNoFormat is the state of a task whose data format could not be determined
+	ImportTaskNoFormat ImportTaskState = "NO_FORMAT"
+
+	// ImportTaskBadFormat is the state of a task whose data format is not supported
+	ImportTaskBadFormat ImportTaskState = "BAD_FORMAT"
+
+	// ImportTaskError is the state of a task which failed
+	ImportTaskError ImportTaskState = "ERROR"
+
+	// ImportTaskCanceled is the state of a task which was canceled
+	ImportTaskCanceled ImportTaskState = "CANCELED"
+
+	// ImportTaskComplete is the state of a task which completed
+	ImportTaskComplete ImportTaskState = "COMPLETE"
+)
+
+// UpdateMode determines how an import task treats an existing layer of the same name
+type UpdateMode string
+
+const (
+	// UpdateModeCreate creates a new layer, renaming it when a layer of the same name exists
+	UpdateModeCreate UpdateMode = "CREATE"
+
+	// UpdateModeReplace replaces the data of the existing layer
+	UpdateModeReplace UpdateMode = "REPLACE"
+
+	// UpdateModeAppend appends the data to the existing layer
+	UpdateModeAppend UpdateMode = "APPEND"
+)
+
+// ImportDataType is the kind of data being imported
+type ImportDataType string
+
+const (
+	// ImportDataFile is a single file on the Geoserver host
+	ImportDataFile ImportDataType = "file"
+
+	// ImportDataDirectory is a directory of files on the Geoserver host
+	ImportDataDirectory ImportDataType = "directory"
+
+	// ImportDataDatabase is the tables of a database
+	ImportDataDatabase ImportDataType = "database"
+)
+
+// ImportData is the data imported by an import, when it is not uploaded
+type ImportData struct {
+	// Type is the kind of data.
+	Type ImportDataType
+
+	// Location is the path of the file or directory on the Geoserver host, used by file and directory data.
+	Location string
+
+	// ConnectionDetails are the details used to connect to the database, used by database data.
+	ConnectionDetails ConnectionDetails
+}
+
+// CreateImportRequest is a request to create an import
+type CreateImportRequest struct {
+	// Workspace is the workspace layers are imported into, the default workspace is used when empty.
+	Workspace string
+
+	// Store is the existing store data is imported into, a new store is created for each task when empty.
+	Store string
+
+	// Data is the data to import, it is optional as files can be uploaded to the import instead.
+	Data *ImportData
+}
+
+// Import is an import of data into Geoserver using the Importer extension
+type Import struct {
+	// ID is the identifier of the import.
+	ID int
+
+	// State is the state of the import.
+	State ImportState
+
+	// Tasks are the tasks of the import, one for each layer being imported.
+	Tasks []*ImportTask
+}
+
+// ImportTask is the import of a single layer
+type ImportTask struct {
+	// ID is the identifier of the task within its import.
+	ID int
+
+	// State is the state of the task.
+	State ImportTaskState
+
+	// UpdateMode determines how the task treats an existing layer of the same name.
+	UpdateMode UpdateMode
+
+	// LayerName is the name of the layer the task creates or updates.
+	LayerName string
+
+	// SRS is the coordinate reference system of the layer.
+	SRS string
+
+	// ErrorMessage describes why the task failed, when it has.
+	ErrorMessage string
+}
+
+// UpdateImportTaskRequest is a request to change how a task imports its layer
+type UpdateImportTaskRequest struct {
+	// ImportID is the identifier of the import.
+	ImportID int
+
+	// TaskID is the identifier of the task.
+	TaskID int
+
+	// UpdateMode replaces the update mode of the task when provided.
+	UpdateMode UpdateMode
+
+	// LayerName replaces the name of the layer when provided.
+	LayerName string
+
+	// SRS replaces the coordinate reference system of the layer when provided, for example for tasks with no CRS.
+	SRS string
+}
+
+// ImportTransform transforms the data of a task as it is imported
+type ImportTransform struct {
+	// Type is the Importer's name for the transform e.g "AttributeRenameTransform".
+	Type string
+
+	// Field is the attribute transformed, used by date format transforms.
+	Field string
+
+	// Format is the date format used to parse the attribute, used by date format transforms.
+	Format string
+
+	// From is the attribute renamed, used by attribute rename transforms.
+	From string
+
+	// To is the new name of the attribute, used by attribute rename transforms.
+	To string
+
+	// Target is the SRS data is reprojected to, used by reproject transforms.
+	Target string
+}
+
+// NewFileImportData creates import data for a single file on the Geoserver host
+func NewFileImportData(path string) *ImportData {
+	return &ImportData{Type: ImportDataFile, Location: path}
+}
+
+// NewDirectoryImportData creates import data for a directory of files on the Geoserver host
+func NewDirectoryImportData(path string) *ImportData {
+	return &ImportData{Type: ImportDataDirectory, Location: path}
+}
+
+// NewDatabaseImportData creates import data for the tables of a database
+func NewDatabaseImportData(connectionDetails ConnectionDetails) *ImportData {
+	return &ImportData{Type: ImportDataDatabase, ConnectionDetails: connectionDetails}
+}
+
+// NewAttributeRenameTransform creates a transform which renames an attribute
+func NewAttributeRenameTransform(from string, to string) *ImportTransform {
+	return &ImportTransform{Type: "AttributeRenameTransform", From: from, To: to}
+}
+
+// NewDateFormatTransform creates a transform which parses a text attribute into a date using a format
+// e.g "yyyy-MM-dd", the Importer guesses the format when it is empty
+func NewDateFormatTransform(field string, format string) *ImportTransform {
+	return &ImportTransform{Type: "DateFormatTransform", Field: field, Format: format}
+}
+
+// NewReprojectTransform creates a transform which reprojects the data to an SRS
+func NewReprojectTransform(target string) *ImportTransform {
+	return &ImportTransform{Type: "ReprojectTransform", Target: target}
+}
+
+// IsComplete returns true when the import has finished running, whether or not all of its tasks succeeded
+func (imp *Import) IsComplete() bool {
+	return imp.State == ImportComplete || imp.State == ImportIncomplete
+}
+
+// CreateImport creates an import, returning an error if it is not possible.
+// It interacts with with Geoserver using its Importer REST API.
+func (client *RestGeoserverClient) CreateImport(request *CreateImportRequest) (imp *Import, err error) {
+	url := client.geoserverBaseURL + "/rest/imports"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Creating a Geoserver import",
+		urlKey, url,
+		"workspace", request.Workspace,
+		"store", request.Store,
+	)
+
+	statusCode, responseBody, err := client.doJSONRequest(http.MethodPost, url, newRestImportRequest(request))
+	if err != nil {
+		return
+	}
+
+	if codeCreated != statusCode && httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Unable to create import", url, statusCode, responseBody)
+		err = fmt.Errorf("unable to create import")
+		return
+	}
+
+	return client.parseImport(url, statusCode, responseBody)
+}
+
+// GetImport gets an import and the state of its tasks, returning an error if it is not possible.
+// It interacts with with Geoserver using its Importer REST API.
+func (client *RestGeoserverClient) GetImport(importID int) (imp *Import, err error) {
+	url := client.importURL(importID) + "?expand=all"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for an import",
+		urlKey, url,
+	)
+
+	statusCode, responseBody, err := client.doJSONRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Unable to get import", url, statusCode, responseBody)
+		err = fmt.Errorf("unable to get import %d", importID)
+		return
+	}
+
+	return client.parseImport(url, statusCode, responseBody)
+}
+
+// DeleteImport deletes an import, returning an error if it is not possible. Layers it has imported are kept.
+// It interacts with with Geoserver using its Importer REST API.
+func (client *RestGeoserverClient) DeleteImport(importID int) (err error) {
+	url := client.importURL(importID)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Deleting a Geoserver import",
+		urlKey, url,
+	)
+
+	statusCode, responseBody, err := client.doJSONRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode || httpCodeNoContent == statusCode {
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to delete import", url, statusCode, responseBody)
+	err = fmt.Errorf("unable to delete import %d", importID)
+	return
+}
+
+// UploadImportFile uploads a file to an import, such as a zipped shapefile, creating a task for each layer it contains.
+// The content is streamed to Geoserver rather than read into memory.
+// It interacts with with Geoserver using its Importer REST API.
+func (client *RestGeoserverClient) UploadImportFile(importID int, filename string, content io.Reader) (tasks []*ImportTask, err error) {
+	url := client.importURL(importID) + "/tasks/" + pathEscape(filename)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Uploading a file to a Geoserver import",
+		urlKey, url,
+		"filename", filename,
+	)
+
+	req, err := client.createAuthRequest(http.MethodPut, url, applicationOctetStream, content)
+	if err != nil {
+		return
+	}
+	req.Header.Set(acceptHeader, applicationJSON)
+
+	statusCode, responseBody, err := client.doRequest(req)
+	if err != nil {
+		return
+	}
+
+	if codeCreated != statusCode && httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Unable to upload file to import", url, statusCode, responseBody)
+		err = fmt.Errorf("unable to upload '%s' to import %d", filename, importID)
+		return
+	}
+
+	restResponse := &restImportTasks{}
+	err = json.Unmarshal(responseBody, restResponse)
+	if err != nil {
+		client.logUnexpectedResponse("Geoserver returned an invalid response", url, statusCode, responseBody)
+		return
+	}
+
+	tasks = restResponse.toImportTasks()
+	return
+}
+
+// GetImportTask gets a task of an import, returning an error if it is not possible.
+// It interacts with with Geoserver using its Importer REST API.
+func (client *RestGeoserverClient) GetImportTask(importID int, taskID int) (task *ImportTask, err error) {
+	url := client.importTaskURL(importID, taskID)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for an import task",
+		urlKey, url,
+	)
+
+	statusCode, responseBody, err := client.doJSONRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Unable to get import task", url, statusCode, responseBody)
+		err = fmt.Errorf("unable to get task %d of import %d", taskID, importID)
+		return
+	}
+
+	restResponse := &restImportTasks{}
+	err = json.Unmarshal(responseBody, restResponse)
+	if err != nil || restResponse.Task == nil {
+		client.logUnexpectedResponse("Geoserver returned an invalid response", url, statusCode, responseBody)
+		if err == nil {
+			err = fmt.Errorf("unable to get task %d of import %d, Geoserver returned an invalid response", taskID, importID)
+		}
+		return
+	}
+
+	task = restResponse.Task.toImportTask()
+	return
+}
+
+// UpdateImportTask changes how a task imports its layer, returning an error if it is not possible.
+// It interacts with with Geoserver using its Importer REST API.
+func (client *RestGeoserverClient) UpdateImportTask(request *UpdateImportTaskRequest) (err error) {
+	url := client.importTaskURL(request.ImportID, request.TaskID)
+	failure := fmt.Sprintf("unable to update task %d of import %d", request.TaskID, request.ImportID)
+
+	if request.UpdateMode != "" {
+		err = client.sendImportRequest(http.MethodPut, url, &restImportTaskRequest{
+			Task: &restImportTaskUpdate{UpdateMode: request.UpdateMode},
+		}, failure)
+		if err != nil {
+			return
+		}
+	}
+
+	if request.LayerName != "" || request.SRS != "" {
+		err = client.sendImportRequest(http.MethodPut, url+"/layer", &restImportLayerRequest{
+			Layer: &restImportLayer{Name: request.LayerName, SRS: request.SRS},
+		}, failure)
+	}
+	return
+}
+
+// AddImportTransform adds a transform to a task, which is applied to its data as it is imported,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its Importer REST API.
+func (client *RestGeoserverClient) AddImportTransform(importID int, taskID int, transform *ImportTransform) (err error) {
+	url := client.importTaskURL(importID, taskID) + "/transforms"
+	failure := fmt.Sprintf("unable to add a %s to task %d of import %d", transform.Type, taskID, importID)
+	return client.sendImportRequest(http.MethodPost, url, newRestImportTransform(transform), failure)
+}
+
+// RunImport runs an import. When async is false it returns once the import has finished, otherwise it returns
+// immediately and WaitForImport can be used to wait for it to finish.
+// It interacts with with Geoserver using its Importer REST API.
+func (client *RestGeoserverClient) RunImport(importID int, async bool) (err error) {
+	url := client.importURL(importID)
+	if async {
+		url += "?async=true"
+	}
+	return client.sendImportRequest(http.MethodPost, url, nil, fmt.Sprintf("unable to run import %d", importID))
+}
+
+// WaitForImport polls an import which is running until it is complete or the context is done, returning an error
+// if the Importer could not inspect the data of the import.
+func (client *RestGeoserverClient) WaitForImport(ctx context.Context, importID int, pollInterval time.Duration) (imp *Import, err error) {
+	for {
+		imp, err = client.GetImport(importID)
+		if err != nil {
+			return
+		}
+
+		if imp.IsComplete() {
+			return
+		}
+
+		if imp.State == ImportInitError {
+			err = fmt.Errorf("import %d could not inspect its data", importID)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// importURL creates the URL of an import
+func (client *RestGeoserverClient) importURL(importID int) string {
+	return client.geoserverBaseURL + "/rest/imports/" + strconv.Itoa(importID)
+}
+
+// importTaskURL creates the URL of a task of an import
+func (client *RestGeoserverClient) importTaskURL(importID int, taskID int) string {
+	return client.importURL(importID) + "/tasks/" + strconv.Itoa(taskID)
+}
+
+// sendImportRequest sends a request to the Importer, which responds with no content on success
+func (client *RestGeoserverClient) sendImportRequest(method string, url string, payload interface{}, failure string) (err error) {
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Sending a request to the Geoserver importer",
+		urlKey, url,
+	)
+
+	statusCode, responseBody, err := client.doJSONRequest(method, url, payload)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode || codeCreated == statusCode || httpCodeNoContent == statusCode {
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to send request to the importer", url, statusCode, responseBody)
+	err = fmt.Errorf("%s", failure)
+	return
+}
+
+// parseImport parses the Importer's representation of an import
+func (client *RestGeoserverClient) parseImport(url string, statusCode int, responseBody []byte) (imp *Import, err error) {
+	restResponse := &restImportResponse{}
+	err = json.Unmarshal(responseBody, restResponse)
+	if err != nil || restResponse.Import == nil {
+		client.logUnexpectedResponse("Geoserver returned an invalid response", url, statusCode, responseBody)
+		if err == nil {
+			err = fmt.Errorf("Geoserver returned an invalid import")
+		}
+		return
+	}
+
+	imp = &Import{
+		ID:    restResponse.Import.ID,
+		State: restResponse.Import.State,
+		Tasks: make([]*ImportTask, 0),
+	}
+	for _, task := range restResponse.Import.Tasks {
+		imp.Tasks = append(imp.Tasks, task.toImportTask())
+	}
+	return
+}
+
+/**
+ * REST API
+ */
+
+// restImportRequest exists in order to represent the JSON required by the Importer to create an import
+type restImportRequest struct {
+	Import *restImportContext `json:"import"`
+}
+
+// restImportContext is the target of an import
+type restImportContext struct {
+	TargetWorkspace *restImportWorkspace `json:"targetWorkspace,omitempty"`
+	TargetStore     *restImportStore     `json:"targetStore,omitempty"`
+	Data            *restImportData      `json:"data,omitempty"`
+}
+
+// restImportWorkspace is the workspace targeted by an import
+type restImportWorkspace struct {
+	Workspace *restNamedEntity `json:"workspace"`
+}
+
+// restImportStore is the store targeted by an import
+type restImportStore struct {
+	DataStore *restNamedEntity `json:"dataStore"`
+}
+
+// restNamedEntity is a reference to a catalog entity by name
+type restNamedEntity struct {
+	Name string `json:"name"`
+}
+
+// restImportData is the data imported
+type restImportData struct {
+	Type     ImportDataType    `json:"type"`
+	File     string            `json:"file,omitempty"`
+	Location string            `json:"location,omitempty"`
+	Params   map[string]string `json:"params,omitempty"`
+}
+
+// restImportResponse exists in order to represent the JSON returned by the Importer for an import
+type restImportResponse struct {
+	Import *struct {
+		ID    int               `json:"id"`
+		State ImportState       `json:"state"`
+		Tasks []*restImportTask `json:"tasks"`
+	} `json:"import"`
+}
+
+// restImportTasks exists in order to represent the JSON returned by the Importer for one or many tasks
+type restImportTasks struct {
+	Task  *restImportTask   `json:"task"`
+	Tasks []*restImportTask `json:"tasks"`
+}
+
+// restImportTask is a task of an import
+type restImportTask struct {
+	ID           int             `json:"id"`
+	State        ImportTaskState `json:"state"`
+	UpdateMode   UpdateMode      `json:"updateMode"`
+	ErrorMessage string          `json:"errorMessage"`
+	Layer        *struct {
+		Name string `json:"name"`
+		SRS  string `json:"srs"`
+	} `json:"layer"`
+}
+
+// restImportTaskRequest exists in order to represent the JSON required by the Importer to update a task
+type restImportTaskRequest struct {
+	Task *restImportTaskUpdate `json:"task"`
+}
+
+// restImportTaskUpdate is an update to a task
+type restImportTaskUpdate struct {
+	UpdateMode UpdateMode `json:"updateMode,omitempty"`
+}
+
+// restImportLayerRequest exists in order to represent the JSON required by the Importer to update the layer of a task
+type restImportLayerRequest struct {
+	Layer *restImportLayer `json:"layer"`
+}
+
+// restImportLayer is an update to the layer of a task
+type restImportLayer struct {
+	Name string `json:"name,omitempty"`
+	SRS  string `json:"srs,omitempty"`
+}
+
+// restImportTransform exists in order to represent the JSON required by the Importer for a transform
+type restImportTransform struct {
+	Type   string `json:"type"`
+	Field  string `json:"field,omitempty"`
+	Format string `json:"format,omitempty"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+	Target string `json:"target,omitempty"`
+}
+
+// newRestImportRequest converts a CreateImportRequest into a restImportRequest
+func newRestImportRequest(request *CreateImportRequest) *restImportRequest {
+	importContext := &restImportContext{}
+
+	if request.Workspace != "" {
+		importContext.TargetWorkspace = &restImportWorkspace{Workspace: &restNamedEntity{Name: request.Workspace}}
+	}
+	if request.Store != "" {
+		importContext.TargetStore = &restImportStore{DataStore: &restNamedEntity{Name: request.Store}}
+	}
+
+	if data := request.Data; data != nil {
+		importContext.Data = &restImportData{Type: data.Type}
+		switch data.Type {
+		case ImportDataFile:
+			importContext.Data.File = data.Location
+		case ImportDataDatabase:
+			if data.ConnectionDetails != nil {
+				importContext.Data.Params = data.ConnectionDetails.Entries()
+			}
+		default:
+			importContext.Data.Location = data.Location
+		}
+	}
+
+	return &restImportRequest{Import: importContext}
+}
+
+// newRestImportTransform converts an ImportTransform into a restImportTransform
+func newRestImportTransform(transform *ImportTransform) *restImportTransform {
+	return &restImportTransform{
+		Type:   transform.Type,
+		Field:  transform.Field,
+		Format: transform.Format,
+		From:   transform.From,
+		To:     transform.To,
+		Target: transform.Target,
+	}
+}
+
+// toImportTask converts a restImportTask into an ImportTask
+func (task *restImportTask) toImportTask() *ImportTask {
+	result := &ImportTask{
+		ID:           task.ID,
+		State:        task.State,
+		UpdateMode:   task.UpdateMode,
+		ErrorMessage: task.ErrorMessage,
+	}
+	if task.Layer != nil {
+		result.LayerName = task.Layer.Name
+		result.SRS = task.Layer.SRS
+	}
+	return result
+}
+
+// toImportTasks converts the task, or tasks, of a restImportTasks into ImportTasks
+func (tasks *restImportTasks) toImportTasks() []*ImportTask {
+	result := make([]*ImportTask, 0)
+	if tasks.Task != nil {
+		result = append(result, tasks.Task.toImportTask())
+	}
+	for _, task := range tasks.Tasks {
+		result = append(result, task.toImportTask())
+	}
+	return result
+}
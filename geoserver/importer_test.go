@@ -0,0 +1,122 @@
+package geoserver
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewRestImportRequestTargetsWorkspaceStoreAndData(t *testing.T) {
+	body, err := json.Marshal(newRestImportRequest(&CreateImportRequest{
+		Workspace: "topp",
+		Store:     "states",
+		Data:      NewDirectoryImportData("/data/shapefiles"),
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"import":{"targetWorkspace":{"workspace":{"name":"topp"}},"targetStore":{"dataStore":{"name":"states"}},`+
+		`"data":{"type":"directory","location":"/data/shapefiles"}}}`, string(body))
+
+	body, err = json.Marshal(newRestImportRequest(&CreateImportRequest{
+		Data: NewDatabaseImportData(newGeoserverPostgisConnectionDetails("db", 5432, "user", "pass", "public", "topp")),
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"data":{"type":"database","params":{`)
+	assert.Contains(t, string(body), `"host":"db"`)
+	assert.NotContains(t, string(body), "targetWorkspace")
+}
+
+func TestNewRestImportTransformOmitsUnusedFields(t *testing.T) {
+	body, err := json.Marshal(newRestImportTransform(NewAttributeRenameTransform("NAME", "name")))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"AttributeRenameTransform","from":"NAME","to":"name"}`, string(body))
+
+	body, err = json.Marshal(newRestImportTransform(NewDateFormatTransform("created", "yyyy-MM-dd")))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"DateFormatTransform","field":"created","format":"yyyy-MM-dd"}`, string(body))
+}
+
+func TestImportIsUploadedUpdatedRunAndPolledUntilComplete(t *testing.T) {
+	var requests []string
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/imports":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"import":{"id":3,"state":"PENDING"}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/imports/3/tasks/roads.zip":
+			assert.Equal(t, applicationOctetStream, r.Header.Get(contentTypeHeader))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"task":{"id":0,"state":"NO_CRS","updateMode":"CREATE","layer":{"name":"roads"}}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/imports/3":
+			polls++
+			state := "RUNNING"
+			if polls > 1 {
+				state = "COMPLETE"
+			}
+			w.Write([]byte(`{"import":{"id":3,"state":"` + state + `","tasks":[{"id":0,"state":"COMPLETE","layer":{"name":"streets","srs":"EPSG:4326"}}]}}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	imp, err := client.CreateImport(&CreateImportRequest{Workspace: "topp"})
+	assert.NoError(t, err)
+	assert.Equal(t, &Import{ID: 3, State: ImportPending, Tasks: []*ImportTask{}}, imp)
+
+	tasks, err := client.UploadImportFile(imp.ID, "roads.zip", strings.NewReader("zipped shapefile"))
+	assert.NoError(t, err)
+	assert.Equal(t, []*ImportTask{{ID: 0, State: ImportTaskNoCRS, UpdateMode: UpdateModeCreate, LayerName: "roads"}}, tasks)
+
+	err = client.UpdateImportTask(&UpdateImportTaskRequest{ImportID: 3, TaskID: 0, UpdateMode: UpdateModeReplace, LayerName: "streets", SRS: "EPSG:4326"})
+	assert.NoError(t, err)
+
+	err = client.AddImportTransform(3, 0, NewAttributeRenameTransform("NAME", "name"))
+	assert.NoError(t, err)
+
+	err = client.RunImport(3, true)
+	assert.NoError(t, err)
+
+	imp, err = client.WaitForImport(context.Background(), 3, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, ImportComplete, imp.State)
+	assert.Equal(t, "streets", imp.Tasks[0].LayerName)
+
+	assert.Equal(t, []string{
+		`POST /rest/imports {"import":{"targetWorkspace":{"workspace":{"name":"topp"}}}}`,
+		`PUT /rest/imports/3/tasks/roads.zip zipped shapefile`,
+		`PUT /rest/imports/3/tasks/0 {"task":{"updateMode":"REPLACE"}}`,
+		`PUT /rest/imports/3/tasks/0/layer {"layer":{"name":"streets","srs":"EPSG:4326"}}`,
+		`POST /rest/imports/3/tasks/0/transforms {"type":"AttributeRenameTransform","from":"NAME","to":"name"}`,
+		`POST /rest/imports/3?async=true `,
+		`GET /rest/imports/3?expand=all `,
+		`GET /rest/imports/3?expand=all `,
+	}, requests)
+}
+
+func TestUploadImportFileEscapesTheFilename(t *testing.T) {
+	var requestURI string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURI = r.URL.RequestURI()
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"task":{"id":0,"state":"READY"}}`))
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	_, err := client.UploadImportFile(3, "main roads #2?.zip", strings.NewReader("zipped shapefile"))
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/imports/3/tasks/main%20roads%20%232%3F.zip", requestURI)
+}
@@ -0,0 +1,117 @@
+package geoserver
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// LoggingProfile is a logging configuration shipped with Geoserver
+type LoggingProfile string
+
+const (
+	// LoggingDefault is the default logging profile
+	LoggingDefault LoggingProfile = "DEFAULT_LOGGING.properties"
+
+	// LoggingProduction only logs problems
+	LoggingProduction LoggingProfile = "PRODUCTION_LOGGING.properties"
+
+	// LoggingQuiet logs almost nothing
+	LoggingQuiet LoggingProfile = "QUIET_LOGGING.properties"
+
+	// LoggingVerbose logs everything Geoserver and GeoTools do
+	LoggingVerbose LoggingProfile = "VERBOSE_LOGGING.properties"
+
+	// LoggingGeoServerDeveloper logs Geoserver's internals in detail
+	LoggingGeoServerDeveloper LoggingProfile = "GEOSERVER_DEVELOPER_LOGGING.properties"
+
+	// LoggingGeoToolsDeveloper logs GeoTools' internals in detail
+	LoggingGeoToolsDeveloper LoggingProfile = "GEOTOOLS_DEVELOPER_LOGGING.properties"
+)
+
+// LoggingSettings are the logging settings of Geoserver
+type LoggingSettings struct {
+	// Level is the logging profile in use.
+	Level LoggingProfile
+
+	// Location is the path of the log file, relative to the data directory.
+	Location string
+
+	// StdOutLogging determines whether Geoserver also logs to standard output.
+	StdOutLogging bool
+}
+
+// GetLoggingSettings gets the logging settings of Geoserver, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetLoggingSettings() (settings *LoggingSettings, err error) {
+	restResponse := &restLoggingWrapper{}
+	err = client.getRestResource(client.geoserverBaseURL+"/rest/logging", "the logging settings", restResponse)
+	if err != nil {
+		return
+	}
+
+	if restResponse.Logging == nil {
+		err = fmt.Errorf("unable to get the logging settings, Geoserver returned an invalid response")
+		return
+	}
+
+	settings = &LoggingSettings{
+		Level:         restResponse.Logging.Level,
+		Location:      restResponse.Logging.Location,
+		StdOutLogging: restResponse.Logging.StdOutLogging,
+	}
+	return
+}
+
+// UpdateLoggingSettings replaces the logging settings of Geoserver, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UpdateLoggingSettings(settings *LoggingSettings) (err error) {
+	return client.sendRestResource(http.MethodPut, client.geoserverBaseURL+"/rest/logging", "the logging settings",
+		&restLoggingWrapper{Logging: &restLogging{
+			Level:         settings.Level,
+			Location:      settings.Location,
+			StdOutLogging: settings.StdOutLogging,
+		}})
+}
+
+// WithLoggingProfile switches Geoserver to a logging profile while the callback runs, then restores the previous
+// logging settings, even when the callback fails. The callback's error is returned in preference to an error
+// restoring the settings.
+func (client *RestGeoserverClient) WithLoggingProfile(profile LoggingProfile, callback func() error) (err error) {
+	previous, err := client.GetLoggingSettings()
+	if err != nil {
+		return
+	}
+
+	raised := *previous
+	raised.Level = profile
+	err = client.UpdateLoggingSettings(&raised)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		restoreErr := client.UpdateLoggingSettings(previous)
+		if err == nil {
+			err = restoreErr
+		}
+	}()
+
+	err = callback()
+	return
+}
+
+/**
+ * REST API
+ */
+
+// restLoggingWrapper exists in order to represent the JSON used by Geoserver for the logging settings
+type restLoggingWrapper struct {
+	Logging *restLogging `json:"logging"`
+}
+
+// restLogging is the logging settings of Geoserver
+type restLogging struct {
+	Level         LoggingProfile `json:"level"`
+	Location      string         `json:"location,omitempty"`
+	StdOutLogging bool           `json:"stdOutLogging"`
+}
@@ -0,0 +1,40 @@
+package geoserver
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithLoggingProfileRestoresThePreviousSettingsWhenTheCallbackFails(t *testing.T) {
+	current := `{"logging":{"level":"DEFAULT_LOGGING.properties","location":"logs/geoserver.log","stdOutLogging":true}}`
+	var updates []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			body, _ := ioutil.ReadAll(r.Body)
+			updates = append(updates, string(body))
+			current = string(body)
+		}
+		w.Write([]byte(current))
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	callbackErr := errors.New("callback failed")
+	err := client.WithLoggingProfile(LoggingGeoServerDeveloper, func() error {
+		settings, err := client.GetLoggingSettings()
+		assert.NoError(t, err)
+		assert.Equal(t, LoggingGeoServerDeveloper, settings.Level)
+		return callbackErr
+	})
+	assert.Equal(t, callbackErr, err)
+
+	assert.Equal(t, []string{
+		`{"logging":{"level":"GEOSERVER_DEVELOPER_LOGGING.properties","location":"logs/geoserver.log","stdOutLogging":true}}`,
+		`{"logging":{"level":"DEFAULT_LOGGING.properties","location":"logs/geoserver.log","stdOutLogging":true}}`,
+	}, updates)
+}
@@ -0,0 +1,79 @@
+package geoserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Reload reloads the catalog and configuration from the data directory, and resets every store, raster reader
+// and authentication cache, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) Reload() (err error) {
+	return client.postCatalogOperation(context.Background(), client.geoserverBaseURL+"/rest/reload", "reload the catalog")
+}
+
+// Reset resets every store, raster reader and authentication cache without reloading the catalog, for example
+// after swapping database credentials, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) Reset() (err error) {
+	return client.postCatalogOperation(context.Background(), client.geoserverBaseURL+"/rest/reset", "reset the store caches")
+}
+
+// ReloadAndWait reloads the catalog and then polls IsHealthOk until Geoserver is healthy again, returning an error
+// if the reload fails or Geoserver is not healthy within the timeout, which also covers the reload request itself.
+func (client *RestGeoserverClient) ReloadAndWait(timeout time.Duration, pollInterval time.Duration) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err = client.postCatalogOperation(ctx, client.geoserverBaseURL+"/rest/reload", "reload the catalog")
+	if err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("timed out after %s waiting for Geoserver to reload the catalog", timeout)
+		}
+		return
+	}
+
+	for {
+		// Errors are expected while the catalog is reloading, so only the result of the health check matters
+		isHealthy, _ := client.IsHealthOk()
+		if isHealthy {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			err = fmt.Errorf("timed out after %s waiting for Geoserver to be healthy following a reload", timeout)
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// postCatalogOperation posts an empty request to a catalog operation, giving up when the context is done
+func (client *RestGeoserverClient) postCatalogOperation(ctx context.Context, url string, description string) (err error) {
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Asking Geoserver to "+description,
+		urlKey, url,
+	)
+
+	req, err := client.createAuthJSONRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return
+	}
+
+	statusCode, responseBody, err := client.doRequest(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode {
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to "+description, url, statusCode, responseBody)
+	err = fmt.Errorf("unable to %s", description)
+	return
+}
@@ -0,0 +1,62 @@
+package geoserver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReloadAndWaitPollsUntilGeoserverIsHealthy(t *testing.T) {
+	statusChecks := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/about/status" {
+			statusChecks++
+			if statusChecks < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	err := client.ReloadAndWait(time.Minute, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, statusChecks)
+}
+
+func TestReloadAndWaitFailsWhenGeoserverIsNotHealthyWithinTheTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/about/status" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	err := client.ReloadAndWait(20*time.Millisecond, time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestReloadAndWaitTimeoutCoversTheReloadRequest(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/reload" {
+			<-release
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	start := time.Now()
+	err := client.ReloadAndWait(20*time.Millisecond, time.Millisecond)
+	assert.Error(t, err)
+	assert.True(t, time.Since(start) < 5*time.Second, "the reload request was not cancelled by the timeout")
+}
@@ -0,0 +1,312 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// Keyword is a keyword describing a published resource, used by catalogues to find it
+type Keyword struct {
+	// Value is the keyword itself
+	Value string
+
+	// Language is the language of the keyword e.g "en", it is optional
+	Language string
+
+	// Vocabulary is the controlled vocabulary the keyword comes from e.g "GEMET", it is optional
+	Vocabulary string
+}
+
+// MetadataLink is a link to a metadata record describing a published resource
+type MetadataLink struct {
+	// Type is the standard the metadata record conforms to e.g "ISO19115:2003", "FGDC" or "TC211"
+	Type string
+
+	// Format is the mime type of the metadata record e.g "text/xml"
+	Format string
+
+	// URL is the location of the metadata record
+	URL string
+}
+
+// DataLink is a link to the data of a published resource, for example a download page
+type DataLink struct {
+	// Format is the mime type of the linked data e.g "text/html"
+	Format string
+
+	// URL is the location of the data
+	URL string
+}
+
+// ResourceDescription is the descriptive information of a published feature type or coverage
+type ResourceDescription struct {
+	// Title is the title of the resource
+	Title string
+
+	// Abstract is the abstract of the resource
+	Abstract string
+
+	// Keywords are the keywords describing the resource
+	Keywords []*Keyword
+
+	// MetadataLinks are the links to metadata records describing the resource
+	MetadataLinks []*MetadataLink
+
+	// DataLinks are the links to the data of the resource
+	DataLinks []*DataLink
+}
+
+// GetFeatureTypeDescription gets the title, abstract, keywords and links of a feature type,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetFeatureTypeDescription(workspace string, datastore string, featureType string) (description *ResourceDescription, err error) {
+	restResponse := &restResourceDescriptionWrapper{}
+	err = client.getRestResource(
+		client.geoserverBaseURL+"/rest/workspaces/"+workspace+"/datastores/"+datastore+"/featuretypes/"+featureType+".json",
+		"feature type '"+featureType+"'", restResponse)
+	if err != nil {
+		return
+	}
+
+	return restResourceDescriptionToResourceDescription(restResponse.FeatureType), nil
+}
+
+// GetCoverageDescription gets the title, abstract, keywords and links of a coverage,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetCoverageDescription(workspace string, coverageStore string, coverage string) (description *ResourceDescription, err error) {
+	restResponse := &restResourceDescriptionWrapper{}
+	err = client.getRestResource(client.coveragesURL(workspace, coverageStore)+"/"+coverage+".json",
+		coverageDescription(workspace, coverageStore, coverage), restResponse)
+	if err != nil {
+		return
+	}
+
+	return restResourceDescriptionToResourceDescription(restResponse.Coverage), nil
+}
+
+/**
+ * REST API
+ */
+
+// keywordPattern matches the string representation Geoserver uses for keywords
+var keywordPattern = regexp.MustCompile(`^(.*?)(?:\\@language=(.*?)\\;)?(?:\\@vocabulary=(.*?)\\;)?$`)
+
+// restKeywords exists in order to represent the JSON required by Geoserver for keywords
+type restKeywords struct {
+	Strings restStringOrArray `json:"string"`
+}
+
+// restStringOrArray is a JSON array of strings which can be unmarshalled from a single string
+type restStringOrArray []string
+
+// UnmarshalJSON unmarshals either an array of strings or a single string
+func (list *restStringOrArray) UnmarshalJSON(data []byte) (err error) {
+	var single string
+	if json.Unmarshal(data, &single) == nil {
+		*list = restStringOrArray{single}
+		return
+	}
+
+	var many []string
+	err = json.Unmarshal(data, &many)
+	*list = restStringOrArray(many)
+	return
+}
+
+// restMetadataLinks exists in order to represent the JSON required by Geoserver for metadata links
+type restMetadataLinks struct {
+	MetadataLinks restMetadataLinkList `json:"metadataLink"`
+}
+
+// restMetadataLinkList are metadata links, which Geoserver encodes as a single object when there is only one
+type restMetadataLinkList []*restMetadataLink
+
+// restMetadataLink is a metadata link
+type restMetadataLink struct {
+	Type         string `json:"type"`
+	MetadataType string `json:"metadataType"`
+	Content      string `json:"content"`
+}
+
+// restDataLinks exists in order to represent the JSON required by Geoserver for data links,
+// which Geoserver names after the Java class implementing them
+type restDataLinks struct {
+	DataLinks restDataLinkList `json:"org.geoserver.catalog.impl.DataLinkInfoImpl"`
+}
+
+// restDataLinkList are data links, which Geoserver encodes as a single object when there is only one
+type restDataLinkList []*restDataLink
+
+// restDataLink is a data link
+type restDataLink struct {
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+// restResourceDescriptionWrapper exists in order to read the descriptive information of a feature type or coverage
+// from the JSON returned by Geoserver, only the field of the resource requested is set
+type restResourceDescriptionWrapper struct {
+	FeatureType *restResourceDescription `json:"featureType"`
+	Coverage    *restResourceDescription `json:"coverage"`
+}
+
+// restResourceDescription is the descriptive information of a feature type or coverage
+type restResourceDescription struct {
+	Title         string             `json:"title"`
+	Abstract      string             `json:"abstract"`
+	Keywords      *restKeywords      `json:"keywords"`
+	MetadataLinks *restMetadataLinks `json:"metadataLinks"`
+	DataLinks     *restDataLinks     `json:"dataLinks"`
+}
+
+// UnmarshalJSON unmarshals either an array of metadata links or a single metadata link
+func (links *restMetadataLinkList) UnmarshalJSON(data []byte) (err error) {
+	var many []*restMetadataLink
+	if json.Unmarshal(data, &many) == nil {
+		*links = many
+		return
+	}
+
+	single := &restMetadataLink{}
+	err = json.Unmarshal(data, single)
+	*links = restMetadataLinkList{single}
+	return
+}
+
+// UnmarshalJSON unmarshals either an array of data links or a single data link
+func (links *restDataLinkList) UnmarshalJSON(data []byte) (err error) {
+	var many []*restDataLink
+	if json.Unmarshal(data, &many) == nil {
+		*links = many
+		return
+	}
+
+	single := &restDataLink{}
+	err = json.Unmarshal(data, single)
+	*links = restDataLinkList{single}
+	return
+}
+
+// String returns the keyword in the form Geoserver uses e.g "rivers\@language=en\;\@vocabulary=GEMET\;"
+func (keyword *Keyword) String() string {
+	result := keyword.Value
+	if keyword.Language != "" {
+		result += `\@language=` + keyword.Language + `\;`
+	}
+	if keyword.Vocabulary != "" {
+		result += `\@vocabulary=` + keyword.Vocabulary + `\;`
+	}
+	return result
+}
+
+// parseKeyword parses the string representation Geoserver uses for keywords
+func parseKeyword(value string) *Keyword {
+	matches := keywordPattern.FindStringSubmatch(value)
+	if matches == nil {
+		return &Keyword{Value: value}
+	}
+	return &Keyword{
+		Value:      matches[1],
+		Language:   matches[2],
+		Vocabulary: matches[3],
+	}
+}
+
+// newRestKeywords converts keywords into restKeywords, or nil if there are none
+func newRestKeywords(keywords []*Keyword) *restKeywords {
+	if len(keywords) == 0 {
+		return nil
+	}
+
+	result := &restKeywords{}
+	for _, keyword := range keywords {
+		result.Strings = append(result.Strings, keyword.String())
+	}
+	return result
+}
+
+// newRestMetadataLinks converts metadata links into restMetadataLinks, or nil if there are none
+func newRestMetadataLinks(links []*MetadataLink) *restMetadataLinks {
+	if len(links) == 0 {
+		return nil
+	}
+
+	result := &restMetadataLinks{}
+	for _, link := range links {
+		result.MetadataLinks = append(result.MetadataLinks, &restMetadataLink{
+			Type:         link.Format,
+			MetadataType: link.Type,
+			Content:      link.URL,
+		})
+	}
+	return result
+}
+
+// newRestDataLinks converts data links into restDataLinks, or nil if there are none
+func newRestDataLinks(links []*DataLink) *restDataLinks {
+	if len(links) == 0 {
+		return nil
+	}
+
+	result := &restDataLinks{}
+	for _, link := range links {
+		result.DataLinks = append(result.DataLinks, &restDataLink{
+			Type:    link.Format,
+			Content: link.URL,
+		})
+	}
+	return result
+}
+
+// applyResourceInfoUpdate replaces the keywords and links which are provided in the JSON representation
+// of a feature type or coverage
+func applyResourceInfoUpdate(resource map[string]interface{}, keywords []*Keyword, metadataLinks []*MetadataLink, dataLinks []*DataLink) {
+	if keywords != nil {
+		resource["keywords"] = newRestKeywords(keywords)
+	}
+	if metadataLinks != nil {
+		resource["metadataLinks"] = newRestMetadataLinks(metadataLinks)
+	}
+	if dataLinks != nil {
+		resource["dataLinks"] = newRestDataLinks(dataLinks)
+	}
+}
+
+// restResourceDescriptionToResourceDescription converts a restResourceDescription into a ResourceDescription
+func restResourceDescriptionToResourceDescription(restDescription *restResourceDescription) *ResourceDescription {
+	description := &ResourceDescription{
+		Keywords:      make([]*Keyword, 0),
+		MetadataLinks: make([]*MetadataLink, 0),
+		DataLinks:     make([]*DataLink, 0),
+	}
+	if restDescription == nil {
+		return description
+	}
+
+	description.Title = restDescription.Title
+	description.Abstract = restDescription.Abstract
+	if restDescription.Keywords != nil {
+		for _, keyword := range restDescription.Keywords.Strings {
+			description.Keywords = append(description.Keywords, parseKeyword(keyword))
+		}
+	}
+	if restDescription.MetadataLinks != nil {
+		for _, link := range restDescription.MetadataLinks.MetadataLinks {
+			description.MetadataLinks = append(description.MetadataLinks, &MetadataLink{
+				Type:   link.MetadataType,
+				Format: link.Type,
+				URL:    link.Content,
+			})
+		}
+	}
+	if restDescription.DataLinks != nil {
+		for _, link := range restDescription.DataLinks.DataLinks {
+			description.DataLinks = append(description.DataLinks, &DataLink{
+				Format: link.Type,
+				URL:    link.Content,
+			})
+		}
+	}
+	return description
+}
@@ -0,0 +1,82 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestKeywordsRoundTripThroughTheGeoserverRepresentation(t *testing.T) {
+	for _, keyword := range []*Keyword{
+		{Value: "rivers"},
+		{Value: "rivers", Language: "en"},
+		{Value: "rivers", Vocabulary: "GEMET"},
+		{Value: "rivers", Language: "en", Vocabulary: "GEMET"},
+	} {
+		assert.Equal(t, keyword, parseKeyword(keyword.String()))
+	}
+	assert.Equal(t, `rivers\@language=en\;\@vocabulary=GEMET\;`, (&Keyword{Value: "rivers", Language: "en", Vocabulary: "GEMET"}).String())
+}
+
+func TestLinksAreSerialisedInTheGeoserverRepresentation(t *testing.T) {
+	request := newCreateFeatureTypeRestRequest(&CreateFeatureTypeRequest{
+		Name:          "rivers",
+		Keywords:      []*Keyword{{Value: "rivers", Language: "en"}},
+		MetadataLinks: []*MetadataLink{{Type: "ISO19115:2003", Format: "text/xml", URL: "http://example.com/metadata.xml"}},
+		DataLinks:     []*DataLink{{Format: "text/html", URL: "http://example.com/rivers"}},
+	}, nil)
+
+	body, err := json.Marshal(request)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"keywords":{"string":["rivers\\@language=en\\;"]}`)
+	assert.Contains(t, string(body), `"metadataLinks":{"metadataLink":[{"type":"text/xml","metadataType":"ISO19115:2003","content":"http://example.com/metadata.xml"}]}`)
+	assert.Contains(t, string(body), `"dataLinks":{"org.geoserver.catalog.impl.DataLinkInfoImpl":[{"type":"text/html","content":"http://example.com/rivers"}]}`)
+}
+
+func TestResourceDescriptionsAreReadFromGeoserver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/workspaces/topp/datastores/states/featuretypes/rivers.json":
+			w.Write([]byte(`{"featureType":{"name":"rivers","title":"Rivers","abstract":"The rivers of the state",` +
+				`"keywords":{"string":["rivers\\@language=en\\;","water"]},` +
+				`"metadataLinks":{"metadataLink":[{"type":"text/xml","metadataType":"ISO19115:2003","content":"http://example.com/a.xml"},` +
+				`{"type":"text/html","metadataType":"FGDC","content":"http://example.com/b.html"}]}}}`))
+		case "/rest/workspaces/ocean/coveragestores/sst/coverages/temperature.json":
+			w.Write([]byte(`{"coverage":{"name":"temperature","title":"SST","keywords":{"string":"temperature"},` +
+				`"metadataLinks":{"metadataLink":{"type":"text/xml","metadataType":"TC211","content":"http://example.com/sst.xml"}},` +
+				`"dataLinks":{"org.geoserver.catalog.impl.DataLinkInfoImpl":{"type":"text/html","content":"http://example.com/sst"}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(NewStdOutLogger(), &http.Client{}, server.URL, "admin", "geoserver")
+
+	description, err := client.GetFeatureTypeDescription("topp", "states", "rivers")
+	assert.NoError(t, err)
+	assert.Equal(t, &ResourceDescription{
+		Title:    "Rivers",
+		Abstract: "The rivers of the state",
+		Keywords: []*Keyword{{Value: "rivers", Language: "en"}, {Value: "water"}},
+		MetadataLinks: []*MetadataLink{
+			{Type: "ISO19115:2003", Format: "text/xml", URL: "http://example.com/a.xml"},
+			{Type: "FGDC", Format: "text/html", URL: "http://example.com/b.html"},
+		},
+		DataLinks: []*DataLink{},
+	}, description)
+
+	description, err = client.GetCoverageDescription("ocean", "sst", "temperature")
+	assert.NoError(t, err)
+	assert.Equal(t, &ResourceDescription{
+		Title:         "SST",
+		Keywords:      []*Keyword{{Value: "temperature"}},
+		MetadataLinks: []*MetadataLink{{Type: "TC211", Format: "text/xml", URL: "http://example.com/sst.xml"}},
+		DataLinks:     []*DataLink{{Format: "text/html", URL: "http://example.com/sst"}},
+	}, description)
+
+	_, err = client.GetCoverageDescription("ocean", "sst", "missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,580 @@
+package geoserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrIncorrectPassword is returned when changing a password and the old password provided is wrong
+var ErrIncorrectPassword = errors.New("the old password is incorrect")
+
+// wrongMasterPasswordMessage is the message Geoserver responds with when the old master password is wrong
+const wrongMasterPasswordMessage = "Wrong master password"
+
+// User is a user of the default Geoserver user group service
+type User struct {
+	// Name is the name of the user, used to log in
+	Name string
+
+	// Enabled is true when the user is able to log in
+	Enabled bool
+}
+
+// GetUsersResponse is the response to GetUsers
+type GetUsersResponse struct {
+	// Users are the users of the default user group service
+	Users []*User
+}
+
+// CreateUserRequest is a request to create a user
+type CreateUserRequest struct {
+	// Name is the name of the user, used to log in
+	Name string
+
+	// Password is the password of the user, it is never logged
+	Password string
+
+	// Enabled is true when the user is able to log in
+	Enabled bool
+}
+
+// UpdateUserRequest is a request to update a user
+type UpdateUserRequest struct {
+	// Name is the name of the user to update
+	Name string
+
+	// Password is the new password of the user, it is left unchanged when empty and is never logged
+	Password string
+
+	// Enabled is true when the user is able to log in
+	Enabled bool
+}
+
+// GetGroupsResponse is the response to GetGroups and GetUserGroups
+type GetGroupsResponse struct {
+	// Groups are the names of the groups
+	Groups []string
+}
+
+// GetRolesResponse is the response to GetRoles, GetUserRoles and GetGroupRoles
+type GetRolesResponse struct {
+	// Roles are the names of the roles
+	Roles []string
+}
+
+// GetUsers gets the users of the default user group service, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetUsers() (response *GetUsersResponse, err error) {
+	url := client.geoserverBaseURL + "/rest/security/usergroup/users"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for users",
+		urlKey, url,
+	)
+
+	responseBody, err := client.doSecurityRequest(http.MethodGet, url, nil, "unable to get users")
+	if err != nil {
+		return
+	}
+
+	restResponse := &restUsers{}
+	err = json.Unmarshal(responseBody, restResponse)
+	if err != nil {
+		client.logUnexpectedResponse("Geoserver returned an invalid response", url, httpCodeOK, responseBody)
+		return
+	}
+
+	response = &GetUsersResponse{
+		Users: make([]*User, 0),
+	}
+	for _, user := range restResponse.Users {
+		response.Users = append(response.Users, &User{
+			Name:    user.UserName,
+			Enabled: user.Enabled,
+		})
+	}
+	return
+}
+
+// CreateUser creates a user in the default user group service, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) CreateUser(request *CreateUserRequest) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/usergroup/users"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Creating a Geoserver user",
+		urlKey, url,
+		"user", request.Name,
+	)
+
+	restRequest := &restUserRequest{
+		User: &restUser{
+			UserName: request.Name,
+			Password: request.Password,
+			Enabled:  request.Enabled,
+		},
+	}
+	_, err = client.doSecurityRequest(http.MethodPost, url, restRequest, fmt.Sprintf("unable to create user '%s'", request.Name))
+	return
+}
+
+// UpdateUser updates a user in the default user group service, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UpdateUser(request *UpdateUserRequest) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/usergroup/user/" + pathEscape(request.Name)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Updating a Geoserver user",
+		urlKey, url,
+		"user", request.Name,
+	)
+
+	restRequest := &restUserRequest{
+		User: &restUser{
+			UserName: request.Name,
+			Password: request.Password,
+			Enabled:  request.Enabled,
+		},
+	}
+	_, err = client.doSecurityRequest(http.MethodPost, url, restRequest, fmt.Sprintf("unable to update user '%s'", request.Name))
+	return
+}
+
+// DeleteUser deletes a user from the default user group service, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteUser(user string) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/usergroup/user/" + pathEscape(user)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Deleting a Geoserver user",
+		urlKey, url,
+		"user", user,
+	)
+
+	_, err = client.doSecurityRequest(http.MethodDelete, url, nil, fmt.Sprintf("unable to delete user '%s'", user))
+	return
+}
+
+// GetGroups gets the groups of the default user group service, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetGroups() (response *GetGroupsResponse, err error) {
+	url := client.geoserverBaseURL + "/rest/security/usergroup/groups"
+	return client.getGroups(url, "unable to get groups")
+}
+
+// GetUserGroups gets the groups a user belongs to, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetUserGroups(user string) (response *GetGroupsResponse, err error) {
+	url := client.geoserverBaseURL + "/rest/security/usergroup/user/" + pathEscape(user) + "/groups"
+	return client.getGroups(url, fmt.Sprintf("unable to get the groups of user '%s'", user))
+}
+
+// CreateGroup creates a group in the default user group service, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) CreateGroup(group string) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/usergroup/group/" + pathEscape(group)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Creating a Geoserver group",
+		urlKey, url,
+		"group", group,
+	)
+
+	_, err = client.doSecurityRequest(http.MethodPost, url, nil, fmt.Sprintf("unable to create group '%s'", group))
+	return
+}
+
+// DeleteGroup deletes a group from the default user group service, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteGroup(group string) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/usergroup/group/" + pathEscape(group)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Deleting a Geoserver group",
+		urlKey, url,
+		"group", group,
+	)
+
+	_, err = client.doSecurityRequest(http.MethodDelete, url, nil, fmt.Sprintf("unable to delete group '%s'", group))
+	return
+}
+
+// AddUserToGroup adds a user to a group, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) AddUserToGroup(user string, group string) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/usergroup/user/" + pathEscape(user) + "/group/" + pathEscape(group)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Adding a Geoserver user to a group",
+		urlKey, url,
+		"user", user,
+		"group", group,
+	)
+
+	_, err = client.doSecurityRequest(http.MethodPost, url, nil, fmt.Sprintf("unable to add user '%s' to group '%s'", user, group))
+	return
+}
+
+// RemoveUserFromGroup removes a user from a group, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) RemoveUserFromGroup(user string, group string) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/usergroup/user/" + pathEscape(user) + "/group/" + pathEscape(group)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Removing a Geoserver user from a group",
+		urlKey, url,
+		"user", user,
+		"group", group,
+	)
+
+	_, err = client.doSecurityRequest(http.MethodDelete, url, nil, fmt.Sprintf("unable to remove user '%s' from group '%s'", user, group))
+	return
+}
+
+// GetRoles gets the roles of the default role service, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetRoles() (response *GetRolesResponse, err error) {
+	url := client.geoserverBaseURL + "/rest/security/roles"
+	return client.getRoles(url, "unable to get roles")
+}
+
+// GetUserRoles gets the roles associated with a user, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetUserRoles(user string) (response *GetRolesResponse, err error) {
+	url := client.geoserverBaseURL + "/rest/security/roles/user/" + pathEscape(user)
+	return client.getRoles(url, fmt.Sprintf("unable to get the roles of user '%s'", user))
+}
+
+// GetGroupRoles gets the roles associated with a group, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetGroupRoles(group string) (response *GetRolesResponse, err error) {
+	url := client.geoserverBaseURL + "/rest/security/roles/group/" + pathEscape(group)
+	return client.getRoles(url, fmt.Sprintf("unable to get the roles of group '%s'", group))
+}
+
+// CreateRole creates a role in the default role service, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) CreateRole(role string) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/roles/role/" + pathEscape(role)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Creating a Geoserver role",
+		urlKey, url,
+		"role", role,
+	)
+
+	_, err = client.doSecurityRequest(http.MethodPost, url, nil, fmt.Sprintf("unable to create role '%s'", role))
+	return
+}
+
+// DeleteRole deletes a role from the default role service, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteRole(role string) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/roles/role/" + pathEscape(role)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Deleting a Geoserver role",
+		urlKey, url,
+		"role", role,
+	)
+
+	_, err = client.doSecurityRequest(http.MethodDelete, url, nil, fmt.Sprintf("unable to delete role '%s'", role))
+	return
+}
+
+// AssignRoleToUser associates a role with a user, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) AssignRoleToUser(role string, user string) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/roles/role/" + pathEscape(role) + "/user/" + pathEscape(user)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Assigning a Geoserver role to a user",
+		urlKey, url,
+		"role", role,
+		"user", user,
+	)
+
+	_, err = client.doSecurityRequest(http.MethodPost, url, nil, fmt.Sprintf("unable to assign role '%s' to user '%s'", role, user))
+	return
+}
+
+// RemoveRoleFromUser removes the association between a role and a user, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) RemoveRoleFromUser(role string, user string) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/roles/role/" + pathEscape(role) + "/user/" + pathEscape(user)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Removing a Geoserver role from a user",
+		urlKey, url,
+		"role", role,
+		"user", user,
+	)
+
+	_, err = client.doSecurityRequest(http.MethodDelete, url, nil, fmt.Sprintf("unable to remove role '%s' from user '%s'", role, user))
+	return
+}
+
+// AssignRoleToGroup associates a role with a group, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) AssignRoleToGroup(role string, group string) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/roles/role/" + pathEscape(role) + "/group/" + pathEscape(group)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Assigning a Geoserver role to a group",
+		urlKey, url,
+		"role", role,
+		"group", group,
+	)
+
+	_, err = client.doSecurityRequest(http.MethodPost, url, nil, fmt.Sprintf("unable to assign role '%s' to group '%s'", role, group))
+	return
+}
+
+// RemoveRoleFromGroup removes the association between a role and a group, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) RemoveRoleFromGroup(role string, group string) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/roles/role/" + pathEscape(role) + "/group/" + pathEscape(group)
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Removing a Geoserver role from a group",
+		urlKey, url,
+		"role", role,
+		"group", group,
+	)
+
+	_, err = client.doSecurityRequest(http.MethodDelete, url, nil, fmt.Sprintf("unable to remove role '%s' from group '%s'", role, group))
+	return
+}
+
+// ChangeMasterPassword changes the keystore master password, returning ErrIncorrectPassword if the old password is wrong.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) ChangeMasterPassword(oldPassword string, newPassword string) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/masterpw"
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Changing the Geoserver master password",
+		urlKey, url,
+	)
+
+	restRequest := &restMasterPasswordRequest{
+		OldMasterPassword: oldPassword,
+		NewMasterPassword: newPassword,
+	}
+	statusCode, responseBody, err := client.doJSONRequest(http.MethodPut, url, restRequest)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode {
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "Master password changed successfully",
+			urlKey, url,
+		)
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to change the master password", url, statusCode, responseBody)
+	if bytes.Contains(responseBody, []byte(wrongMasterPasswordMessage)) {
+		err = ErrIncorrectPassword
+		return
+	}
+
+	err = fmt.Errorf("unable to change the master password")
+	return
+}
+
+// ChangeSelfPassword changes the password of the user the client authenticates as, returning ErrIncorrectPassword
+// if the old password is wrong. The request is authenticated using the old password, and the client uses the
+// new password from then on.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) ChangeSelfPassword(oldPassword string, newPassword string) (err error) {
+	url := client.geoserverBaseURL + "/rest/security/self/password"
+
+	username, _ := client.credentials()
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Changing the password of the Geoserver user",
+		urlKey, url,
+		"user", username,
+	)
+
+	requestJSONBytes, err := json.Marshal(&restSelfPasswordRequest{NewPassword: newPassword})
+	if err != nil {
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(requestJSONBytes))
+	if err != nil {
+		return
+	}
+	req.Header.Set(contentTypeHeader, applicationJSON)
+	req.Header.Set(acceptHeader, applicationJSON)
+	req.SetBasicAuth(username, oldPassword)
+
+	statusCode, responseBody, err := client.doRequest(req)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode {
+		client.credentialsMutex.Lock()
+		client.geoserverPassword = newPassword
+		client.credentialsMutex.Unlock()
+
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "Password changed successfully",
+			urlKey, url,
+			"user", username,
+		)
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to change the password of the Geoserver user", url, statusCode, responseBody)
+	if httpCodeUnauthorized == statusCode {
+		err = ErrIncorrectPassword
+		return
+	}
+
+	err = fmt.Errorf("unable to change the password of user '%s'", username)
+	return
+}
+
+// getGroups gets a list of groups from the provided URL
+func (client *RestGeoserverClient) getGroups(url string, failure string) (response *GetGroupsResponse, err error) {
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for groups",
+		urlKey, url,
+	)
+
+	responseBody, err := client.doSecurityRequest(http.MethodGet, url, nil, failure)
+	if err != nil {
+		return
+	}
+
+	restResponse := &restGroups{}
+	err = json.Unmarshal(responseBody, restResponse)
+	if err != nil {
+		client.logUnexpectedResponse("Geoserver returned an invalid response", url, httpCodeOK, responseBody)
+		return
+	}
+
+	response = &GetGroupsResponse{
+		Groups: make([]string, 0),
+	}
+	response.Groups = append(response.Groups, restResponse.Groups...)
+	return
+}
+
+// getRoles gets a list of roles from the provided URL
+func (client *RestGeoserverClient) getRoles(url string, failure string) (response *GetRolesResponse, err error) {
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for roles",
+		urlKey, url,
+	)
+
+	responseBody, err := client.doSecurityRequest(http.MethodGet, url, nil, failure)
+	if err != nil {
+		return
+	}
+
+	restResponse := &restRoles{}
+	err = json.Unmarshal(responseBody, restResponse)
+	if err != nil {
+		client.logUnexpectedResponse("Geoserver returned an invalid response", url, httpCodeOK, responseBody)
+		return
+	}
+
+	response = &GetRolesResponse{
+		Roles: make([]string, 0),
+	}
+	response.Roles = append(response.Roles, restResponse.Roles...)
+	return
+}
+
+// doSecurityRequest sends a request to the security REST API, returning the response body when Geoserver
+// responds with a success code and an error with the provided failure message otherwise.
+// The payload is never logged, as it may contain passwords.
+func (client *RestGeoserverClient) doSecurityRequest(method string, url string, payload interface{}, failure string) (responseBody []byte, err error) {
+	statusCode, responseBody, err := client.doJSONRequest(method, url, payload)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode || codeCreated == statusCode {
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "Geoserver security request was successful",
+			urlKey, url,
+		)
+		return
+	}
+
+	client.logUnexpectedResponse("Geoserver security request was unsuccessful", url, statusCode, responseBody)
+	err = errors.New(failure)
+	return
+}
+
+/**
+ * REST API
+ */
+
+// restUsers exists in order to represent the JSON returned by Geoserver for users
+type restUsers struct {
+	Users []*restUser `json:"users"`
+}
+
+// restUserRequest exists in order to represent the JSON required by Geoserver to create or update a user
+type restUserRequest struct {
+	User *restUser `json:"user"`
+}
+
+// restUser is a user
+type restUser struct {
+	UserName string `json:"userName"`
+	Password string `json:"password,omitempty"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// restGroups exists in order to represent the JSON returned by Geoserver for groups
+type restGroups struct {
+	Groups []string `json:"groups"`
+}
+
+// restRoles exists in order to represent the JSON returned by Geoserver for roles
+type restRoles struct {
+	Roles []string `json:"roles"`
+}
+
+// restMasterPasswordRequest exists in order to represent the JSON required by Geoserver to change the master password
+type restMasterPasswordRequest struct {
+	OldMasterPassword string `json:"oldMasterPassword"`
+	NewMasterPassword string `json:"newMasterPassword"`
+}
+
+// restSelfPasswordRequest exists in order to represent the JSON required by Geoserver for a user to change their password
+type restSelfPasswordRequest struct {
+	NewPassword string `json:"newPassword"`
+}
@@ -0,0 +1,168 @@
+package geoserver
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingLogger is a LoggerFunc which records every log line
+type recordingLogger struct {
+	lock  sync.Mutex
+	lines []string
+}
+
+// Log records the log line
+func (logger *recordingLogger) Log(s string, args ...interface{}) {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+	logger.lines = append(logger.lines, fmt.Sprint(append([]interface{}{s}, args...)...))
+}
+
+func TestCreateAndUpdateUserNeverLogPasswords(t *testing.T) {
+	var requestBodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requestBodies = append(requestBodies, string(body))
+		if r.URL.Path == "/rest/security/usergroup/users" {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := NewRestGeoserverClient(logger, &http.Client{}, server.URL, "admin", "geoserver")
+
+	err := client.CreateUser(&CreateUserRequest{Name: "bob", Password: "s3cr3t-create", Enabled: true})
+	assert.NoError(t, err)
+
+	err = client.UpdateUser(&UpdateUserRequest{Name: "bob", Password: "s3cr3t-update", Enabled: true})
+	assert.Error(t, err)
+
+	assert.Contains(t, requestBodies[0], `"password":"s3cr3t-create"`)
+	assert.Contains(t, requestBodies[1], `"password":"s3cr3t-update"`)
+	for _, line := range logger.lines {
+		assert.NotContains(t, line, "s3cr3t")
+	}
+}
+
+func TestUserGroupAndRoleNamesAreEscapedInURLs(t *testing.T) {
+	var requestURIs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURIs = append(requestURIs, r.Method+" "+r.URL.RequestURI())
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	assert.NoError(t, client.DeleteUser("jane doe@example.com#1"))
+	assert.NoError(t, client.AddUserToGroup("jane/doe", "map editors?"))
+	assert.NoError(t, client.DeleteRole("ROLE_A/B"))
+	assert.NoError(t, client.AssignRoleToUser("ROLE_EDITOR", "jane doe@example.com"))
+	assert.Equal(t, []string{
+		"DELETE /rest/security/usergroup/user/jane%20doe%40example.com%231",
+		"POST /rest/security/usergroup/user/jane%2Fdoe/group/map%20editors%3F",
+		"DELETE /rest/security/roles/role/ROLE_A%2FB",
+		"POST /rest/security/roles/role/ROLE_EDITOR/user/jane%20doe%40example.com",
+	}, requestURIs)
+}
+
+func TestChangeSelfPasswordUpdatesTheClientCredentials(t *testing.T) {
+	password := "geoserver"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, requestPassword, _ := r.BasicAuth()
+		if requestPassword != password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/rest/security/self/password" {
+			password = "changed"
+		}
+		w.Write([]byte(`{"users":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	err := client.ChangeSelfPassword("wrong", "changed")
+	assert.Equal(t, ErrIncorrectPassword, err)
+
+	err = client.ChangeSelfPassword("geoserver", "changed")
+	assert.NoError(t, err)
+
+	_, err = client.GetUsers()
+	assert.NoError(t, err)
+}
+
+func TestChangeMasterPasswordReturnsErrIncorrectPasswordWhenTheOldPasswordIsWrong(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(422)
+		w.Write([]byte("Wrong master password"))
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	err := client.ChangeMasterPassword("wrong", "changed")
+	assert.Equal(t, ErrIncorrectPassword, err)
+}
+
+func TestChangeMasterPasswordDoesNotReturnTheResponseBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("java.lang.IllegalStateException at org.geoserver.security"))
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	err := client.ChangeMasterPassword("geoserver", "changed")
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "IllegalStateException")
+}
+
+func TestChangeSelfPasswordDoesNotBlockOtherRequests(t *testing.T) {
+	arrived := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/security/self/password" {
+			close(arrived)
+			<-release
+		}
+		w.Write([]byte(`{"users":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	changed := make(chan error)
+	go func() {
+		changed <- client.ChangeSelfPassword("geoserver", "changed")
+	}()
+	<-arrived
+
+	queried := make(chan error)
+	go func() {
+		_, err := client.GetUsers()
+		queried <- err
+	}()
+
+	select {
+	case err := <-queried:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Error("GetUsers was blocked by the password change")
+	}
+
+	close(release)
+	assert.NoError(t, <-changed)
+	_, password := client.credentials()
+	assert.Equal(t, "changed", password)
+}
@@ -0,0 +1,677 @@
+package geoserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// OWSService is an OGC service provided by Geoserver
+type OWSService string
+
+const (
+	// ServiceWMS is the Web Map Service
+	ServiceWMS OWSService = "wms"
+
+	// ServiceWFS is the Web Feature Service
+	ServiceWFS OWSService = "wfs"
+
+	// ServiceWCS is the Web Coverage Service
+	ServiceWCS OWSService = "wcs"
+
+	// ServiceWMTS is the Web Map Tile Service
+	ServiceWMTS OWSService = "wmts"
+)
+
+// WatermarkPosition is the position of a watermark on a map
+type WatermarkPosition string
+
+const (
+	// WatermarkTopLeft places the watermark in the top left corner
+	WatermarkTopLeft WatermarkPosition = "TOP_LEFT"
+
+	// WatermarkTopCenter places the watermark at the centre of the top edge
+	WatermarkTopCenter WatermarkPosition = "TOP_CENTER"
+
+	// WatermarkTopRight places the watermark in the top right corner
+	WatermarkTopRight WatermarkPosition = "TOP_RIGHT"
+
+	// WatermarkMidLeft places the watermark at the centre of the left edge
+	WatermarkMidLeft WatermarkPosition = "MID_LEFT"
+
+	// WatermarkMidCenter places the watermark at the centre of the map
+	WatermarkMidCenter WatermarkPosition = "MID_CENTER"
+
+	// WatermarkMidRight places the watermark at the centre of the right edge
+	WatermarkMidRight WatermarkPosition = "MID_RIGHT"
+
+	// WatermarkBottomLeft places the watermark in the bottom left corner
+	WatermarkBottomLeft WatermarkPosition = "BOT_LEFT"
+
+	// WatermarkBottomCenter places the watermark at the centre of the bottom edge
+	WatermarkBottomCenter WatermarkPosition = "BOT_CENTER"
+
+	// WatermarkBottomRight places the watermark in the bottom right corner
+	WatermarkBottomRight WatermarkPosition = "BOT_RIGHT"
+)
+
+// WFSServiceLevel is the level of functionality provided by WFS
+type WFSServiceLevel string
+
+const (
+	// WFSServiceBasic only allows features to be read
+	WFSServiceBasic WFSServiceLevel = "BASIC"
+
+	// WFSServiceTransactional allows features to be read and modified
+	WFSServiceTransactional WFSServiceLevel = "TRANSACTIONAL"
+
+	// WFSServiceComplete allows features to be read, modified and locked
+	WFSServiceComplete WFSServiceLevel = "COMPLETE"
+)
+
+// GMLVersion is a version of GML produced by WFS
+type GMLVersion string
+
+const (
+	// GML2 is GML 2, used by WFS 1.0
+	GML2 GMLVersion = "V_10"
+
+	// GML3 is GML 3.1, used by WFS 1.1
+	GML3 GMLVersion = "V_11"
+
+	// GML32 is GML 3.2, used by WFS 2.0
+	GML32 GMLVersion = "V_20"
+)
+
+// SRSNameStyle is the style of the SRS names in GML
+type SRSNameStyle string
+
+const (
+	// SRSNameNormal produces names such as "EPSG:4326"
+	SRSNameNormal SRSNameStyle = "NORMAL"
+
+	// SRSNameXML produces names such as "http://www.opengis.net/gml/srs/epsg.xml#4326"
+	SRSNameXML SRSNameStyle = "XML"
+
+	// SRSNameURN produces names such as "urn:x-ogc:def:crs:EPSG:4326"
+	SRSNameURN SRSNameStyle = "URN"
+
+	// SRSNameURN2 produces names such as "urn:ogc:def:crs:EPSG::4326"
+	SRSNameURN2 SRSNameStyle = "URN2"
+
+	// SRSNameURL produces names such as "http://www.opengis.net/def/crs/EPSG/0/4326"
+	SRSNameURL SRSNameStyle = "URL"
+)
+
+// ServiceSettings are the settings shared by every service
+type ServiceSettings struct {
+	// Workspace is the workspace the settings apply to, it is set when workspace settings are retrieved.
+	Workspace string
+
+	// Enabled determines whether the service responds to requests.
+	Enabled bool
+
+	// Title is the title of the service advertised in its capabilities document.
+	Title string
+
+	// Abstract is the description of the service advertised in its capabilities document.
+	Abstract string
+
+	// Maintainer is the maintainer of the service.
+	Maintainer string
+
+	// AccessConstraints are the constraints on accessing the service.
+	AccessConstraints string
+
+	// Fees are the fees for using the service.
+	Fees string
+
+	// OnlineResource is the URL of the website advertised in the capabilities document.
+	OnlineResource string
+
+	// Keywords are the keywords advertised in the capabilities document.
+	Keywords []string
+
+	// SRS limits the coordinate reference systems advertised by the service, every one is advertised when empty.
+	// It is only used by WMS, WFS and WCS.
+	SRS []string
+
+	// Verbose determines whether responses are indented.
+	Verbose bool
+
+	// CiteCompliant determines whether the service strictly follows the OGC specification.
+	CiteCompliant bool
+}
+
+// Watermark is an image drawn over every map produced by WMS
+type Watermark struct {
+	// Enabled determines whether the watermark is drawn.
+	Enabled bool
+
+	// Position is the position of the watermark on the map.
+	Position WatermarkPosition
+
+	// Transparency is the transparency of the watermark, from 0 for opaque to 100 for invisible.
+	Transparency int
+
+	// URL is the URL of the watermark image.
+	URL string
+}
+
+// WMSSettings are the settings of WMS
+type WMSSettings struct {
+	ServiceSettings
+
+	// MaxRequestMemory is the maximum memory used to render a map, in kilobytes, there is no limit when zero.
+	MaxRequestMemory int
+
+	// MaxRenderingTime is the maximum time spent rendering a map, there is no limit when zero.
+	MaxRenderingTime time.Duration
+
+	// MaxRenderingErrors is the maximum number of errors tolerated while rendering a map, there is no limit when zero.
+	MaxRenderingErrors int
+
+	// MaxBuffer is the maximum number of pixels a map is rendered beyond its edges to draw symbols correctly.
+	MaxBuffer int
+
+	// Watermark is drawn over every map.
+	Watermark *Watermark
+}
+
+// GMLSettings are the settings of a version of GML produced by WFS
+type GMLSettings struct {
+	// SRSNameStyle is the style of the SRS names in the GML.
+	SRSNameStyle SRSNameStyle
+
+	// OverrideGMLAttributes determines whether the GML attributes such as name and description are encoded
+	// from attributes of the same name.
+	OverrideGMLAttributes bool
+}
+
+// WFSSettings are the settings of WFS
+type WFSSettings struct {
+	ServiceSettings
+
+	// MaxFeatures is the maximum number of features returned by a request.
+	MaxFeatures int
+
+	// ServiceLevel is the level of functionality provided.
+	ServiceLevel WFSServiceLevel
+
+	// FeatureBounding determines whether each feature's bounding box is encoded.
+	FeatureBounding bool
+
+	// HitsIgnoreMaxFeatures determines whether requests for the number of features ignore MaxFeatures.
+	HitsIgnoreMaxFeatures bool
+
+	// GML are the settings of each version of GML.
+	GML map[GMLVersion]*GMLSettings
+}
+
+// WCSSettings are the settings of WCS
+type WCSSettings struct {
+	ServiceSettings
+
+	// MaxInputMemory is the maximum memory used to read coverage data, in kilobytes, there is no limit when zero.
+	MaxInputMemory int
+
+	// MaxOutputMemory is the maximum memory used to produce a coverage, in kilobytes, there is no limit when zero.
+	MaxOutputMemory int
+
+	// SubsamplingEnabled determines whether requests can subsample coverages.
+	SubsamplingEnabled bool
+
+	// LatLon determines whether coverages are advertised with their bounding box in latitude and longitude.
+	LatLon bool
+
+	// GMLPrefixing determines whether the GML in responses is prefixed.
+	GMLPrefixing bool
+}
+
+// WMTSSettings are the settings of WMTS
+type WMTSSettings struct {
+	ServiceSettings
+}
+
+// GetWMSSettings gets the settings of WMS, for a workspace or globally when the workspace is empty,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetWMSSettings(workspace string) (settings *WMSSettings, err error) {
+	restResponse := &restServiceSettingsWrapper{}
+	err = client.getServiceSettings(ServiceWMS, workspace, restResponse)
+	if err != nil {
+		return
+	}
+
+	settings = restServiceToWMSSettings(restResponse.WMS)
+	return
+}
+
+// UpdateWMSSettings replaces the settings of WMS, for a workspace or globally when the workspace is empty,
+// returning an error if it is not possible. Workspace settings are created when they do not exist.
+// Every field is sent, so the settings should be retrieved with GetWMSSettings and then modified.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UpdateWMSSettings(workspace string, settings *WMSSettings) (err error) {
+	return client.updateServiceSettings(ServiceWMS, workspace, &restServiceSettingsWrapper{WMS: newRestWMSService(workspace, settings)})
+}
+
+// DeleteWMSSettings deletes the WMS settings of a workspace, so the global settings apply to it again,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteWMSSettings(workspace string) (err error) {
+	return client.deleteServiceSettings(ServiceWMS, workspace)
+}
+
+// GetWFSSettings gets the settings of WFS, for a workspace or globally when the workspace is empty,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetWFSSettings(workspace string) (settings *WFSSettings, err error) {
+	restResponse := &restServiceSettingsWrapper{}
+	err = client.getServiceSettings(ServiceWFS, workspace, restResponse)
+	if err != nil {
+		return
+	}
+
+	settings = restServiceToWFSSettings(restResponse.WFS)
+	return
+}
+
+// UpdateWFSSettings replaces the settings of WFS, for a workspace or globally when the workspace is empty,
+// returning an error if it is not possible. Workspace settings are created when they do not exist.
+// Every field is sent, so the settings should be retrieved with GetWFSSettings and then modified.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UpdateWFSSettings(workspace string, settings *WFSSettings) (err error) {
+	return client.updateServiceSettings(ServiceWFS, workspace, &restServiceSettingsWrapper{WFS: newRestWFSService(workspace, settings)})
+}
+
+// DeleteWFSSettings deletes the WFS settings of a workspace, so the global settings apply to it again,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteWFSSettings(workspace string) (err error) {
+	return client.deleteServiceSettings(ServiceWFS, workspace)
+}
+
+// GetWCSSettings gets the settings of WCS, for a workspace or globally when the workspace is empty,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetWCSSettings(workspace string) (settings *WCSSettings, err error) {
+	restResponse := &restServiceSettingsWrapper{}
+	err = client.getServiceSettings(ServiceWCS, workspace, restResponse)
+	if err != nil {
+		return
+	}
+
+	settings = restServiceToWCSSettings(restResponse.WCS)
+	return
+}
+
+// UpdateWCSSettings replaces the settings of WCS, for a workspace or globally when the workspace is empty,
+// returning an error if it is not possible. Workspace settings are created when they do not exist.
+// Every field is sent, so the settings should be retrieved with GetWCSSettings and then modified.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UpdateWCSSettings(workspace string, settings *WCSSettings) (err error) {
+	return client.updateServiceSettings(ServiceWCS, workspace, &restServiceSettingsWrapper{WCS: newRestWCSService(workspace, settings)})
+}
+
+// DeleteWCSSettings deletes the WCS settings of a workspace, so the global settings apply to it again,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteWCSSettings(workspace string) (err error) {
+	return client.deleteServiceSettings(ServiceWCS, workspace)
+}
+
+// GetWMTSSettings gets the settings of WMTS, for a workspace or globally when the workspace is empty,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetWMTSSettings(workspace string) (settings *WMTSSettings, err error) {
+	restResponse := &restServiceSettingsWrapper{}
+	err = client.getServiceSettings(ServiceWMTS, workspace, restResponse)
+	if err != nil {
+		return
+	}
+
+	settings = &WMTSSettings{ServiceSettings: *restServiceToServiceSettings(restResponse.WMTS)}
+	return
+}
+
+// UpdateWMTSSettings replaces the settings of WMTS, for a workspace or globally when the workspace is empty,
+// returning an error if it is not possible. Workspace settings are created when they do not exist.
+// Every field is sent, so the settings should be retrieved with GetWMTSSettings and then modified.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UpdateWMTSSettings(workspace string, settings *WMTSSettings) (err error) {
+	return client.updateServiceSettings(ServiceWMTS, workspace,
+		&restServiceSettingsWrapper{WMTS: newRestService(workspace, &settings.ServiceSettings)})
+}
+
+// DeleteWMTSSettings deletes the WMTS settings of a workspace, so the global settings apply to it again,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteWMTSSettings(workspace string) (err error) {
+	return client.deleteServiceSettings(ServiceWMTS, workspace)
+}
+
+// serviceSettingsURL creates the URL of the settings of a service, for a workspace or globally when the workspace is empty
+func (client *RestGeoserverClient) serviceSettingsURL(service OWSService, workspace string) string {
+	if workspace == "" {
+		return client.geoserverBaseURL + "/rest/services/" + string(service) + "/settings"
+	}
+	return client.geoserverBaseURL + "/rest/services/" + string(service) + "/workspaces/" + workspace + "/settings"
+}
+
+// serviceSettingsDescription describes the settings of a service for logs and errors
+func serviceSettingsDescription(service OWSService, workspace string) string {
+	if workspace == "" {
+		return fmt.Sprintf("the global %s settings", service)
+	}
+	return fmt.Sprintf("the %s settings of workspace '%s'", service, workspace)
+}
+
+// getServiceSettings gets the settings of a service, returning an error if Geoserver does not return them
+func (client *RestGeoserverClient) getServiceSettings(service OWSService, workspace string, result *restServiceSettingsWrapper) (err error) {
+	description := serviceSettingsDescription(service, workspace)
+	err = client.getRestResource(client.serviceSettingsURL(service, workspace), description, result)
+	if err != nil {
+		return
+	}
+
+	if result.service(service) == nil {
+		err = fmt.Errorf("unable to get %s, Geoserver returned an invalid response", description)
+	}
+	return
+}
+
+// updateServiceSettings replaces the settings of a service
+func (client *RestGeoserverClient) updateServiceSettings(service OWSService, workspace string, payload *restServiceSettingsWrapper) (err error) {
+	return client.sendRestResource(http.MethodPut, client.serviceSettingsURL(service, workspace),
+		serviceSettingsDescription(service, workspace), payload)
+}
+
+// deleteServiceSettings deletes the settings of a service for a workspace
+func (client *RestGeoserverClient) deleteServiceSettings(service OWSService, workspace string) (err error) {
+	if workspace == "" {
+		err = fmt.Errorf("the global %s settings cannot be deleted", service)
+		return
+	}
+	return client.deleteRestResource(client.serviceSettingsURL(service, workspace), serviceSettingsDescription(service, workspace))
+}
+
+/**
+ * REST API
+ */
+
+// restServiceSettingsWrapper exists in order to represent the JSON used by Geoserver for the settings of a service
+type restServiceSettingsWrapper struct {
+	WMS  *restService `json:"wms,omitempty"`
+	WFS  *restService `json:"wfs,omitempty"`
+	WCS  *restService `json:"wcs,omitempty"`
+	WMTS *restService `json:"wmts,omitempty"`
+}
+
+// restService is the settings of a service, holding the properties of every kind of service
+type restService struct {
+	Workspace         *restNamedEntity `json:"workspace,omitempty"`
+	Enabled           bool             `json:"enabled"`
+	Title             string           `json:"title,omitempty"`
+	Abstract          string           `json:"abstrct,omitempty"`
+	Maintainer        string           `json:"maintainer,omitempty"`
+	AccessConstraints string           `json:"accessConstraints,omitempty"`
+	Fees              string           `json:"fees,omitempty"`
+	OnlineResource    string           `json:"onlineResource,omitempty"`
+	Keywords          *restStringList  `json:"keywords,omitempty"`
+	SRS               *restStringList  `json:"srs,omitempty"`
+	Verbose           bool             `json:"verbose"`
+	CiteCompliant     bool             `json:"citeCompliant"`
+
+	// WMS
+	MaxRequestMemory   *int           `json:"maxRequestMemory,omitempty"`
+	MaxRenderingTime   *int           `json:"maxRenderingTime,omitempty"`
+	MaxRenderingErrors *int           `json:"maxRenderingErrors,omitempty"`
+	MaxBuffer          *int           `json:"maxBuffer,omitempty"`
+	Watermark          *restWatermark `json:"watermark,omitempty"`
+
+	// WFS
+	MaxFeatures           *int            `json:"maxFeatures,omitempty"`
+	ServiceLevel          WFSServiceLevel `json:"serviceLevel,omitempty"`
+	FeatureBounding       *bool           `json:"featureBounding,omitempty"`
+	HitsIgnoreMaxFeatures *bool           `json:"hitsIgnoreMaxFeatures,omitempty"`
+	GML                   *restGMLEntries `json:"gml,omitempty"`
+
+	// WCS
+	MaxInputMemory     *int  `json:"maxInputMemory,omitempty"`
+	MaxOutputMemory    *int  `json:"maxOutputMemory,omitempty"`
+	SubsamplingEnabled *bool `json:"subsamplingEnabled,omitempty"`
+	LatLon             *bool `json:"latLon,omitempty"`
+	GMLPrefixing       *bool `json:"gmlPrefixing,omitempty"`
+}
+
+// restStringList is a list of strings, which Geoserver encodes as a single string when there is only one
+type restStringList struct {
+	Strings restStringOrArray `json:"string"`
+}
+
+// restWatermark is the watermark of WMS
+type restWatermark struct {
+	Enabled      bool              `json:"enabled"`
+	Position     WatermarkPosition `json:"position,omitempty"`
+	Transparency int               `json:"transparency"`
+	URL          string            `json:"URL,omitempty"`
+}
+
+// restGMLEntries are the settings of each version of GML produced by WFS
+type restGMLEntries struct {
+	Entries []*restGMLEntry `json:"entry"`
+}
+
+// restGMLEntry is the settings of a version of GML
+type restGMLEntry struct {
+	Version GMLVersion       `json:"version"`
+	GML     *restGMLSettings `json:"gml"`
+}
+
+// restGMLSettings is the settings of a version of GML
+type restGMLSettings struct {
+	SRSNameStyle          restStringOrArray `json:"srsNameStyle,omitempty"`
+	OverrideGMLAttributes bool              `json:"overrideGMLAttributes"`
+}
+
+// service returns the settings of a service
+func (wrapper *restServiceSettingsWrapper) service(service OWSService) *restService {
+	switch service {
+	case ServiceWMS:
+		return wrapper.WMS
+	case ServiceWFS:
+		return wrapper.WFS
+	case ServiceWCS:
+		return wrapper.WCS
+	}
+	return wrapper.WMTS
+}
+
+// newRestStringList converts strings into a restStringList, or nil if there are none
+func newRestStringList(strings []string) *restStringList {
+	if len(strings) == 0 {
+		return nil
+	}
+	return &restStringList{Strings: restStringOrArray(strings)}
+}
+
+// newRestService converts ServiceSettings into a restService
+func newRestService(workspace string, settings *ServiceSettings) *restService {
+	service := &restService{
+		Enabled:           settings.Enabled,
+		Title:             settings.Title,
+		Abstract:          settings.Abstract,
+		Maintainer:        settings.Maintainer,
+		AccessConstraints: settings.AccessConstraints,
+		Fees:              settings.Fees,
+		OnlineResource:    settings.OnlineResource,
+		Keywords:          newRestStringList(settings.Keywords),
+		SRS:               newRestStringList(settings.SRS),
+		Verbose:           settings.Verbose,
+		CiteCompliant:     settings.CiteCompliant,
+	}
+	if workspace != "" {
+		service.Workspace = &restNamedEntity{Name: workspace}
+	}
+	return service
+}
+
+// newRestWMSService converts WMSSettings into a restService
+func newRestWMSService(workspace string, settings *WMSSettings) *restService {
+	service := newRestService(workspace, &settings.ServiceSettings)
+	maxRenderingTime := int(settings.MaxRenderingTime / time.Second)
+	service.MaxRequestMemory = &settings.MaxRequestMemory
+	service.MaxRenderingTime = &maxRenderingTime
+	service.MaxRenderingErrors = &settings.MaxRenderingErrors
+	service.MaxBuffer = &settings.MaxBuffer
+	if settings.Watermark != nil {
+		service.Watermark = &restWatermark{
+			Enabled:      settings.Watermark.Enabled,
+			Position:     settings.Watermark.Position,
+			Transparency: settings.Watermark.Transparency,
+			URL:          settings.Watermark.URL,
+		}
+	}
+	return service
+}
+
+// newRestWFSService converts WFSSettings into a restService
+func newRestWFSService(workspace string, settings *WFSSettings) *restService {
+	service := newRestService(workspace, &settings.ServiceSettings)
+	service.MaxFeatures = &settings.MaxFeatures
+	service.ServiceLevel = settings.ServiceLevel
+	service.FeatureBounding = &settings.FeatureBounding
+	service.HitsIgnoreMaxFeatures = &settings.HitsIgnoreMaxFeatures
+
+	if len(settings.GML) > 0 {
+		versions := make([]string, 0)
+		for version := range settings.GML {
+			versions = append(versions, string(version))
+		}
+		sort.Strings(versions)
+
+		service.GML = &restGMLEntries{}
+		for _, version := range versions {
+			gml := settings.GML[GMLVersion(version)]
+			entry := &restGMLEntry{Version: GMLVersion(version)}
+			entry.GML = &restGMLSettings{OverrideGMLAttributes: gml.OverrideGMLAttributes}
+			if gml.SRSNameStyle != "" {
+				entry.GML.SRSNameStyle = restStringOrArray{string(gml.SRSNameStyle)}
+			}
+			service.GML.Entries = append(service.GML.Entries, entry)
+		}
+	}
+	return service
+}
+
+// newRestWCSService converts WCSSettings into a restService
+func newRestWCSService(workspace string, settings *WCSSettings) *restService {
+	service := newRestService(workspace, &settings.ServiceSettings)
+	service.MaxInputMemory = &settings.MaxInputMemory
+	service.MaxOutputMemory = &settings.MaxOutputMemory
+	service.SubsamplingEnabled = &settings.SubsamplingEnabled
+	service.LatLon = &settings.LatLon
+	service.GMLPrefixing = &settings.GMLPrefixing
+	return service
+}
+
+// restServiceToServiceSettings converts a restService into ServiceSettings
+func restServiceToServiceSettings(service *restService) *ServiceSettings {
+	settings := &ServiceSettings{
+		Enabled:           service.Enabled,
+		Title:             service.Title,
+		Abstract:          service.Abstract,
+		Maintainer:        service.Maintainer,
+		AccessConstraints: service.AccessConstraints,
+		Fees:              service.Fees,
+		OnlineResource:    service.OnlineResource,
+		Keywords:          make([]string, 0),
+		SRS:               make([]string, 0),
+		Verbose:           service.Verbose,
+		CiteCompliant:     service.CiteCompliant,
+	}
+	if service.Workspace != nil {
+		settings.Workspace = service.Workspace.Name
+	}
+	if service.Keywords != nil {
+		settings.Keywords = append(settings.Keywords, service.Keywords.Strings...)
+	}
+	if service.SRS != nil {
+		settings.SRS = append(settings.SRS, service.SRS.Strings...)
+	}
+	return settings
+}
+
+// restServiceToWMSSettings converts a restService into WMSSettings
+func restServiceToWMSSettings(service *restService) *WMSSettings {
+	settings := &WMSSettings{
+		ServiceSettings:    *restServiceToServiceSettings(service),
+		MaxRequestMemory:   intValue(service.MaxRequestMemory),
+		MaxRenderingTime:   time.Duration(intValue(service.MaxRenderingTime)) * time.Second,
+		MaxRenderingErrors: intValue(service.MaxRenderingErrors),
+		MaxBuffer:          intValue(service.MaxBuffer),
+	}
+	if service.Watermark != nil {
+		settings.Watermark = &Watermark{
+			Enabled:      service.Watermark.Enabled,
+			Position:     service.Watermark.Position,
+			Transparency: service.Watermark.Transparency,
+			URL:          service.Watermark.URL,
+		}
+	}
+	return settings
+}
+
+// restServiceToWFSSettings converts a restService into WFSSettings
+func restServiceToWFSSettings(service *restService) *WFSSettings {
+	settings := &WFSSettings{
+		ServiceSettings:       *restServiceToServiceSettings(service),
+		MaxFeatures:           intValue(service.MaxFeatures),
+		ServiceLevel:          service.ServiceLevel,
+		FeatureBounding:       boolValue(service.FeatureBounding),
+		HitsIgnoreMaxFeatures: boolValue(service.HitsIgnoreMaxFeatures),
+		GML:                   make(map[GMLVersion]*GMLSettings),
+	}
+	if service.GML != nil {
+		for _, entry := range service.GML.Entries {
+			gml := &GMLSettings{}
+			if entry.GML != nil {
+				gml.OverrideGMLAttributes = entry.GML.OverrideGMLAttributes
+				if len(entry.GML.SRSNameStyle) > 0 {
+					gml.SRSNameStyle = SRSNameStyle(entry.GML.SRSNameStyle[0])
+				}
+			}
+			settings.GML[entry.Version] = gml
+		}
+	}
+	return settings
+}
+
+// restServiceToWCSSettings converts a restService into WCSSettings
+func restServiceToWCSSettings(service *restService) *WCSSettings {
+	return &WCSSettings{
+		ServiceSettings:    *restServiceToServiceSettings(service),
+		MaxInputMemory:     intValue(service.MaxInputMemory),
+		MaxOutputMemory:    intValue(service.MaxOutputMemory),
+		SubsamplingEnabled: boolValue(service.SubsamplingEnabled),
+		LatLon:             boolValue(service.LatLon),
+		GMLPrefixing:       boolValue(service.GMLPrefixing),
+	}
+}
+
+// intValue returns the value of an optional int, or zero when it is not present
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+// boolValue returns the value of an optional bool, or false when it is not present
+func boolValue(value *bool) bool {
+	if value == nil {
+		return false
+	}
+	return *value
+}
@@ -0,0 +1,55 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWFSSettingsAreConvertedFromGeoserversJSON(t *testing.T) {
+	restResponse := &restServiceSettingsWrapper{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"wfs":{"workspace":{"name":"topp"},"enabled":true,"name":"WFS","title":"Roads",`+
+		`"abstrct":"Road network","keywords":{"string":"roads"},"srs":{"string":["EPSG:4326","EPSG:3857"]},"maxFeatures":500,`+
+		`"serviceLevel":"BASIC","featureBounding":true,"gml":{"entry":[{"version":"V_11","gml":{"srsNameStyle":["URN"],`+
+		`"overrideGMLAttributes":false}},{"version":"V_10","gml":{"srsNameStyle":"XML","overrideGMLAttributes":true}}]}}}`), restResponse))
+
+	assert.Equal(t, &WFSSettings{
+		ServiceSettings: ServiceSettings{
+			Workspace: "topp",
+			Enabled:   true,
+			Title:     "Roads",
+			Abstract:  "Road network",
+			Keywords:  []string{"roads"},
+			SRS:       []string{"EPSG:4326", "EPSG:3857"},
+		},
+		MaxFeatures:     500,
+		ServiceLevel:    WFSServiceBasic,
+		FeatureBounding: true,
+		GML: map[GMLVersion]*GMLSettings{
+			GML2: {SRSNameStyle: SRSNameXML, OverrideGMLAttributes: true},
+			GML3: {SRSNameStyle: SRSNameURN},
+		},
+	}, restServiceToWFSSettings(restResponse.WFS))
+}
+
+func TestNewRestWMSServiceEncodesLimitsAndWatermark(t *testing.T) {
+	body, err := json.Marshal(&restServiceSettingsWrapper{WMS: newRestWMSService("topp", &WMSSettings{
+		ServiceSettings:  ServiceSettings{Enabled: true, SRS: []string{"EPSG:4326"}},
+		MaxRequestMemory: 65536,
+		MaxRenderingTime: time.Minute,
+		Watermark:        &Watermark{Enabled: true, Position: WatermarkTopLeft, Transparency: 20, URL: "logo.png"},
+	})})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"wms":{"workspace":{"name":"topp"},"enabled":true,"srs":{"string":["EPSG:4326"]},"verbose":false,"citeCompliant":false,`+
+		`"maxRequestMemory":65536,"maxRenderingTime":60,"maxRenderingErrors":0,"maxBuffer":0,`+
+		`"watermark":{"enabled":true,"position":"TOP_LEFT","transparency":20,"URL":"logo.png"}}}`, string(body))
+}
+
+func TestServiceSettingsURLIsScopedToTheWorkspace(t *testing.T) {
+	client := NewRestGeoserverClient(&recordingLogger{}, nil, "http://localhost/geoserver", "admin", "geoserver")
+
+	assert.Equal(t, "http://localhost/geoserver/rest/services/wcs/settings", client.serviceSettingsURL(ServiceWCS, ""))
+	assert.Equal(t, "http://localhost/geoserver/rest/services/wmts/workspaces/topp/settings", client.serviceSettingsURL(ServiceWMTS, "topp"))
+	assert.Error(t, client.DeleteWMTSSettings(""))
+}
@@ -0,0 +1,427 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ContactInfo is the contact information advertised in the capabilities documents of Geoserver's services
+type ContactInfo struct {
+	// Person is the name of the contact person.
+	Person string
+
+	// Position is the position of the contact person within the organisation.
+	Position string
+
+	// Organization is the name of the organisation.
+	Organization string
+
+	// Email is the email address of the contact.
+	Email string
+
+	// Phone is the telephone number of the contact.
+	Phone string
+
+	// Fax is the fax number of the contact.
+	Fax string
+
+	// AddressType is the type of the address e.g "Work".
+	AddressType string
+
+	// Address is the street address.
+	Address string
+
+	// City is the city of the address.
+	City string
+
+	// State is the state or province of the address.
+	State string
+
+	// PostalCode is the postal code of the address.
+	PostalCode string
+
+	// Country is the country of the address.
+	Country string
+
+	// OnlineResource is the URL of the contact's website.
+	OnlineResource string
+}
+
+// GeoServerSettings are the global settings of Geoserver
+type GeoServerSettings struct {
+	// ProxyBaseURL is the URL Geoserver is reached at through a proxy, used when generating links in responses.
+	ProxyBaseURL string
+
+	// OnlineResource is the URL of the website advertised in capabilities documents.
+	OnlineResource string
+
+	// Contact is the contact information advertised in capabilities documents.
+	Contact *ContactInfo
+
+	// Charset is the character set used in responses e.g "UTF-8".
+	Charset string
+
+	// NumDecimals is the number of decimals used when encoding coordinates.
+	NumDecimals int
+
+	// Verbose determines whether XML responses are indented.
+	Verbose bool
+
+	// VerboseExceptions determines whether service exceptions include stack traces.
+	VerboseExceptions bool
+
+	// GlobalServices determines whether services can be used outside of a workspace.
+	GlobalServices bool
+}
+
+// WorkspaceSettings are the settings of a workspace, which override the global settings for its services
+type WorkspaceSettings struct {
+	// Workspace is the name of the workspace, it is set when settings are retrieved.
+	Workspace string
+
+	// ProxyBaseURL is the URL the workspace is reached at through a proxy, used when generating links in responses.
+	ProxyBaseURL string
+
+	// OnlineResource is the URL of the website advertised in capabilities documents.
+	OnlineResource string
+
+	// Contact is the contact information advertised in capabilities documents.
+	Contact *ContactInfo
+
+	// Charset is the character set used in responses e.g "UTF-8".
+	Charset string
+
+	// NumDecimals is the number of decimals used when encoding coordinates.
+	NumDecimals int
+
+	// Verbose determines whether XML responses are indented.
+	Verbose bool
+
+	// VerboseExceptions determines whether service exceptions include stack traces.
+	VerboseExceptions bool
+
+	// LocalWorkspaceIncludesPrefix determines whether layer names are prefixed with the workspace in virtual services.
+	LocalWorkspaceIncludesPrefix bool
+}
+
+// GetSettings gets the global settings of Geoserver, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetSettings() (settings *GeoServerSettings, err error) {
+	restResponse := &restGlobalSettingsWrapper{}
+	err = client.getRestResource(client.geoserverBaseURL+"/rest/settings", "the global settings", restResponse)
+	if err != nil {
+		return
+	}
+
+	if restResponse.Global == nil || restResponse.Global.Settings == nil {
+		err = fmt.Errorf("unable to get the global settings, Geoserver returned an invalid response")
+		return
+	}
+
+	settings = restGlobalSettingsToGeoServerSettings(restResponse.Global)
+	return
+}
+
+// UpdateSettings replaces the global settings of Geoserver, returning an error if it is not possible.
+// Every field is sent, so the settings should be retrieved with GetSettings and then modified.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UpdateSettings(settings *GeoServerSettings) (err error) {
+	return client.sendRestResource(http.MethodPut, client.geoserverBaseURL+"/rest/settings", "the global settings",
+		&restGlobalSettingsWrapper{Global: newRestGlobalSettings(settings)})
+}
+
+// GetWorkspaceSettings gets the settings of a workspace, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetWorkspaceSettings(workspace string) (settings *WorkspaceSettings, err error) {
+	restResponse := &restSettingsWrapper{}
+	err = client.getRestResource(client.workspaceSettingsURL(workspace), fmt.Sprintf("the settings of workspace '%s'", workspace), restResponse)
+	if err != nil {
+		return
+	}
+
+	if restResponse.Settings == nil {
+		err = fmt.Errorf("unable to get the settings of workspace '%s', Geoserver returned an invalid response", workspace)
+		return
+	}
+
+	settings = restSettingsToWorkspaceSettings(restResponse.Settings)
+	if settings.Workspace == "" {
+		settings.Workspace = workspace
+	}
+	return
+}
+
+// CreateWorkspaceSettings creates settings for a workspace which has none, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) CreateWorkspaceSettings(workspace string, settings *WorkspaceSettings) (err error) {
+	return client.sendRestResource(http.MethodPost, client.workspaceSettingsURL(workspace),
+		fmt.Sprintf("the settings of workspace '%s'", workspace),
+		&restSettingsWrapper{Settings: newRestWorkspaceSettings(workspace, settings)})
+}
+
+// UpdateWorkspaceSettings replaces the settings of a workspace, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) UpdateWorkspaceSettings(workspace string, settings *WorkspaceSettings) (err error) {
+	return client.sendRestResource(http.MethodPut, client.workspaceSettingsURL(workspace),
+		fmt.Sprintf("the settings of workspace '%s'", workspace),
+		&restSettingsWrapper{Settings: newRestWorkspaceSettings(workspace, settings)})
+}
+
+// DeleteWorkspaceSettings deletes the settings of a workspace, so the global settings apply to it again,
+// returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteWorkspaceSettings(workspace string) (err error) {
+	return client.deleteRestResource(client.workspaceSettingsURL(workspace), fmt.Sprintf("the settings of workspace '%s'", workspace))
+}
+
+// workspaceSettingsURL creates the URL of the settings of a workspace
+func (client *RestGeoserverClient) workspaceSettingsURL(workspace string) string {
+	return client.geoserverBaseURL + "/rest/workspaces/" + workspace + "/settings"
+}
+
+// getRestResource gets a resource from Geoserver, unmarshalling its JSON into the result
+func (client *RestGeoserverClient) getRestResource(url string, description string, result interface{}) (err error) {
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for "+description,
+		urlKey, url,
+	)
+
+	statusCode, responseBody, err := client.doJSONRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Unable to get "+description, url, statusCode, responseBody)
+		err = fmt.Errorf("unable to get %s", description)
+		return
+	}
+
+	err = json.Unmarshal(responseBody, result)
+	if err != nil {
+		client.logUnexpectedResponse("Geoserver returned an invalid response", url, statusCode, responseBody)
+	}
+	return
+}
+
+// sendRestResource creates or updates a resource in Geoserver using its JSON representation
+func (client *RestGeoserverClient) sendRestResource(method string, url string, description string, payload interface{}) (err error) {
+	var requestJSONBytes []byte
+	requestJSONBytes, err = json.Marshal(loggablePayload(payload))
+	if err != nil {
+		return
+	}
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Sending "+description+" to Geoserver",
+		urlKey, url,
+		requestKey, string(requestJSONBytes),
+	)
+
+	statusCode, responseBody, err := client.doJSONRequest(method, url, payload)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode || codeCreated == statusCode {
+		client.logger.Log(
+			levelKey, levelDebug,
+			messageKey, "Geoserver accepted "+description,
+			urlKey, url,
+		)
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to send "+description, url, statusCode, responseBody)
+	err = fmt.Errorf("unable to send %s", description)
+	return
+}
+
+// deleteRestResource deletes a resource from Geoserver
+func (client *RestGeoserverClient) deleteRestResource(url string, description string) (err error) {
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Deleting "+description+" from Geoserver",
+		urlKey, url,
+	)
+
+	statusCode, responseBody, err := client.doJSONRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode {
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to delete "+description, url, statusCode, responseBody)
+	err = fmt.Errorf("unable to delete %s", description)
+	return
+}
+
+/**
+ * REST API
+ */
+
+// restGlobalSettingsWrapper exists in order to represent the JSON used by Geoserver for the global settings
+type restGlobalSettingsWrapper struct {
+	Global *restGlobalSettings `json:"global"`
+}
+
+// restGlobalSettings are the global settings of Geoserver, the JAI and coverage access settings are left untouched
+// as Geoserver only copies the properties which are provided
+type restGlobalSettings struct {
+	Settings       *restSettings `json:"settings"`
+	GlobalServices bool          `json:"globalServices"`
+}
+
+// restSettingsWrapper exists in order to represent the JSON used by Geoserver for the settings of a workspace
+type restSettingsWrapper struct {
+	Settings *restSettings `json:"settings"`
+}
+
+// restSettings are the settings shared by Geoserver and its workspaces
+type restSettings struct {
+	Workspace                    *restNamedEntity `json:"workspace,omitempty"`
+	Contact                      *restContact     `json:"contact,omitempty"`
+	Charset                      string           `json:"charset,omitempty"`
+	NumDecimals                  int              `json:"numDecimals"`
+	OnlineResource               string           `json:"onlineResource,omitempty"`
+	ProxyBaseURL                 string           `json:"proxyBaseUrl,omitempty"`
+	Verbose                      bool             `json:"verbose"`
+	VerboseExceptions            bool             `json:"verboseExceptions"`
+	LocalWorkspaceIncludesPrefix *bool            `json:"localWorkspaceIncludesPrefix,omitempty"`
+}
+
+// restContact is the contact information of Geoserver or a workspace
+type restContact struct {
+	Person         string `json:"contactPerson,omitempty"`
+	Position       string `json:"contactPosition,omitempty"`
+	Organization   string `json:"contactOrganization,omitempty"`
+	Email          string `json:"contactEmail,omitempty"`
+	Voice          string `json:"contactVoice,omitempty"`
+	Facsimile      string `json:"contactFacsimile,omitempty"`
+	AddressType    string `json:"addressType,omitempty"`
+	Address        string `json:"address,omitempty"`
+	City           string `json:"addressCity,omitempty"`
+	State          string `json:"addressState,omitempty"`
+	PostalCode     string `json:"addressPostalCode,omitempty"`
+	Country        string `json:"addressCountry,omitempty"`
+	OnlineResource string `json:"onlineResource,omitempty"`
+}
+
+// newRestGlobalSettings converts GeoServerSettings into restGlobalSettings
+func newRestGlobalSettings(settings *GeoServerSettings) *restGlobalSettings {
+	return &restGlobalSettings{
+		Settings: &restSettings{
+			Contact:           newRestContact(settings.Contact),
+			Charset:           settings.Charset,
+			NumDecimals:       settings.NumDecimals,
+			OnlineResource:    settings.OnlineResource,
+			ProxyBaseURL:      settings.ProxyBaseURL,
+			Verbose:           settings.Verbose,
+			VerboseExceptions: settings.VerboseExceptions,
+		},
+		GlobalServices: settings.GlobalServices,
+	}
+}
+
+// newRestWorkspaceSettings converts WorkspaceSettings into restSettings
+func newRestWorkspaceSettings(workspace string, settings *WorkspaceSettings) *restSettings {
+	localWorkspaceIncludesPrefix := settings.LocalWorkspaceIncludesPrefix
+	return &restSettings{
+		Workspace:                    &restNamedEntity{Name: workspace},
+		Contact:                      newRestContact(settings.Contact),
+		Charset:                      settings.Charset,
+		NumDecimals:                  settings.NumDecimals,
+		OnlineResource:               settings.OnlineResource,
+		ProxyBaseURL:                 settings.ProxyBaseURL,
+		Verbose:                      settings.Verbose,
+		VerboseExceptions:            settings.VerboseExceptions,
+		LocalWorkspaceIncludesPrefix: &localWorkspaceIncludesPrefix,
+	}
+}
+
+// newRestContact converts ContactInfo into a restContact
+func newRestContact(contact *ContactInfo) *restContact {
+	if contact == nil {
+		return nil
+	}
+
+	return &restContact{
+		Person:         contact.Person,
+		Position:       contact.Position,
+		Organization:   contact.Organization,
+		Email:          contact.Email,
+		Voice:          contact.Phone,
+		Facsimile:      contact.Fax,
+		AddressType:    contact.AddressType,
+		Address:        contact.Address,
+		City:           contact.City,
+		State:          contact.State,
+		PostalCode:     contact.PostalCode,
+		Country:        contact.Country,
+		OnlineResource: contact.OnlineResource,
+	}
+}
+
+// restGlobalSettingsToGeoServerSettings converts restGlobalSettings into GeoServerSettings
+func restGlobalSettingsToGeoServerSettings(restSettings *restGlobalSettings) *GeoServerSettings {
+	settings := restSettings.Settings
+	return &GeoServerSettings{
+		ProxyBaseURL:      settings.ProxyBaseURL,
+		OnlineResource:    settings.OnlineResource,
+		Contact:           restContactToContactInfo(settings.Contact),
+		Charset:           settings.Charset,
+		NumDecimals:       settings.NumDecimals,
+		Verbose:           settings.Verbose,
+		VerboseExceptions: settings.VerboseExceptions,
+		GlobalServices:    restSettings.GlobalServices,
+	}
+}
+
+// restSettingsToWorkspaceSettings converts restSettings into WorkspaceSettings
+func restSettingsToWorkspaceSettings(restSettings *restSettings) *WorkspaceSettings {
+	settings := &WorkspaceSettings{
+		ProxyBaseURL:      restSettings.ProxyBaseURL,
+		OnlineResource:    restSettings.OnlineResource,
+		Contact:           restContactToContactInfo(restSettings.Contact),
+		Charset:           restSettings.Charset,
+		NumDecimals:       restSettings.NumDecimals,
+		Verbose:           restSettings.Verbose,
+		VerboseExceptions: restSettings.VerboseExceptions,
+	}
+	if restSettings.Workspace != nil {
+		settings.Workspace = restSettings.Workspace.Name
+	}
+	if restSettings.LocalWorkspaceIncludesPrefix != nil {
+		settings.LocalWorkspaceIncludesPrefix = *restSettings.LocalWorkspaceIncludesPrefix
+	}
+	return settings
+}
+
+// restContactToContactInfo converts a restContact into ContactInfo
+func restContactToContactInfo(contact *restContact) *ContactInfo {
+	if contact == nil {
+		return nil
+	}
+
+	return &ContactInfo{
+		Person:         contact.Person,
+		Position:       contact.Position,
+		Organization:   contact.Organization,
+		Email:          contact.Email,
+		Phone:          contact.Voice,
+		Fax:            contact.Facsimile,
+		AddressType:    contact.AddressType,
+		Address:        contact.Address,
+		City:           contact.City,
+		State:          contact.State,
+		PostalCode:     contact.PostalCode,
+		Country:        contact.Country,
+		OnlineResource: contact.OnlineResource,
+	}
+}
@@ -0,0 +1,86 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewRestWorkspaceSettingsEncodesTheWorkspaceAndContact(t *testing.T) {
+	body, err := json.Marshal(&restSettingsWrapper{Settings: newRestWorkspaceSettings("topp", &WorkspaceSettings{
+		Charset:     "UTF-8",
+		NumDecimals: 4,
+		Contact:     &ContactInfo{Person: "Jane Doe", Phone: "555-0100"},
+	})})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"settings":{"workspace":{"name":"topp"},"contact":{"contactPerson":"Jane Doe","contactVoice":"555-0100"},`+
+		`"charset":"UTF-8","numDecimals":4,"verbose":false,"verboseExceptions":false,"localWorkspaceIncludesPrefix":false}}`, string(body))
+}
+
+func TestGlobalSettingsAreConvertedIntoGeoServerSettings(t *testing.T) {
+	restResponse := &restGlobalSettingsWrapper{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"global":{"settings":{"id":"SettingsInfoImpl--1","contact":{"contactOrganization":"GeoServer",`+
+		`"addressCity":"Alexandria"},"charset":"UTF-8","numDecimals":8,"onlineResource":"http://geoserver.org","proxyBaseUrl":"http://proxy",`+
+		`"verbose":false,"verboseExceptions":true},"jai":{"allowInterpolation":false},"globalServices":true,"updateSequence":5}}`), restResponse))
+
+	assert.Equal(t, &GeoServerSettings{
+		ProxyBaseURL:      "http://proxy",
+		OnlineResource:    "http://geoserver.org",
+		Contact:           &ContactInfo{Organization: "GeoServer", City: "Alexandria"},
+		Charset:           "UTF-8",
+		NumDecimals:       8,
+		VerboseExceptions: true,
+		GlobalServices:    true,
+	}, restGlobalSettingsToGeoServerSettings(restResponse.Global))
+}
+
+func TestCreateWorkspaceWithSettingsCreatesBoth(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	err := client.CreateWorkspaceWithSettings(&CreateWorkspaceRequest{"topp"}, &WorkspaceSettings{NumDecimals: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`POST /rest/workspaces.json {"workspace":{"name":"topp"}}`,
+		`POST /rest/workspaces/topp/settings {"settings":{"workspace":{"name":"topp"},"numDecimals":4,"verbose":false,` +
+			`"verboseExceptions":false,"localWorkspaceIncludesPrefix":false}}`,
+	}, requests)
+}
+
+func TestCreateWorkspaceWithSettingsDeletesTheWorkspaceWhenItsSettingsFail(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch r.Method {
+		case http.MethodPost:
+			if r.URL.Path == "/rest/workspaces/topp/settings" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	err := client.CreateWorkspaceWithSettings(&CreateWorkspaceRequest{"topp"}, &WorkspaceSettings{NumDecimals: 4})
+	assert.Error(t, err)
+	assert.Equal(t, []string{
+		"POST /rest/workspaces.json",
+		"POST /rest/workspaces/topp/settings",
+		"DELETE /rest/workspaces/topp?recurse=true",
+	}, requests)
+}
@@ -0,0 +1,219 @@
+package geoserver
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// javaLogicalFonts are the fonts every Java runtime provides, so they are available whether or not Geoserver lists them
+var javaLogicalFonts = []string{"Serif", "SansSerif", "Monospaced", "Dialog", "DialogInput"}
+
+// SLDReferences are the external things an SLD document refers to, in the order they first appear
+type SLDReferences struct {
+	// Fonts are the font families used by text symbolizers.
+	Fonts []string
+
+	// ExternalGraphics are the URLs of the external graphics used by symbolizers.
+	ExternalGraphics []string
+
+	// PropertyNames are the attributes used by filters and expressions, without their namespace prefix.
+	PropertyNames []string
+}
+
+// SLDValidationResult is the result of checking an SLD document against Geoserver before it is uploaded
+type SLDValidationResult struct {
+	// References are everything the SLD document refers to.
+	References *SLDReferences
+
+	// MissingFonts are the fonts which Geoserver does not have.
+	MissingFonts []string
+
+	// UnknownProperties are the property names which are not attributes of the feature type.
+	UnknownProperties []string
+}
+
+// IsValid returns true when every font exists and every property name is an attribute of the feature type
+func (result *SLDValidationResult) IsValid() bool {
+	return len(result.MissingFonts) == 0 && len(result.UnknownProperties) == 0
+}
+
+// ParseSLDReferences parses an SLD document, version 1.0 or 1.1, and returns the fonts, external graphics and
+// property names it refers to, returning an error if the document is not well formed XML.
+func ParseSLDReferences(sld []byte) (references *SLDReferences, err error) {
+	references = &SLDReferences{
+		Fonts:            make([]string, 0),
+		ExternalGraphics: make([]string, 0),
+		PropertyNames:    make([]string, 0),
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(sld))
+	var elements []string
+	var text bytes.Buffer
+	var fontParameter []bool
+	for {
+		token, tokenErr := decoder.Token()
+		if tokenErr == io.EOF {
+			break
+		}
+		if tokenErr != nil {
+			references = nil
+			err = fmt.Errorf("unable to parse SLD: %s", tokenErr)
+			return
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			elements = append(elements, element.Name.Local)
+			switch element.Name.Local {
+			case "CssParameter", "SvgParameter":
+				fontParameter = append(fontParameter, attributeValue(element, "name") == "font-family")
+				text.Reset()
+			case "PropertyName":
+				text.Reset()
+			case "OnlineResource":
+				if len(elements) > 1 && elements[len(elements)-2] == "ExternalGraphic" {
+					references.ExternalGraphics = appendUnique(references.ExternalGraphics, attributeValue(element, "href"))
+				}
+			}
+
+		case xml.CharData:
+			text.Write(element)
+
+		case xml.EndElement:
+			elements = elements[:len(elements)-1]
+			switch element.Name.Local {
+			case "CssParameter", "SvgParameter":
+				if fontParameter[len(fontParameter)-1] {
+					references.Fonts = appendUnique(references.Fonts, strings.TrimSpace(text.String()))
+				}
+				fontParameter = fontParameter[:len(fontParameter)-1]
+			case "PropertyName":
+				references.PropertyNames = appendUnique(references.PropertyNames, unprefixedName(strings.TrimSpace(text.String())))
+				text.Reset()
+			}
+		}
+	}
+	return
+}
+
+// GetFonts gets the names of the fonts available to Geoserver, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetFonts() (fonts []string, err error) {
+	restResponse := &restFonts{}
+	err = client.getRestResource(client.geoserverBaseURL+"/rest/fonts.json", "the fonts", restResponse)
+	if err != nil {
+		return
+	}
+
+	fonts = make([]string, 0)
+	fonts = append(fonts, restResponse.Fonts...)
+	return
+}
+
+// ValidateSLD checks an SLD document before it is uploaded, reporting the fonts Geoserver does not have and,
+// when a feature type is provided, the property names which are not its attributes. External graphics are
+// reported in the references but not checked. An error is returned if the document cannot be parsed or Geoserver
+// cannot be queried.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) ValidateSLD(sld []byte, workspace string, datastore string, featureType string) (result *SLDValidationResult, err error) {
+	references, err := ParseSLDReferences(sld)
+	if err != nil {
+		return
+	}
+
+	result = &SLDValidationResult{
+		References:        references,
+		MissingFonts:      make([]string, 0),
+		UnknownProperties: make([]string, 0),
+	}
+
+	if len(references.Fonts) > 0 {
+		var fonts []string
+		fonts, err = client.GetFonts()
+		if err != nil {
+			result = nil
+			return
+		}
+		// Java looks fonts up without regard to case
+		result.MissingFonts = missingNames(references.Fonts, append(fonts, javaLogicalFonts...), true)
+	}
+
+	if featureType != "" && len(references.PropertyNames) > 0 {
+		var attributes []*Attribute
+		attributes, err = client.GetFeatureTypeAttributes(workspace, datastore, featureType)
+		if err != nil {
+			result = nil
+			return
+		}
+
+		attributeNames := make([]string, 0)
+		for _, attribute := range attributes {
+			attributeNames = append(attributeNames, attribute.Name)
+		}
+		result.UnknownProperties = missingNames(references.PropertyNames, attributeNames, false)
+	}
+	return
+}
+
+// attributeValue returns the value of an attribute of an element, ignoring its namespace
+func attributeValue(element xml.StartElement, name string) string {
+	for _, attribute := range element.Attr {
+		if attribute.Name.Local == name {
+			return attribute.Value
+		}
+	}
+	return ""
+}
+
+// unprefixedName removes the namespace prefix from a name e.g "topp:STATE_NAME" becomes "STATE_NAME"
+func unprefixedName(name string) string {
+	return name[strings.LastIndex(name, ":")+1:]
+}
+
+// appendUnique appends a value to a list when it is not empty and not already in the list
+func appendUnique(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// missingNames returns the names which are not available, optionally comparing them without regard to case
+func missingNames(names []string, available []string, ignoreCase bool) []string {
+	normalise := func(name string) string {
+		if ignoreCase {
+			return strings.ToLower(name)
+		}
+		return name
+	}
+
+	availableNames := make(map[string]bool)
+	for _, name := range available {
+		availableNames[normalise(name)] = true
+	}
+
+	missing := make([]string, 0)
+	for _, name := range names {
+		if !availableNames[normalise(name)] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+/**
+ * REST API
+ */
+
+// restFonts exists in order to represent the JSON returned by Geoserver for its fonts
+type restFonts struct {
+	Fonts []string `json:"fonts"`
+}
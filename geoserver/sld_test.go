@@ -0,0 +1,97 @@
+package geoserver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testSLD = `<?xml version="1.0" encoding="UTF-8"?>
+<StyledLayerDescriptor version="1.0.0" xmlns="http://www.opengis.net/sld" xmlns:ogc="http://www.opengis.net/ogc"
+  xmlns:xlink="http://www.w3.org/1999/xlink">
+  <NamedLayer>
+    <Name>states</Name>
+    <UserStyle>
+      <FeatureTypeStyle>
+        <Rule>
+          <ogc:Filter>
+            <ogc:PropertyIsGreaterThan>
+              <ogc:PropertyName>topp:PERSONS</ogc:PropertyName>
+              <ogc:Literal>4000000</ogc:Literal>
+            </ogc:PropertyIsGreaterThan>
+          </ogc:Filter>
+          <PointSymbolizer>
+            <Graphic>
+              <ExternalGraphic>
+                <OnlineResource xlink:type="simple" xlink:href="http://localhost/icons/capital.png"/>
+                <Format>image/png</Format>
+              </ExternalGraphic>
+            </Graphic>
+          </PointSymbolizer>
+          <TextSymbolizer>
+            <Label><ogc:PropertyName>STATE_ABBR</ogc:PropertyName></Label>
+            <Font>
+              <CssParameter name="font-family">DejaVu Sans</CssParameter>
+              <CssParameter name="font-family">Serif</CssParameter>
+              <CssParameter name="font-size">12</CssParameter>
+            </Font>
+          </TextSymbolizer>
+        </Rule>
+        <Rule>
+          <TextSymbolizer>
+            <Label><ogc:PropertyName>STATE_NAME</ogc:PropertyName></Label>
+            <Font>
+              <CssParameter name="font-family">Comic Sans MS</CssParameter>
+            </Font>
+          </TextSymbolizer>
+        </Rule>
+      </FeatureTypeStyle>
+    </UserStyle>
+  </NamedLayer>
+</StyledLayerDescriptor>`
+
+func TestParseSLDReferences(t *testing.T) {
+	references, err := ParseSLDReferences([]byte(testSLD))
+	assert.NoError(t, err)
+	assert.Equal(t, &SLDReferences{
+		Fonts:            []string{"DejaVu Sans", "Serif", "Comic Sans MS"},
+		ExternalGraphics: []string{"http://localhost/icons/capital.png"},
+		PropertyNames:    []string{"PERSONS", "STATE_ABBR", "STATE_NAME"},
+	}, references)
+
+	_, err = ParseSLDReferences([]byte("<StyledLayerDescriptor>"))
+	assert.Error(t, err)
+}
+
+func TestValidateSLDReportsMissingFontsAndUnknownProperties(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/fonts.json":
+			w.Write([]byte(`{"fonts":["DejaVu Sans","dejavu serif"]}`))
+		case "/rest/workspaces/topp/datastores/states/featuretypes/states.json":
+			w.Write([]byte(`{"featureType":{"name":"states","attributes":{"attribute":[
+				{"name":"the_geom","minOccurs":0,"maxOccurs":1,"nillable":true,"binding":"org.locationtech.jts.geom.MultiPolygon"},
+				{"name":"STATE_NAME","minOccurs":0,"maxOccurs":1,"nillable":true,"binding":"java.lang.String"},
+				{"name":"PERSONS","minOccurs":0,"maxOccurs":1,"nillable":true,"binding":"java.lang.Double"}]}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	result, err := client.ValidateSLD([]byte(testSLD), "topp", "states", "states")
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid())
+	assert.Equal(t, []string{"Comic Sans MS"}, result.MissingFonts)
+	assert.Equal(t, []string{"STATE_ABBR"}, result.UnknownProperties)
+
+	result, err = client.ValidateSLD([]byte(testSLD), "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, result.UnknownProperties)
+
+	_, err = client.ValidateSLD([]byte(testSLD), "topp", "states", "missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,187 @@
+package geoserver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// virtualTableMetadataKey is the feature type metadata key Geoserver uses for SQL view definitions
+const virtualTableMetadataKey = "JDBC_VIRTUAL_TABLE"
+
+// CreateSQLViewFeatureTypeRequest is the information required in order to create a feature type backed by an SQL view.
+// The Name of the feature type is used as the name of the SQL view.
+type CreateSQLViewFeatureTypeRequest struct {
+	CreateFeatureTypeRequest
+
+	// SQLView is the definition of the SQL view.
+	SQLView *SQLView
+}
+
+// SQLView is a parameterised SQL query which Geoserver publishes as if it were a table.
+type SQLView struct {
+	// SQL is the query, parameters are referenced as %name%.
+	SQL string
+
+	// EscapeSQL is true when Geoserver should escape special SQL characters in parameter values.
+	EscapeSQL bool
+
+	// KeyColumns are the columns which uniquely identify a row.
+	KeyColumns []string
+
+	// Geometry is the geometry column of the query.
+	Geometry *SQLViewGeometry
+
+	// Parameters are the named parameters used in the query.
+	Parameters []*SQLViewParameter
+}
+
+// SQLViewGeometry describes the geometry column of an SQL view.
+type SQLViewGeometry struct {
+	// Name is the name of the column.
+	Name string
+
+	// Type is the type of the geometries in the column.
+	Type GeometryType
+
+	// SRID is the spatial reference ID of the geometries e.g 4326.
+	SRID int
+}
+
+// SQLViewParameter is a named parameter of an SQL view, whose value is provided by the viewparams of a request.
+type SQLViewParameter struct {
+	// Name is the name of the parameter, it is referenced in the SQL as %name%.
+	Name string
+
+	// DefaultValue is the value used when a request does not provide one.
+	DefaultValue string
+
+	// ValidationRegex is the regular expression values must match as a whole, protecting against SQL injection.
+	// When there is a default value it is checked against the regex, which must then be supported by Go as well as Java.
+	ValidationRegex string
+}
+
+// validate checks that the SQL view is complete and consistent, returning an error describing the first problem found.
+func (view *SQLView) validate() error {
+	if view == nil {
+		return errors.New("an SQL view is required")
+	}
+
+	if strings.TrimSpace(view.SQL) == "" {
+		return errors.New("the SQL of an SQL view must not be empty")
+	}
+
+	if view.Geometry != nil {
+		if view.Geometry.Name == "" {
+			return errors.New("the geometry column of an SQL view must have a name")
+		}
+		if !view.Geometry.Type.isValid() {
+			return fmt.Errorf("unknown geometry type '%s' for SQL view", view.Geometry.Type)
+		}
+	}
+
+	for _, parameter := range view.Parameters {
+		if parameter.Name == "" {
+			return errors.New("SQL view parameters must have a name")
+		}
+
+		if !strings.Contains(view.SQL, "%"+parameter.Name+"%") {
+			return fmt.Errorf("SQL view parameter '%s' is not referenced in the SQL", parameter.Name)
+		}
+
+		if parameter.ValidationRegex == "" || parameter.DefaultValue == "" {
+			continue
+		}
+
+		// Geoserver uses Java's Matcher.matches, so the regex has to match the whole value
+		regex, err := regexp.Compile("^(?:" + parameter.ValidationRegex + ")$")
+		if err != nil {
+			return fmt.Errorf("unable to check the default value of SQL view parameter '%s': %s", parameter.Name, err)
+		}
+		if !regex.MatchString(parameter.DefaultValue) {
+			return fmt.Errorf("the default value of SQL view parameter '%s' does not match its validation regex", parameter.Name)
+		}
+	}
+
+	return nil
+}
+
+/**
+ * REST API
+ */
+
+// restVirtualTable exists in order to represent the JSON required by Geoserver when defining an SQL view.
+type restVirtualTable struct {
+	Name       string                       `json:"name"`
+	SQL        string                       `json:"sql"`
+	EscapeSQL  bool                         `json:"escapeSql"`
+	KeyColumns []string                     `json:"keyColumn,omitempty"`
+	Geometry   *restVirtualTableGeometry    `json:"geometry,omitempty"`
+	Parameters []*restVirtualTableParameter `json:"parameter,omitempty"`
+}
+
+// restVirtualTableGeometry is the geometry column of an SQL view.
+type restVirtualTableGeometry struct {
+	Name string       `json:"name"`
+	Type GeometryType `json:"type"`
+	SRID int          `json:"srid"`
+}
+
+// restVirtualTableParameter is a named parameter of an SQL view.
+type restVirtualTableParameter struct {
+	Name            string `json:"name"`
+	DefaultValue    string `json:"defaultValue,omitempty"`
+	RegexpValidator string `json:"regexpValidator,omitempty"`
+}
+
+// newCreateSQLViewFeatureTypeRestRequest converts a CreateSQLViewFeatureTypeRequest into a createFeatureTypeRestRequest,
+// returning an error if the SQL view is invalid.
+func newCreateSQLViewFeatureTypeRestRequest(request *CreateSQLViewFeatureTypeRequest) (*createFeatureTypeRestRequest, error) {
+	if err := request.SQLView.validate(); err != nil {
+		return nil, err
+	}
+
+	restRequest := newCreateFeatureTypeRestRequest(&request.CreateFeatureTypeRequest, nil)
+
+	// Geoserver works out the attributes of an SQL view from the query itself
+	restRequest.FeatureType.Attributes = nil
+	restRequest.FeatureType.NativeName = request.Name
+	if restRequest.FeatureType.Metadata == nil {
+		restRequest.FeatureType.Metadata = &restMetadata{}
+	}
+	restRequest.FeatureType.Metadata.Entry = append(restRequest.FeatureType.Metadata.Entry, &restMetadataEntry{
+		Key:          virtualTableMetadataKey,
+		VirtualTable: newRestVirtualTable(request.Name, request.SQLView),
+	})
+
+	return restRequest, nil
+}
+
+// newRestVirtualTable converts an SQLView into a restVirtualTable
+func newRestVirtualTable(name string, view *SQLView) *restVirtualTable {
+	virtualTable := &restVirtualTable{
+		Name:       name,
+		SQL:        view.SQL,
+		EscapeSQL:  view.EscapeSQL,
+		KeyColumns: view.KeyColumns,
+	}
+
+	if view.Geometry != nil {
+		virtualTable.Geometry = &restVirtualTableGeometry{
+			Name: view.Geometry.Name,
+			Type: view.Geometry.Type,
+			SRID: view.Geometry.SRID,
+		}
+	}
+
+	for _, parameter := range view.Parameters {
+		virtualTable.Parameters = append(virtualTable.Parameters, &restVirtualTableParameter{
+			Name:            parameter.Name,
+			DefaultValue:    parameter.DefaultValue,
+			RegexpValidator: parameter.ValidationRegex,
+		})
+	}
+
+	return virtualTable
+}
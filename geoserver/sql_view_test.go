@@ -0,0 +1,58 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSQLViewIsSerialisedAsAVirtualTableMetadataEntry(t *testing.T) {
+	restRequest, err := newCreateSQLViewFeatureTypeRestRequest(&CreateSQLViewFeatureTypeRequest{
+		CreateFeatureTypeRequest: CreateFeatureTypeRequest{
+			Name:      "rivers",
+			Workspace: "hydro",
+			DataStore: "postgis",
+		},
+		SQLView: &SQLView{
+			SQL:        "select * from rivers where flow = %minflow%",
+			KeyColumns: []string{"id"},
+			Geometry:   &SQLViewGeometry{Name: "geom", Type: GeometryTypeMultiLineString, SRID: 4326},
+			Parameters: []*SQLViewParameter{{Name: "minflow", DefaultValue: "10", ValidationRegex: `^\d+$`}},
+		},
+	})
+	assert.NoError(t, err)
+
+	requestJSON, err := json.Marshal(restRequest)
+	assert.NoError(t, err)
+
+	assert.Contains(t, string(requestJSON), `"metadata":{"entry":[{"@key":"JDBC_VIRTUAL_TABLE","virtualTable":{"name":"rivers","sql":"select * from rivers where flow = %minflow%","escapeSql":false,"keyColumn":["id"],"geometry":{"name":"geom","type":"MultiLineString","srid":4326},"parameter":[{"name":"minflow","defaultValue":"10","regexpValidator":"^\\d+$"}]}}]}`)
+	assert.NotContains(t, string(requestJSON), `"attributes"`)
+}
+
+func TestSQLViewValidation(t *testing.T) {
+	invalidViews := map[string]*SQLView{
+		"missing SQL":            {},
+		"unknown geometry type":  {SQL: "select 1", Geometry: &SQLViewGeometry{Name: "geom", Type: "Curve"}},
+		"unnamed geometry":       {SQL: "select 1", Geometry: &SQLViewGeometry{Type: GeometryTypePoint}},
+		"unreferenced parameter": {SQL: "select 1", Parameters: []*SQLViewParameter{{Name: "p"}}},
+		"default fails regex": {
+			SQL:        "select %p%",
+			Parameters: []*SQLViewParameter{{Name: "p", DefaultValue: "abc", ValidationRegex: `^\d+$`}},
+		},
+		"default only partly matches regex": {
+			SQL:        "select %p%",
+			Parameters: []*SQLViewParameter{{Name: "p", DefaultValue: "10;drop", ValidationRegex: `\d+`}},
+		},
+		"regex Go cannot compile": {
+			SQL:        "select %p%",
+			Parameters: []*SQLViewParameter{{Name: "p", DefaultValue: "10", ValidationRegex: `(?!drop)\d+`}},
+		},
+	}
+
+	for description, view := range invalidViews {
+		assert.Error(t, view.validate(), description)
+	}
+
+	assert.NoError(t, (&SQLView{SQL: "select %p%", Parameters: []*SQLViewParameter{{Name: "p", DefaultValue: "1", ValidationRegex: `^\d+$`}}}).validate())
+	assert.NoError(t, (&SQLView{SQL: "select %p%", Parameters: []*SQLViewParameter{{Name: "p", DefaultValue: "10", ValidationRegex: `\d+|all`}}}).validate())
+}
@@ -0,0 +1,315 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrInvalidTemplateScope is returned when a template scope names a feature type without its datastore,
+// or a datastore without its workspace
+var ErrInvalidTemplateScope = errors.New("a template scope needs the workspace of its datastore and the datastore of its feature type")
+
+// templateExtension is the extension of Freemarker templates
+const templateExtension = ".ftl"
+
+// TemplateScope is the level of the catalog a Freemarker template is stored at. The global templates are used when
+// every field is empty, otherwise the templates of the most specific of the workspace, datastore and feature type.
+type TemplateScope struct {
+	// Workspace is the workspace of the templates.
+	Workspace string
+
+	// DataStore is the datastore of the templates, it requires the workspace.
+	DataStore string
+
+	// FeatureType is the feature type of the templates, it requires the datastore.
+	FeatureType string
+}
+
+// ResolvedTemplate is the template which applies to a layer
+type ResolvedTemplate struct {
+	// Scope is the level of the catalog the template was found at.
+	Scope *TemplateScope
+
+	// Name is the name of the template e.g "content.ftl".
+	Name string
+
+	// Content is the Freemarker source of the template.
+	Content string
+}
+
+// ListTemplates lists the names of the templates at a level of the catalog, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) ListTemplates(scope *TemplateScope) (names []string, err error) {
+	templatesURL, err := client.templatesURL(scope)
+	if err != nil {
+		return
+	}
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for templates",
+		urlKey, templatesURL,
+	)
+
+	statusCode, responseBody, err := client.doJSONRequest(http.MethodGet, templatesURL+".json", nil)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Unable to list templates", templatesURL, statusCode, responseBody)
+		err = fmt.Errorf("unable to list templates of %s", scope)
+		return
+	}
+
+	restResponse := &restTemplatesWrapper{}
+	err = json.Unmarshal(responseBody, restResponse)
+	if err != nil {
+		client.logUnexpectedResponse("Geoserver returned an invalid response", templatesURL, statusCode, responseBody)
+		return
+	}
+
+	names = make([]string, 0)
+	for _, template := range restResponse.Templates.Templates {
+		names = append(names, template.Name)
+	}
+	return
+}
+
+// GetTemplate gets the content of a template at a level of the catalog, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) GetTemplate(scope *TemplateScope, name string) (content string, err error) {
+	content, found, err := client.getTemplate(scope, name)
+	if err == nil && !found {
+		err = fmt.Errorf("template '%s' does not exist in %s", name, scope)
+	}
+	return
+}
+
+// PutTemplate creates or replaces a template at a level of the catalog, returning an error if it is not possible.
+// The ".ftl" extension is added to the name when it is missing.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) PutTemplate(scope *TemplateScope, name string, content string) (err error) {
+	templateURL, err := client.templateURL(scope, name)
+	if err != nil {
+		return
+	}
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Putting a Geoserver template",
+		urlKey, templateURL,
+	)
+
+	req, err := client.createAuthRequest(http.MethodPut, templateURL, textPlain, strings.NewReader(content))
+	if err != nil {
+		return
+	}
+
+	statusCode, responseBody, err := client.doRequest(req)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK == statusCode || codeCreated == statusCode {
+		return
+	}
+
+	client.logUnexpectedResponse("Unable to put template", templateURL, statusCode, responseBody)
+	err = fmt.Errorf("unable to put template '%s' in %s", name, scope)
+	return
+}
+
+// DeleteTemplate deletes a template at a level of the catalog, returning an error if it is not possible.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) DeleteTemplate(scope *TemplateScope, name string) (err error) {
+	templateURL, err := client.templateURL(scope, name)
+	if err != nil {
+		return
+	}
+
+	return client.deleteRestResource(templateURL, fmt.Sprintf("template '%s' in %s", name, scope))
+}
+
+// ResolveTemplate finds the template which Geoserver applies to a feature type, looking at the feature type,
+// then its datastore, then its workspace and finally the global templates. It returns nil when none of them
+// have the template, in which case Geoserver uses its built-in template.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) ResolveTemplate(workspace string, dataStore string, featureType string, name string) (template *ResolvedTemplate, err error) {
+	scopes := []*TemplateScope{
+		{Workspace: workspace, DataStore: dataStore, FeatureType: featureType},
+		{Workspace: workspace, DataStore: dataStore},
+		{Workspace: workspace},
+		{},
+	}
+
+	for _, scope := range scopes {
+		content, found, getErr := client.getTemplate(scope, name)
+		if getErr != nil {
+			err = getErr
+			return
+		}
+
+		if found {
+			template = &ResolvedTemplate{Scope: scope, Name: templateFileName(name), Content: content}
+			return
+		}
+	}
+	return
+}
+
+// String describes the level of the catalog
+func (scope *TemplateScope) String() string {
+	switch {
+	case scope == nil || scope.Workspace == "":
+		return "the global templates"
+	case scope.FeatureType != "":
+		return fmt.Sprintf("feature type '%s:%s'", scope.Workspace, scope.FeatureType)
+	case scope.DataStore != "":
+		return fmt.Sprintf("datastore '%s:%s'", scope.Workspace, scope.DataStore)
+	}
+	return fmt.Sprintf("workspace '%s'", scope.Workspace)
+}
+
+// getTemplate gets the content of a template, reporting whether it exists at the level of the catalog
+func (client *RestGeoserverClient) getTemplate(scope *TemplateScope, name string) (content string, found bool, err error) {
+	templateURL, err := client.templateURL(scope, name)
+	if err != nil {
+		return
+	}
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for a template",
+		urlKey, templateURL,
+	)
+
+	req, err := client.createAuthRequest(http.MethodGet, templateURL, textPlain, nil)
+	if err != nil {
+		return
+	}
+
+	statusCode, responseBody, err := client.doRequest(req)
+	if err != nil {
+		return
+	}
+
+	switch statusCode {
+	case httpCodeOK:
+		content = string(responseBody)
+		found = true
+	case httpCodeNotFound:
+	default:
+		client.logUnexpectedResponse("Unable to get template", templateURL, statusCode, responseBody)
+		err = fmt.Errorf("unable to get template '%s' in %s", name, scope)
+	}
+	return
+}
+
+// templatesURL creates the URL of the templates at a level of the catalog
+func (client *RestGeoserverClient) templatesURL(scope *TemplateScope) (templatesURL string, err error) {
+	if scope == nil {
+		scope = &TemplateScope{}
+	}
+
+	if (scope.DataStore != "" && scope.Workspace == "") || (scope.FeatureType != "" && scope.DataStore == "") {
+		err = ErrInvalidTemplateScope
+		return
+	}
+
+	templatesURL = client.geoserverBaseURL + "/rest"
+	if scope.Workspace != "" {
+		templatesURL += "/workspaces/" + scope.Workspace
+	}
+	if scope.DataStore != "" {
+		templatesURL += "/datastores/" + scope.DataStore
+	}
+	if scope.FeatureType != "" {
+		templatesURL += "/featuretypes/" + scope.FeatureType
+	}
+	templatesURL += "/templates"
+	return
+}
+
+// templateURL creates the URL of a template at a level of the catalog, returning an error for names
+// which are empty or contain a path
+func (client *RestGeoserverClient) templateURL(scope *TemplateScope, name string) (templateURL string, err error) {
+	name = templateFileName(name)
+	if name == templateExtension || strings.ContainsAny(name, "/\\") {
+		err = fmt.Errorf("invalid template name '%s'", name)
+		return
+	}
+
+	templatesURL, err := client.templatesURL(scope)
+	if err != nil {
+		return
+	}
+
+	templateURL = templatesURL + "/" + name
+	return
+}
+
+// templateFileName adds the Freemarker extension to a template name when it is missing
+func templateFileName(name string) string {
+	if strings.HasSuffix(name, templateExtension) {
+		return name
+	}
+	return name + templateExtension
+}
+
+/**
+ * REST API
+ */
+
+// restTemplatesWrapper exists in order to represent the JSON returned by Geoserver when listing templates
+type restTemplatesWrapper struct {
+	Templates restTemplateList `json:"templates"`
+}
+
+// restTemplateList is the list of templates, Geoserver likes to return {"templates":""} when there are none
+type restTemplateList struct {
+	Templates restTemplates `json:"template"`
+}
+
+// restTemplates is a list of templates, which Geoserver encodes as a single object when there is only one
+type restTemplates []*restTemplate
+
+// UnmarshalJSON unmarshals the templates of a list, ignoring the empty string used when there are none
+func (list *restTemplateList) UnmarshalJSON(data []byte) (err error) {
+	if strings.HasPrefix(string(data), `"`) {
+		return
+	}
+
+	var templates struct {
+		Templates restTemplates `json:"template"`
+	}
+	err = json.Unmarshal(data, &templates)
+	list.Templates = templates.Templates
+	return
+}
+
+// UnmarshalJSON unmarshals either an array of templates or a single template
+func (templates *restTemplates) UnmarshalJSON(data []byte) (err error) {
+	var many []*restTemplate
+	if json.Unmarshal(data, &many) == nil {
+		*templates = many
+		return
+	}
+
+	one := &restTemplate{}
+	err = json.Unmarshal(data, one)
+	if err != nil {
+		return
+	}
+	*templates = restTemplates{one}
+	return
+}
+
+// restTemplate is a template listed by Geoserver
+type restTemplate struct {
+	Name string `json:"name"`
+	Href string `json:"href"`
+}
@@ -0,0 +1,88 @@
+package geoserver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTemplatesURLFollowsTheCatalogHierarchy(t *testing.T) {
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, "http://localhost/geoserver", "admin", "geoserver")
+
+	for expected, scope := range map[string]*TemplateScope{
+		"http://localhost/geoserver/rest/templates/header.ftl":                                                  nil,
+		"http://localhost/geoserver/rest/workspaces/topp/templates/header.ftl":                                  {Workspace: "topp"},
+		"http://localhost/geoserver/rest/workspaces/topp/datastores/states/templates/header.ftl":                {Workspace: "topp", DataStore: "states"},
+		"http://localhost/geoserver/rest/workspaces/topp/datastores/states/featuretypes/s/templates/header.ftl": {Workspace: "topp", DataStore: "states", FeatureType: "s"},
+	} {
+		templateURL, err := client.templateURL(scope, "header")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, templateURL)
+	}
+
+	_, err := client.templateURL(&TemplateScope{Workspace: "topp", FeatureType: "s"}, "header.ftl")
+	assert.Equal(t, ErrInvalidTemplateScope, err)
+
+	_, err = client.templateURL(nil, "../header.ftl")
+	assert.Error(t, err)
+}
+
+func TestResolveTemplateFallsBackThroughTheHierarchy(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		if r.URL.Path == "/rest/workspaces/topp/templates/content.ftl" {
+			w.Write([]byte("<p>${type.name}</p>"))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	template, err := client.ResolveTemplate("topp", "states", "states", "content")
+	assert.NoError(t, err)
+	assert.Equal(t, &ResolvedTemplate{Scope: &TemplateScope{Workspace: "topp"}, Name: "content.ftl", Content: "<p>${type.name}</p>"}, template)
+	assert.Equal(t, []string{
+		"/rest/workspaces/topp/datastores/states/featuretypes/states/templates/content.ftl",
+		"/rest/workspaces/topp/datastores/states/templates/content.ftl",
+		"/rest/workspaces/topp/templates/content.ftl",
+	}, requested)
+
+	template, err = client.ResolveTemplate("topp", "states", "states", "header.ftl")
+	assert.NoError(t, err)
+	assert.Nil(t, template)
+}
+
+func TestListTemplatesOnlyTreatsTheEmptyStringAsNoTemplates(t *testing.T) {
+	responses := map[string]string{
+		"/rest/templates.json":                 `{"templates":""}`,
+		"/rest/workspaces/topp/templates.json": `{"templates":{"template":{"name":"header.ftl","href":"x"}}}`,
+		"/rest/workspaces/tiger/templates.json": `{"templates":{"template":[{"name":"header.ftl","href":"x"},` +
+			`{"name":"content.ftl","href":"y"}]}}`,
+		"/rest/workspaces/sf/templates.json": `<html><body>Proxy error</body></html>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(responses[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	names, err := client.ListTemplates(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, names)
+
+	names, err = client.ListTemplates(&TemplateScope{Workspace: "topp"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"header.ftl"}, names)
+
+	names, err = client.ListTemplates(&TemplateScope{Workspace: "tiger"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"header.ftl", "content.ftl"}, names)
+
+	_, err = client.ListTemplates(&TemplateScope{Workspace: "sf"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,145 @@
+package geoserver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// ErrUnsupportedByServerVersion is returned when the version of Geoserver does not support the feature requested
+var ErrUnsupportedByServerVersion = errors.New("the feature is not supported by this version of Geoserver")
+
+// serverVersionPattern matches versions such as "2.12.0", "2.12.x", "2.13-SNAPSHOT" and "2.12-RC1"
+var serverVersionPattern = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// versionRetryInterval is how long a failure to detect the version of Geoserver is returned before it is detected again
+const versionRetryInterval = time.Minute
+
+const (
+	// legacyJTSPackage is the package of the JTS geometry classes used before Geoserver 2.14
+	legacyJTSPackage = "com.vividsolutions.jts.geom"
+
+	// locationTechJTSPackage is the package of the JTS geometry classes used from Geoserver 2.14
+	locationTechJTSPackage = "org.locationtech.jts.geom"
+)
+
+// ServerVersion is the version of a Geoserver instance
+type ServerVersion struct {
+	// Major is the major version e.g 2 for "2.12.1".
+	Major int
+
+	// Minor is the minor version e.g 12 for "2.12.1".
+	Minor int
+
+	// Patch is the patch version e.g 1 for "2.12.1", it is zero for snapshots and release candidates.
+	Patch int
+
+	// Raw is the version as reported by Geoserver.
+	Raw string
+}
+
+// ParseServerVersion parses a version reported by Geoserver, returning an error if it is not recognised
+func ParseServerVersion(version string) (serverVersion *ServerVersion, err error) {
+	matches := serverVersionPattern.FindStringSubmatch(version)
+	if matches == nil {
+		err = fmt.Errorf("unable to parse Geoserver version '%s'", version)
+		return
+	}
+
+	serverVersion = &ServerVersion{Raw: version}
+	serverVersion.Major, _ = strconv.Atoi(matches[1])
+	serverVersion.Minor, _ = strconv.Atoi(matches[2])
+	if matches[3] != "" {
+		serverVersion.Patch, _ = strconv.Atoi(matches[3])
+	}
+	return
+}
+
+// AtLeast returns true when the version is the provided major and minor version or later
+func (version *ServerVersion) AtLeast(major int, minor int) bool {
+	if version.Major != major {
+		return version.Major > major
+	}
+	return version.Minor >= minor
+}
+
+// String returns the version as reported by Geoserver
+func (version *ServerVersion) String() string {
+	return version.Raw
+}
+
+// jtsGeometryPackage returns the Java package of the JTS geometry classes, which moved when JTS joined LocationTech.
+// The legacy package is used when the version is not known.
+func (version *ServerVersion) jtsGeometryPackage() string {
+	if version != nil && version.AtLeast(2, 14) {
+		return locationTechJTSPackage
+	}
+	return legacyJTSPackage
+}
+
+// ServerVersion returns the version of Geoserver, detecting it the first time it is needed,
+// returning an error if it is not possible. A failure is returned for a minute before the version is detected again.
+// It interacts with with Geoserver using its REST API.
+func (client *RestGeoserverClient) ServerVersion() (version *ServerVersion, err error) {
+	client.versionMutex.Lock()
+	version, err = client.serverVersion, client.serverVersionErr
+	retry := version == nil && (err == nil || time.Since(client.versionFailedAt) >= versionRetryInterval)
+	client.versionMutex.Unlock()
+	if !retry {
+		return
+	}
+
+	// The lock is not held while Geoserver is asked, so that a slow response does not block every other caller
+	version, err = client.detectServerVersion()
+
+	client.versionMutex.Lock()
+	defer client.versionMutex.Unlock()
+	if err != nil {
+		client.serverVersionErr = err
+		client.versionFailedAt = time.Now()
+		client.logger.Log(
+			levelKey, levelWarn,
+			messageKey, "Unable to detect the Geoserver version",
+			errorKey, err.Error(),
+		)
+		return
+	}
+
+	client.serverVersion, client.serverVersionErr = version, nil
+	return
+}
+
+// detectServerVersion asks Geoserver for its version
+func (client *RestGeoserverClient) detectServerVersion() (version *ServerVersion, err error) {
+	versions, err := client.GetVersion()
+	if err != nil {
+		return
+	}
+
+	version, err = ParseServerVersion(versions.GeoServer.Version)
+	if err != nil {
+		return
+	}
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Detected the Geoserver version",
+		"version", version.String(),
+	)
+	return
+}
+
+// requireServerVersion returns ErrUnsupportedByServerVersion when Geoserver is older than the provided version
+func (client *RestGeoserverClient) requireServerVersion(major int, minor int) (err error) {
+	version, err := client.ServerVersion()
+	if err != nil {
+		return
+	}
+
+	if !version.AtLeast(major, minor) {
+		err = ErrUnsupportedByServerVersion
+	}
+	return
+}
@@ -0,0 +1,111 @@
+package geoserver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseServerVersionAcceptsReleasesSnapshotsAndBranches(t *testing.T) {
+	for raw, expected := range map[string]*ServerVersion{
+		"2.12.1":        {Major: 2, Minor: 12, Patch: 1, Raw: "2.12.1"},
+		"2.13-SNAPSHOT": {Major: 2, Minor: 13, Raw: "2.13-SNAPSHOT"},
+		"2.11.x":        {Major: 2, Minor: 11, Raw: "2.11.x"},
+	} {
+		version, err := ParseServerVersion(raw)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, version)
+	}
+
+	_, err := ParseServerVersion("unknown")
+	assert.Error(t, err)
+}
+
+func TestJTSGeometryPackageDependsOnTheServerVersion(t *testing.T) {
+	var unknown *ServerVersion
+	assert.Equal(t, "com.vividsolutions.jts.geom", unknown.jtsGeometryPackage())
+	assert.Equal(t, "com.vividsolutions.jts.geom", (&ServerVersion{Major: 2, Minor: 13}).jtsGeometryPackage())
+	assert.Equal(t, "org.locationtech.jts.geom", (&ServerVersion{Major: 2, Minor: 14}).jtsGeometryPackage())
+	assert.Equal(t, "org.locationtech.jts.geom", (&ServerVersion{Major: 3, Minor: 0}).jtsGeometryPackage())
+}
+
+func TestServerVersionIsDetectedOnceAndGuardsUnsupportedFeatures(t *testing.T) {
+	versionRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		versionRequests++
+		w.Write([]byte(`{"about":{"resource":[{"@name":"GeoServer","Version":"2.11.4"}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	version, err := client.ServerVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "2.11.4", version.String())
+
+	_, err = client.GetSystemStatus()
+	assert.Equal(t, ErrUnsupportedByServerVersion, err)
+	assert.Equal(t, 1, versionRequests)
+}
+
+func TestCreateFeatureTypeFallsBackToTheLegacyGeometryPackageWhenTheVersionIsUnknown(t *testing.T) {
+	versionRequests := 0
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/about/version.json" {
+			versionRequests++
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(r.Body)
+		body = string(bytes)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	for _, name := range []string{"rivers", "lakes"} {
+		err := client.CreateFeatureType(&CreateFeatureTypeRequest{Name: name, Workspace: "topp", DataStore: "states"})
+		assert.NoError(t, err)
+		assert.Contains(t, body, "com.vividsolutions.jts.geom.Point")
+	}
+	assert.Equal(t, 1, versionRequests)
+}
+
+func TestServerVersionDoesNotBlockOtherCallersWhileGeoserverIsSlow(t *testing.T) {
+	release := make(chan struct{})
+	var versionRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&versionRequests, 1) == 1 {
+			<-release
+		}
+		w.Write([]byte(`{"about":{"resource":[{"@name":"GeoServer","Version":"2.14.0"}]}}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewRestGeoserverClient(&recordingLogger{}, &http.Client{}, server.URL, "admin", "geoserver")
+
+	go client.ServerVersion()
+	for atomic.LoadInt32(&versionRequests) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	detected := make(chan *ServerVersion)
+	go func() {
+		version, _ := client.ServerVersion()
+		detected <- version
+	}()
+
+	select {
+	case version := <-detected:
+		assert.Equal(t, "2.14.0", version.String())
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "ServerVersion was blocked by a slow detection")
+	}
+}
@@ -0,0 +1,437 @@
+package geoserver
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The WKT geometry types supported by filters
+const (
+	wktPoint           = "POINT"
+	wktLineString      = "LINESTRING"
+	wktPolygon         = "POLYGON"
+	wktMultiPoint      = "MULTIPOINT"
+	wktMultiLineString = "MULTILINESTRING"
+	wktMultiPolygon    = "MULTIPOLYGON"
+)
+
+// position is a 2D coordinate
+type position [2]float64
+
+// geometry is a simple 2D geometry, parsed from WKT or GML
+type geometry struct {
+	// kind is the WKT type of the geometry e.g "POINT"
+	kind string
+
+	// positions are the positions of a point or a line string
+	positions []position
+
+	// rings are the rings of a polygon, the first being the exterior
+	rings [][]position
+
+	// members are the geometries of a multi geometry
+	members []*geometry
+}
+
+// parseWKT parses 2D WKT geometries, returning an error if it is not possible
+func parseWKT(wkt string) (*geometry, error) {
+	parser := &wktParser{input: wkt}
+	result, err := parser.parseGeometry()
+	if err != nil {
+		return nil, fmt.Errorf("invalid WKT '%s': %s", wkt, err)
+	}
+
+	parser.skipSpace()
+	if parser.pos != len(parser.input) {
+		return nil, fmt.Errorf("invalid WKT '%s': unexpected trailing characters", wkt)
+	}
+	return result, nil
+}
+
+// String returns the geometry as canonical WKT
+func (g *geometry) String() string {
+	buffer := &bytes.Buffer{}
+	buffer.WriteString(g.kind + " ")
+	g.writeWKTBody(buffer)
+	return buffer.String()
+}
+
+// writeWKTBody writes the parenthesised coordinates of the geometry
+func (g *geometry) writeWKTBody(buffer *bytes.Buffer) {
+	switch g.kind {
+	case wktPoint, wktLineString:
+		writeWKTPositions(buffer, g.positions)
+	case wktPolygon:
+		buffer.WriteString("(")
+		for i, ring := range g.rings {
+			if i > 0 {
+				buffer.WriteString(", ")
+			}
+			writeWKTPositions(buffer, ring)
+		}
+		buffer.WriteString(")")
+	default:
+		buffer.WriteString("(")
+		for i, member := range g.members {
+			if i > 0 {
+				buffer.WriteString(", ")
+			}
+			member.writeWKTBody(buffer)
+		}
+		buffer.WriteString(")")
+	}
+}
+
+// writeWKTPositions writes parenthesised, comma separated positions
+func writeWKTPositions(buffer *bytes.Buffer, positions []position) {
+	buffer.WriteString("(")
+	for i, p := range positions {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString(formatFloat(p[0]) + " " + formatFloat(p[1]))
+	}
+	buffer.WriteString(")")
+}
+
+// writeGML writes the geometry as GML 3, using the gml prefix
+func (g *geometry) writeGML(buffer *bytes.Buffer) {
+	switch g.kind {
+	case wktPoint:
+		buffer.WriteString("<gml:Point><gml:pos>" + formatGMLPositions(g.positions) + "</gml:pos></gml:Point>")
+	case wktLineString:
+		buffer.WriteString("<gml:LineString><gml:posList>" + formatGMLPositions(g.positions) + "</gml:posList></gml:LineString>")
+	case wktPolygon:
+		buffer.WriteString("<gml:Polygon>")
+		for i, ring := range g.rings {
+			boundary := "gml:interior"
+			if i == 0 {
+				boundary = "gml:exterior"
+			}
+			buffer.WriteString("<" + boundary + "><gml:LinearRing><gml:posList>" + formatGMLPositions(ring) + "</gml:posList></gml:LinearRing></" + boundary + ">")
+		}
+		buffer.WriteString("</gml:Polygon>")
+	case wktMultiPoint:
+		writeGMLMembers(buffer, "gml:MultiPoint", "gml:pointMember", g.members)
+	case wktMultiLineString:
+		writeGMLMembers(buffer, "gml:MultiCurve", "gml:curveMember", g.members)
+	case wktMultiPolygon:
+		writeGMLMembers(buffer, "gml:MultiSurface", "gml:surfaceMember", g.members)
+	}
+}
+
+// writeGMLMembers writes the members of a multi geometry
+func writeGMLMembers(buffer *bytes.Buffer, name string, memberName string, members []*geometry) {
+	buffer.WriteString("<" + name + ">")
+	for _, member := range members {
+		buffer.WriteString("<" + memberName + ">")
+		member.writeGML(buffer)
+		buffer.WriteString("</" + memberName + ">")
+	}
+	buffer.WriteString("</" + name + ">")
+}
+
+// formatGMLPositions formats positions as a GML position list
+func formatGMLPositions(positions []position) string {
+	values := make([]string, 0)
+	for _, p := range positions {
+		values = append(values, formatFloat(p[0]), formatFloat(p[1]))
+	}
+	return strings.Join(values, " ")
+}
+
+// parseGML parses a GML 2 or GML 3 geometry, returning an error if it is not possible
+func parseGML(node *filterXMLNode) (*geometry, error) {
+	switch node.XMLName.Local {
+	case "Point":
+		positions, err := parseGMLPositions(node)
+		if err != nil {
+			return nil, err
+		}
+		if len(positions) != 1 {
+			return nil, errors.New("gml:Point must contain a single position")
+		}
+		return &geometry{kind: wktPoint, positions: positions}, nil
+	case "LineString", "LinearRing":
+		positions, err := parseGMLPositions(node)
+		if err != nil {
+			return nil, err
+		}
+		return &geometry{kind: wktLineString, positions: positions}, nil
+	case "Polygon":
+		result := &geometry{kind: wktPolygon}
+		for _, boundary := range node.Children {
+			switch boundary.XMLName.Local {
+			case "exterior", "interior", "outerBoundaryIs", "innerBoundaryIs":
+			default:
+				continue
+			}
+			ring := boundary.child("LinearRing")
+			if ring == nil {
+				return nil, errors.New("gml:Polygon boundary must contain a gml:LinearRing")
+			}
+			positions, err := parseGMLPositions(ring)
+			if err != nil {
+				return nil, err
+			}
+			result.rings = append(result.rings, positions)
+		}
+		return result, nil
+	case "MultiPoint":
+		return parseGMLMembers(node, wktMultiPoint)
+	case "MultiCurve", "MultiLineString":
+		return parseGMLMembers(node, wktMultiLineString)
+	case "MultiSurface", "MultiPolygon":
+		return parseGMLMembers(node, wktMultiPolygon)
+	}
+	return nil, fmt.Errorf("unsupported GML geometry '%s'", node.XMLName.Local)
+}
+
+// parseGMLMembers parses the members of a GML multi geometry
+func parseGMLMembers(node *filterXMLNode, kind string) (*geometry, error) {
+	result := &geometry{kind: kind}
+	for _, member := range node.Children {
+		for _, child := range member.Children {
+			parsed, err := parseGML(child)
+			if err != nil {
+				return nil, err
+			}
+			result.members = append(result.members, parsed)
+		}
+	}
+	return result, nil
+}
+
+// parseGMLPositions parses the positions of a GML geometry from pos, posList or coordinates elements
+func parseGMLPositions(node *filterXMLNode) ([]position, error) {
+	if posList := node.child("posList"); posList != nil {
+		return parseCoordinates(posList.Text)
+	}
+	if coordinates := node.child("coordinates"); coordinates != nil {
+		return parseCoordinates(strings.Replace(coordinates.Text, ",", " ", -1))
+	}
+
+	positions := make([]position, 0)
+	for _, pos := range node.children("pos") {
+		parsed, err := parseCoordinates(pos.Text)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, parsed...)
+	}
+	return positions, nil
+}
+
+// parseCoordinates parses whitespace separated 2D coordinates
+func parseCoordinates(text string) ([]position, error) {
+	fields := strings.Fields(text)
+	if len(fields)%2 != 0 {
+		return nil, errors.New("only 2D coordinates are supported")
+	}
+
+	positions := make([]position, 0)
+	for i := 0; i < len(fields); i += 2 {
+		x, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return nil, err
+		}
+		y, err := strconv.ParseFloat(fields[i+1], 64)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, position{x, y})
+	}
+	return positions, nil
+}
+
+// wktParser is a recursive descent parser for WKT
+type wktParser struct {
+	input string
+	pos   int
+}
+
+// skipSpace skips any whitespace
+func (parser *wktParser) skipSpace() {
+	for parser.pos < len(parser.input) && strings.ContainsRune(" \t\r\n", rune(parser.input[parser.pos])) {
+		parser.pos++
+	}
+}
+
+// accept consumes the character if it is next, returning true if it was
+func (parser *wktParser) accept(character byte) bool {
+	parser.skipSpace()
+	if parser.pos < len(parser.input) && parser.input[parser.pos] == character {
+		parser.pos++
+		return true
+	}
+	return false
+}
+
+// expect consumes the character, returning an error if it is not next
+func (parser *wktParser) expect(character byte) error {
+	if !parser.accept(character) {
+		return fmt.Errorf("expected '%c' at position %d", character, parser.pos)
+	}
+	return nil
+}
+
+// parseGeometry parses a tagged WKT geometry
+func (parser *wktParser) parseGeometry() (*geometry, error) {
+	parser.skipSpace()
+	start := parser.pos
+	for parser.pos < len(parser.input) && isASCIILetter(parser.input[parser.pos]) {
+		parser.pos++
+	}
+	kind := strings.ToUpper(parser.input[start:parser.pos])
+
+	switch kind {
+	case wktPoint:
+		positions, err := parser.parsePositions()
+		if err != nil {
+			return nil, err
+		}
+		if len(positions) != 1 {
+			return nil, errors.New("POINT must contain a single position")
+		}
+		return &geometry{kind: kind, positions: positions}, nil
+	case wktLineString:
+		positions, err := parser.parsePositions()
+		if err != nil {
+			return nil, err
+		}
+		return &geometry{kind: kind, positions: positions}, nil
+	case wktPolygon:
+		rings, err := parser.parseRings()
+		if err != nil {
+			return nil, err
+		}
+		return &geometry{kind: kind, rings: rings}, nil
+	case wktMultiPoint:
+		return parser.parseMultiPoint()
+	case wktMultiLineString:
+		rings, err := parser.parseRings()
+		if err != nil {
+			return nil, err
+		}
+		result := &geometry{kind: kind}
+		for _, positions := range rings {
+			result.members = append(result.members, &geometry{kind: wktLineString, positions: positions})
+		}
+		return result, nil
+	case wktMultiPolygon:
+		result := &geometry{kind: kind}
+		if err := parser.expect('('); err != nil {
+			return nil, err
+		}
+		for {
+			rings, err := parser.parseRings()
+			if err != nil {
+				return nil, err
+			}
+			result.members = append(result.members, &geometry{kind: wktPolygon, rings: rings})
+			if !parser.accept(',') {
+				break
+			}
+		}
+		return result, parser.expect(')')
+	}
+
+	return nil, fmt.Errorf("unsupported geometry type '%s'", kind)
+}
+
+// parseMultiPoint parses the body of a MULTIPOINT, with or without parenthesised points
+func (parser *wktParser) parseMultiPoint() (*geometry, error) {
+	result := &geometry{kind: wktMultiPoint}
+	if err := parser.expect('('); err != nil {
+		return nil, err
+	}
+	for {
+		parenthesised := parser.accept('(')
+		p, err := parser.parsePosition()
+		if err != nil {
+			return nil, err
+		}
+		if parenthesised {
+			if err := parser.expect(')'); err != nil {
+				return nil, err
+			}
+		}
+		result.members = append(result.members, &geometry{kind: wktPoint, positions: []position{p}})
+		if !parser.accept(',') {
+			break
+		}
+	}
+	return result, parser.expect(')')
+}
+
+// parseRings parses a parenthesised list of position lists
+func (parser *wktParser) parseRings() ([][]position, error) {
+	if err := parser.expect('('); err != nil {
+		return nil, err
+	}
+	rings := make([][]position, 0)
+	for {
+		positions, err := parser.parsePositions()
+		if err != nil {
+			return nil, err
+		}
+		rings = append(rings, positions)
+		if !parser.accept(',') {
+			break
+		}
+	}
+	return rings, parser.expect(')')
+}
+
+// parsePositions parses a parenthesised list of positions
+func (parser *wktParser) parsePositions() ([]position, error) {
+	if err := parser.expect('('); err != nil {
+		return nil, err
+	}
+	positions := make([]position, 0)
+	for {
+		p, err := parser.parsePosition()
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, p)
+		if !parser.accept(',') {
+			break
+		}
+	}
+	return positions, parser.expect(')')
+}
+
+// parsePosition parses a 2D position
+func (parser *wktParser) parsePosition() (position, error) {
+	x, err := parser.parseNumber()
+	if err != nil {
+		return position{}, err
+	}
+	y, err := parser.parseNumber()
+	if err != nil {
+		return position{}, err
+	}
+	return position{x, y}, nil
+}
+
+// parseNumber parses a number
+func (parser *wktParser) parseNumber() (float64, error) {
+	parser.skipSpace()
+	start := parser.pos
+	for parser.pos < len(parser.input) && strings.ContainsRune("0123456789.-+eE", rune(parser.input[parser.pos])) {
+		parser.pos++
+	}
+	if start == parser.pos {
+		return 0, fmt.Errorf("expected a number at position %d", start)
+	}
+	return strconv.ParseFloat(parser.input[start:parser.pos], 64)
+}
+
+// isASCIILetter returns true if the character is an ASCII letter
+func isASCIILetter(character byte) bool {
+	return (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z')
+}
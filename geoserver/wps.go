@@ -0,0 +1,1025 @@
+package geoserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MimeTypeGeoJSON is the mime type used for GeoJSON complex data
+	MimeTypeGeoJSON = "application/json"
+
+	// MimeTypeWKT is the mime type used for WKT complex data
+	MimeTypeWKT = "application/wkt"
+
+	// MimeTypeGML3 is the mime type used for GML 3 complex data
+	MimeTypeGML3 = "text/xml; subtype=gml/3.1.1"
+
+	// UnboundedOccurs is the value of MaxOccurs when a process parameter can occur any number of times
+	UnboundedOccurs = -1
+)
+
+// ProcessParameterKind is the kind of data accepted or produced by a process parameter
+type ProcessParameterKind string
+
+const (
+	// ProcessParameterLiteral is a parameter with a literal value, such as a number or a string
+	ProcessParameterLiteral ProcessParameterKind = "literal"
+
+	// ProcessParameterComplex is a parameter with a complex value, such as a geometry or a feature collection
+	ProcessParameterComplex ProcessParameterKind = "complex"
+
+	// ProcessParameterBoundingBox is a parameter with a bounding box value
+	ProcessParameterBoundingBox ProcessParameterKind = "boundingBox"
+)
+
+// ExecutionStatus is the status of a WPS process execution
+type ExecutionStatus string
+
+const (
+	// ExecutionAccepted is the status of an execution which has been queued
+	ExecutionAccepted ExecutionStatus = "ProcessAccepted"
+
+	// ExecutionStarted is the status of an execution which is running
+	ExecutionStarted ExecutionStatus = "ProcessStarted"
+
+	// ExecutionPaused is the status of an execution which has been paused
+	ExecutionPaused ExecutionStatus = "ProcessPaused"
+
+	// ExecutionSucceeded is the status of an execution which has completed successfully
+	ExecutionSucceeded ExecutionStatus = "ProcessSucceeded"
+
+	// ExecutionFailed is the status of an execution which has failed or been dismissed
+	ExecutionFailed ExecutionStatus = "ProcessFailed"
+)
+
+// ProcessSummary is the summary of a WPS process offered by Geoserver
+type ProcessSummary struct {
+	// Identifier is the identifier of the process e.g "JTS:buffer"
+	Identifier string
+
+	// Title is the title of the process
+	Title string
+
+	// Abstract is the description of the process
+	Abstract string
+
+	// Version is the version of the process
+	Version string
+}
+
+// GetProcessesResponse is the response to getting the WPS processes
+type GetProcessesResponse struct {
+	Processes []*ProcessSummary
+}
+
+// ProcessDescription is the full description of a WPS process, including its inputs and outputs
+type ProcessDescription struct {
+	// Identifier is the identifier of the process e.g "JTS:buffer"
+	Identifier string
+
+	// Title is the title of the process
+	Title string
+
+	// Abstract is the description of the process
+	Abstract string
+
+	// StatusSupported is true when the process can report its status during an asynchronous execution
+	StatusSupported bool
+
+	// StoreSupported is true when the process can store its response for an asynchronous execution
+	StoreSupported bool
+
+	// Inputs are the inputs accepted by the process
+	Inputs []*ProcessParameter
+
+	// Outputs are the outputs produced by the process
+	Outputs []*ProcessParameter
+}
+
+// ProcessParameter describes an input or an output of a WPS process
+type ProcessParameter struct {
+	// Identifier is the identifier of the parameter
+	Identifier string
+
+	// Title is the title of the parameter
+	Title string
+
+	// Abstract is the description of the parameter
+	Abstract string
+
+	// MinOccurs is the minimum number of times the parameter must be provided
+	MinOccurs int
+
+	// MaxOccurs is the maximum number of times the parameter can be provided, it is UnboundedOccurs when there is no limit
+	MaxOccurs int
+
+	// Kind is the kind of data the parameter holds
+	Kind ProcessParameterKind
+
+	// DataType is the data type of a literal parameter e.g "xs:double"
+	DataType string
+
+	// AllowedValues are the values a literal parameter is restricted to, it is empty when any value is allowed
+	AllowedValues []string
+
+	// DefaultValue is the default value of a literal parameter
+	DefaultValue string
+
+	// DefaultMimeType is the default mime type of a complex parameter
+	DefaultMimeType string
+
+	// MimeTypes are all of the mime types supported by a complex parameter
+	MimeTypes []string
+}
+
+// ExecuteRequest is the information required in order to execute a WPS process
+type ExecuteRequest struct {
+	// Process is the identifier of the process to execute e.g "JTS:buffer"
+	Process string
+
+	// Inputs are the inputs to the process
+	Inputs []*ProcessInput
+
+	// Output is the output to return, when not provided all outputs are returned in the response document
+	Output *ProcessOutputRequest
+
+	// Async is true when the process should be executed asynchronously, its status can then be polled
+	Async bool
+}
+
+// ProcessInput is an input to a WPS process, only one of Literal, Complex or Reference should be provided
+type ProcessInput struct {
+	// Identifier is the identifier of the input
+	Identifier string
+
+	// Literal is the value of a literal input
+	Literal string
+
+	// Complex is the value of a complex input
+	Complex *ComplexData
+
+	// Reference is a reference to where Geoserver can find the value of the input
+	Reference *InputReference
+}
+
+// ComplexData is complex data, such as GeoJSON or WKT, passed to or returned from a WPS process
+type ComplexData struct {
+	// MimeType is the mime type of the data
+	MimeType string
+
+	// Value is the data itself
+	Value string
+}
+
+// InputReference is a reference to the value of an input, which Geoserver resolves when executing the process
+type InputReference struct {
+	// Href is the URL of the value
+	Href string
+
+	// MimeType is the mime type of the value
+	MimeType string
+
+	// Method is the HTTP method used to resolve the value, GET is used when not provided
+	Method string
+
+	// Body is the body of the request used to resolve the value, it is only used with the POST method
+	Body string
+}
+
+// ProcessOutputRequest describes the output required from a WPS process
+type ProcessOutputRequest struct {
+	// Identifier is the identifier of the output
+	Identifier string
+
+	// MimeType is the mime type the output should be encoded with
+	MimeType string
+
+	// AsReference is true when Geoserver should store the output and return a reference to it
+	AsReference bool
+}
+
+// ExecuteResponse is the response to executing a WPS process, or to querying the status of an execution
+type ExecuteResponse struct {
+	// ExecutionID is the identifier of an asynchronous execution
+	ExecutionID string
+
+	// StatusLocation is the URL where the status of an asynchronous execution can be found
+	StatusLocation string
+
+	// Status is the status of the execution
+	Status ExecutionStatus
+
+	// PercentCompleted is the progress of a running execution
+	PercentCompleted int
+
+	// StatusMessage is the message accompanying the status
+	StatusMessage string
+
+	// FailureMessage is the reason the execution failed
+	FailureMessage string
+
+	// Outputs are the outputs of a successful execution
+	Outputs []*ProcessOutput
+
+	// RawOutput is the output of a synchronous execution requesting a single output not returned by reference
+	RawOutput []byte
+
+	// RawOutputMimeType is the mime type of RawOutput
+	RawOutputMimeType string
+}
+
+// IsComplete returns true when the execution has either succeeded or failed
+func (response *ExecuteResponse) IsComplete() bool {
+	return response.Status == ExecutionSucceeded || response.Status == ExecutionFailed
+}
+
+// ProcessOutput is an output of a WPS process
+type ProcessOutput struct {
+	// Identifier is the identifier of the output
+	Identifier string
+
+	// Title is the title of the output
+	Title string
+
+	// Literal is the value of a literal output
+	Literal string
+
+	// Complex is the value of a complex output
+	Complex *ComplexData
+
+	// Reference is the URL of an output returned by reference
+	Reference string
+}
+
+// NewLiteralInput creates a new ProcessInput with a literal value
+func NewLiteralInput(identifier string, value string) *ProcessInput {
+	return &ProcessInput{
+		Identifier: identifier,
+		Literal:    value,
+	}
+}
+
+// NewComplexInput creates a new ProcessInput with a complex value e.g GeoJSON or WKT
+func NewComplexInput(identifier string, mimeType string, value string) *ProcessInput {
+	return &ProcessInput{
+		Identifier: identifier,
+		Complex: &ComplexData{
+			MimeType: mimeType,
+			Value:    value,
+		},
+	}
+}
+
+// NewReferenceInput creates a new ProcessInput whose value is resolved by Geoserver from the provided URL
+func NewReferenceInput(identifier string, mimeType string, href string) *ProcessInput {
+	return &ProcessInput{
+		Identifier: identifier,
+		Reference: &InputReference{
+			Href:     href,
+			MimeType: mimeType,
+		},
+	}
+}
+
+// GetProcesses gets the WPS processes offered by Geoserver, returning an error if it is not possible.
+// It interacts with Geoserver using its WPS API.
+func (client *RestGeoserverClient) GetProcesses() (response *GetProcessesResponse, err error) {
+	url := client.wpsURL("GetCapabilities")
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for WPS processes",
+		urlKey, url,
+	)
+
+	capabilities := &wpsCapabilities{}
+	err = client.doWPSRequest(http.MethodGet, url, nil, capabilities)
+	if err != nil {
+		return
+	}
+
+	response = &GetProcessesResponse{
+		Processes: make([]*ProcessSummary, 0),
+	}
+	for _, process := range capabilities.Processes {
+		response.Processes = append(response.Processes, process.toProcessSummary())
+	}
+	return
+}
+
+// DescribeProcess gets the full description of a WPS process, returning an error if it is not possible.
+// It interacts with Geoserver using its WPS API.
+func (client *RestGeoserverClient) DescribeProcess(process string) (description *ProcessDescription, err error) {
+	url := client.wpsURL("DescribeProcess") + "&identifier=" + queryEscape(process)
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for WPS process description",
+		urlKey, url,
+		"process", process,
+	)
+
+	descriptions := &wpsProcessDescriptions{}
+	err = client.doWPSRequest(http.MethodGet, url, nil, descriptions)
+	if err != nil {
+		return
+	}
+
+	if len(descriptions.Descriptions) == 0 {
+		err = fmt.Errorf("geoserver did not describe WPS process '%s'", process)
+		return
+	}
+
+	description = descriptions.Descriptions[0].toProcessDescription()
+	return
+}
+
+// Execute executes a WPS process, returning an error if it is not possible.
+// Asynchronous executions return immediately, their status can be polled using GetExecutionStatus or WaitForExecution.
+// It interacts with Geoserver using its WPS API.
+func (client *RestGeoserverClient) Execute(request *ExecuteRequest) (response *ExecuteResponse, err error) {
+	url := client.geoserverBaseURL + "/ows?service=WPS"
+
+	var requestXMLBytes []byte
+	requestXMLBytes, err = xml.Marshal(newWPSExecute(request))
+	if err != nil {
+		return
+	}
+
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Executing a WPS process",
+		urlKey, url,
+		"process", request.Process,
+		"async", fmt.Sprintf("%t", request.Async),
+	)
+
+	req, err := client.createAuthRequest(http.MethodPost, url, applicationXML, bytes.NewReader(requestXMLBytes))
+	if err != nil {
+		return
+	}
+	req.Header.Del(acceptHeader)
+
+	statusCode, responseBody, err := client.doRequest(req)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Unable to execute WPS process", url, statusCode, responseBody)
+		err = fmt.Errorf("unable to execute WPS process '%s'", request.Process)
+		return
+	}
+
+	if err = wpsExceptionFromResponse(responseBody); err != nil {
+		client.logUnexpectedResponse("Geoserver reported an error executing WPS process", url, statusCode, responseBody)
+		return
+	}
+
+	if request.isRawOutput() {
+		response = &ExecuteResponse{
+			Status:            ExecutionSucceeded,
+			RawOutput:         responseBody,
+			RawOutputMimeType: request.Output.MimeType,
+		}
+		return
+	}
+
+	response, err = parseExecuteResponse(responseBody)
+	if err != nil {
+		return
+	}
+
+	if response.Status == ExecutionFailed {
+		err = fmt.Errorf("WPS process '%s' failed: %s", request.Process, response.FailureMessage)
+	}
+	return
+}
+
+// GetExecutionStatus gets the status of an asynchronous WPS process execution, returning an error if it is not possible.
+// It interacts with Geoserver using its WPS API.
+func (client *RestGeoserverClient) GetExecutionStatus(executionID string) (response *ExecuteResponse, err error) {
+	url := client.wpsURL("GetExecutionStatus") + "&executionId=" + queryEscape(executionID)
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Querying Geoserver for WPS execution status",
+		urlKey, url,
+		"executionId", executionID,
+	)
+
+	return client.getExecuteResponse(url, executionID)
+}
+
+// WaitForExecution polls the status of an asynchronous WPS process execution until it completes or the context is done.
+// An error is returned if the execution fails.
+func (client *RestGeoserverClient) WaitForExecution(ctx context.Context, executionID string, pollInterval time.Duration) (response *ExecuteResponse, err error) {
+	for {
+		response, err = client.GetExecutionStatus(executionID)
+		if err != nil {
+			return
+		}
+
+		if response.Status == ExecutionFailed {
+			err = fmt.Errorf("WPS execution '%s' failed: %s", executionID, response.FailureMessage)
+			return
+		}
+
+		if response.IsComplete() {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// Dismiss cancels an asynchronous WPS process execution, returning an error if it is not possible.
+// It interacts with Geoserver using its WPS API.
+func (client *RestGeoserverClient) Dismiss(executionID string) (err error) {
+	url := client.wpsURL("Dismiss") + "&executionId=" + queryEscape(executionID)
+	client.logger.Log(
+		levelKey, levelDebug,
+		messageKey, "Dismissing WPS execution",
+		urlKey, url,
+		"executionId", executionID,
+	)
+
+	_, err = client.getExecuteResponse(url, executionID)
+	return
+}
+
+// wpsURL creates the URL for a WPS operation using a HTTP GET
+func (client *RestGeoserverClient) wpsURL(operation string) string {
+	return client.geoserverBaseURL + "/ows?service=WPS&version=" + wpsVersion + "&request=" + operation
+}
+
+// getExecuteResponse gets an execute response document from Geoserver
+func (client *RestGeoserverClient) getExecuteResponse(url string, executionID string) (response *ExecuteResponse, err error) {
+	req, err := client.createAuthRequest(http.MethodGet, url, applicationXML, nil)
+	if err != nil {
+		return
+	}
+
+	statusCode, responseBody, err := client.doRequest(req)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Unable to query WPS execution", url, statusCode, responseBody)
+		err = fmt.Errorf("unable to query WPS execution '%s'", executionID)
+		return
+	}
+
+	if err = wpsExceptionFromResponse(responseBody); err != nil {
+		client.logUnexpectedResponse("Geoserver reported an error for WPS execution", url, statusCode, responseBody)
+		return
+	}
+
+	response, err = parseExecuteResponse(responseBody)
+	if err == nil && response.ExecutionID == "" {
+		response.ExecutionID = executionID
+	}
+	return
+}
+
+// doWPSRequest sends a WPS request to Geoserver and unmarshals the XML response into result
+func (client *RestGeoserverClient) doWPSRequest(method string, url string, body []byte, result interface{}) (err error) {
+	req, err := client.createAuthRequest(method, url, applicationXML, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	statusCode, responseBody, err := client.doRequest(req)
+	if err != nil {
+		return
+	}
+
+	if httpCodeOK != statusCode {
+		client.logUnexpectedResponse("Geoserver returned a non-200 HTTP status code for WPS request", url, statusCode, responseBody)
+		err = fmt.Errorf("unable to perform WPS request, Geoserver responded with HTTP %d", statusCode)
+		return
+	}
+
+	if err = wpsExceptionFromResponse(responseBody); err != nil {
+		client.logUnexpectedResponse("Geoserver reported an error for WPS request", url, statusCode, responseBody)
+		return
+	}
+
+	err = xml.Unmarshal(responseBody, result)
+	if err != nil {
+		client.logUnexpectedResponse("Geoserver returned an invalid WPS response", url, statusCode, responseBody)
+	}
+	return
+}
+
+// queryEscape escapes a value for use in a URL query string
+func queryEscape(value string) string {
+	return url.QueryEscape(value)
+}
+
+// pathEscape escapes a value for use as a single segment of a URL path, like url.PathEscape which is not available
+// before Go 1.8
+func pathEscape(value string) string {
+	return strings.Replace(url.QueryEscape(value), "+", "%20", -1)
+}
+
+/**
+ * WPS API
+ */
+
+const (
+	// wpsVersion is the version of WPS used to communicate with Geoserver
+	wpsVersion = "1.0.0"
+
+	// wpsNamespace is the XML namespace of WPS 1.0.0
+	wpsNamespace = "http://www.opengis.net/wps/1.0.0"
+
+	// owsNamespace is the XML namespace of OWS 1.1
+	owsNamespace = "http://www.opengis.net/ows/1.1"
+
+	// xlinkNamespace is the XML namespace of XLink
+	xlinkNamespace = "http://www.w3.org/1999/xlink"
+)
+
+// wpsExecute exists in order to represent the XML required by Geoserver when executing a process
+type wpsExecute struct {
+	XMLName        xml.Name         `xml:"wps:Execute"`
+	Service        string           `xml:"service,attr"`
+	Version        string           `xml:"version,attr"`
+	WPSNamespace   string           `xml:"xmlns:wps,attr"`
+	OWSNamespace   string           `xml:"xmlns:ows,attr"`
+	XLinkNamespace string           `xml:"xmlns:xlink,attr"`
+	Identifier     string           `xml:"ows:Identifier"`
+	DataInputs     *wpsDataInputs   `xml:"wps:DataInputs,omitempty"`
+	ResponseForm   *wpsResponseForm `xml:"wps:ResponseForm,omitempty"`
+}
+
+// wpsDataInputs is a collection of inputs to a process
+type wpsDataInputs struct {
+	Inputs []*wpsInput `xml:"wps:Input"`
+}
+
+// wpsInput is an input to a process, either provided by value or by reference
+type wpsInput struct {
+	Identifier string        `xml:"ows:Identifier"`
+	Data       *wpsData      `xml:"wps:Data,omitempty"`
+	Reference  *wpsReference `xml:"wps:Reference,omitempty"`
+}
+
+// wpsData is the value of an input
+type wpsData struct {
+	LiteralData *wpsLiteralData `xml:"wps:LiteralData,omitempty"`
+	ComplexData *wpsComplexData `xml:"wps:ComplexData,omitempty"`
+}
+
+// wpsLiteralData is the value of a literal input
+type wpsLiteralData struct {
+	Value string `xml:",chardata"`
+}
+
+// wpsComplexData is the value of a complex input, wrapped in CDATA so that it does not need escaping
+type wpsComplexData struct {
+	MimeType string `xml:"mimeType,attr,omitempty"`
+	Value    string `xml:",cdata"`
+}
+
+// wpsReference is a reference to the value of an input
+type wpsReference struct {
+	MimeType string   `xml:"mimeType,attr,omitempty"`
+	Href     string   `xml:"xlink:href,attr"`
+	Method   string   `xml:"method,attr,omitempty"`
+	Body     *wpsBody `xml:"wps:Body,omitempty"`
+}
+
+// wpsBody is the body of the request used to resolve a reference
+type wpsBody struct {
+	Value string `xml:",cdata"`
+}
+
+// wpsResponseForm describes the form of the response required from an execution
+type wpsResponseForm struct {
+	RawDataOutput    *wpsOutputDefinition `xml:"wps:RawDataOutput,omitempty"`
+	ResponseDocument *wpsResponseDocument `xml:"wps:ResponseDocument,omitempty"`
+}
+
+// wpsResponseDocument requests that the outputs of an execution are wrapped in a response document
+type wpsResponseDocument struct {
+	StoreExecuteResponse bool                   `xml:"storeExecuteResponse,attr"`
+	Status               bool                   `xml:"status,attr"`
+	Outputs              []*wpsOutputDefinition `xml:"wps:Output"`
+}
+
+// wpsOutputDefinition describes an output required from an execution
+type wpsOutputDefinition struct {
+	MimeType    string `xml:"mimeType,attr,omitempty"`
+	AsReference bool   `xml:"asReference,attr,omitempty"`
+	Identifier  string `xml:"ows:Identifier"`
+}
+
+// isRawOutput returns true when the request is for a single output returned directly in the response body
+func (request *ExecuteRequest) isRawOutput() bool {
+	return !request.Async && request.Output != nil && !request.Output.AsReference
+}
+
+// newWPSExecute converts an ExecuteRequest into the XML representation required by Geoserver
+func newWPSExecute(request *ExecuteRequest) *wpsExecute {
+	execute := &wpsExecute{
+		Service:        "WPS",
+		Version:        wpsVersion,
+		WPSNamespace:   wpsNamespace,
+		OWSNamespace:   owsNamespace,
+		XLinkNamespace: xlinkNamespace,
+		Identifier:     request.Process,
+		ResponseForm:   &wpsResponseForm{},
+	}
+
+	if len(request.Inputs) > 0 {
+		execute.DataInputs = &wpsDataInputs{}
+		for _, input := range request.Inputs {
+			execute.DataInputs.Inputs = append(execute.DataInputs.Inputs, newWPSInput(input))
+		}
+	}
+
+	var output *wpsOutputDefinition
+	if request.Output != nil {
+		output = &wpsOutputDefinition{
+			MimeType:    request.Output.MimeType,
+			AsReference: request.Output.AsReference,
+			Identifier:  request.Output.Identifier,
+		}
+	}
+
+	if request.isRawOutput() {
+		execute.ResponseForm.RawDataOutput = output
+		return execute
+	}
+
+	execute.ResponseForm.ResponseDocument = &wpsResponseDocument{
+		StoreExecuteResponse: request.Async,
+		Status:               request.Async,
+	}
+	if output != nil {
+		execute.ResponseForm.ResponseDocument.Outputs = []*wpsOutputDefinition{output}
+	}
+
+	return execute
+}
+
+// newWPSInput converts a ProcessInput into its XML representation
+func newWPSInput(input *ProcessInput) *wpsInput {
+	result := &wpsInput{
+		Identifier: input.Identifier,
+	}
+
+	switch {
+	case input.Reference != nil:
+		result.Reference = &wpsReference{
+			MimeType: input.Reference.MimeType,
+			Href:     input.Reference.Href,
+			Method:   input.Reference.Method,
+		}
+		if input.Reference.Body != "" {
+			result.Reference.Body = &wpsBody{input.Reference.Body}
+		}
+	case input.Complex != nil:
+		result.Data = &wpsData{
+			ComplexData: &wpsComplexData{
+				MimeType: input.Complex.MimeType,
+				Value:    input.Complex.Value,
+			},
+		}
+	default:
+		result.Data = &wpsData{
+			LiteralData: &wpsLiteralData{input.Literal},
+		}
+	}
+
+	return result
+}
+
+// wpsCapabilities exists in order to parse the XML returned by Geoserver for the WPS capabilities
+type wpsCapabilities struct {
+	Processes []*wpsProcessBrief `xml:"ProcessOfferings>Process"`
+}
+
+// wpsProcessBrief is the brief description of a process
+type wpsProcessBrief struct {
+	Version    string `xml:"processVersion,attr"`
+	Identifier string `xml:"Identifier"`
+	Title      string `xml:"Title"`
+	Abstract   string `xml:"Abstract"`
+}
+
+// toProcessSummary converts a wpsProcessBrief into a ProcessSummary
+func (brief *wpsProcessBrief) toProcessSummary() *ProcessSummary {
+	return &ProcessSummary{
+		Identifier: brief.Identifier,
+		Title:      brief.Title,
+		Abstract:   brief.Abstract,
+		Version:    brief.Version,
+	}
+}
+
+// wpsProcessDescriptions exists in order to parse the XML returned by Geoserver when describing a process
+type wpsProcessDescriptions struct {
+	Descriptions []*wpsProcessDescription `xml:"ProcessDescription"`
+}
+
+// wpsProcessDescription is the description of a process
+type wpsProcessDescription struct {
+	StatusSupported bool                       `xml:"statusSupported,attr"`
+	StoreSupported  bool                       `xml:"storeSupported,attr"`
+	Identifier      string                     `xml:"Identifier"`
+	Title           string                     `xml:"Title"`
+	Abstract        string                     `xml:"Abstract"`
+	Inputs          []*wpsParameterDescription `xml:"DataInputs>Input"`
+	Outputs         []*wpsParameterDescription `xml:"ProcessOutputs>Output"`
+}
+
+// wpsParameterDescription is the description of a process input or output
+type wpsParameterDescription struct {
+	MinOccurs         string                 `xml:"minOccurs,attr"`
+	MaxOccurs         string                 `xml:"maxOccurs,attr"`
+	Identifier        string                 `xml:"Identifier"`
+	Title             string                 `xml:"Title"`
+	Abstract          string                 `xml:"Abstract"`
+	LiteralData       *wpsLiteralDescription `xml:"LiteralData"`
+	LiteralOutput     *wpsLiteralDescription `xml:"LiteralOutput"`
+	ComplexData       *wpsComplexDescription `xml:"ComplexData"`
+	ComplexOutput     *wpsComplexDescription `xml:"ComplexOutput"`
+	BoundingBoxData   *struct{}              `xml:"BoundingBoxData"`
+	BoundingBoxOutput *struct{}              `xml:"BoundingBoxOutput"`
+}
+
+// wpsLiteralDescription is the description of a literal parameter
+type wpsLiteralDescription struct {
+	DataType      string   `xml:"DataType"`
+	AllowedValues []string `xml:"AllowedValues>Value"`
+	DefaultValue  string   `xml:"DefaultValue"`
+}
+
+// wpsComplexDescription is the description of a complex parameter
+type wpsComplexDescription struct {
+	DefaultMimeType    string   `xml:"Default>Format>MimeType"`
+	SupportedMimeTypes []string `xml:"Supported>Format>MimeType"`
+}
+
+// toProcessDescription converts a wpsProcessDescription into a ProcessDescription
+func (description *wpsProcessDescription) toProcessDescription() *ProcessDescription {
+	result := &ProcessDescription{
+		Identifier:      description.Identifier,
+		Title:           description.Title,
+		Abstract:        description.Abstract,
+		StatusSupported: description.StatusSupported,
+		StoreSupported:  description.StoreSupported,
+		Inputs:          make([]*ProcessParameter, 0),
+		Outputs:         make([]*ProcessParameter, 0),
+	}
+
+	for _, input := range description.Inputs {
+		result.Inputs = append(result.Inputs, input.toProcessParameter())
+	}
+	for _, output := range description.Outputs {
+		result.Outputs = append(result.Outputs, output.toProcessParameter())
+	}
+
+	return result
+}
+
+// toProcessParameter converts a wpsParameterDescription into a ProcessParameter
+func (description *wpsParameterDescription) toProcessParameter() *ProcessParameter {
+	result := &ProcessParameter{
+		Identifier: description.Identifier,
+		Title:      description.Title,
+		Abstract:   description.Abstract,
+		MinOccurs:  parseOccurs(description.MinOccurs, 1),
+		MaxOccurs:  parseOccurs(description.MaxOccurs, 1),
+	}
+
+	literal := description.LiteralData
+	if literal == nil {
+		literal = description.LiteralOutput
+	}
+	complexData := description.ComplexData
+	if complexData == nil {
+		complexData = description.ComplexOutput
+	}
+
+	switch {
+	case literal != nil:
+		result.Kind = ProcessParameterLiteral
+		result.DataType = literal.DataType
+		result.AllowedValues = literal.AllowedValues
+		result.DefaultValue = literal.DefaultValue
+	case complexData != nil:
+		result.Kind = ProcessParameterComplex
+		result.DefaultMimeType = complexData.DefaultMimeType
+		result.MimeTypes = complexData.SupportedMimeTypes
+	case description.BoundingBoxData != nil || description.BoundingBoxOutput != nil:
+		result.Kind = ProcessParameterBoundingBox
+	}
+
+	return result
+}
+
+// parseOccurs parses a minOccurs or maxOccurs attribute, using the default when the attribute is absent
+func parseOccurs(occurs string, defaultOccurs int) int {
+	if occurs == "" {
+		return defaultOccurs
+	}
+	if occurs == "unbounded" {
+		return UnboundedOccurs
+	}
+	value, err := strconv.Atoi(occurs)
+	if err != nil {
+		return UnboundedOccurs
+	}
+	return value
+}
+
+// wpsExecuteResponse exists in order to parse the XML returned by Geoserver when executing a process
+type wpsExecuteResponse struct {
+	StatusLocation string           `xml:"statusLocation,attr"`
+	Status         *wpsStatus       `xml:"Status"`
+	Outputs        []*wpsOutputData `xml:"ProcessOutputs>Output"`
+}
+
+// wpsStatus is the status of an execution, only one of the fields is populated
+type wpsStatus struct {
+	Accepted  *wpsProgress `xml:"ProcessAccepted"`
+	Started   *wpsProgress `xml:"ProcessStarted"`
+	Paused    *wpsProgress `xml:"ProcessPaused"`
+	Succeeded *wpsProgress `xml:"ProcessSucceeded"`
+	Failed    *wpsFailure  `xml:"ProcessFailed"`
+}
+
+// wpsProgress is the progress of an execution
+type wpsProgress struct {
+	PercentCompleted int    `xml:"percentCompleted,attr"`
+	Message          string `xml:",chardata"`
+}
+
+// wpsFailure is the reason an execution failed
+type wpsFailure struct {
+	ExceptionReport *owsExceptionReport `xml:"ExceptionReport"`
+}
+
+// wpsOutputData is an output of an execution
+type wpsOutputData struct {
+	Identifier  string              `xml:"Identifier"`
+	Title       string              `xml:"Title"`
+	LiteralData *string             `xml:"Data>LiteralData"`
+	ComplexData *wpsComplexValue    `xml:"Data>ComplexData"`
+	Reference   *wpsOutputReference `xml:"Reference"`
+}
+
+// wpsComplexValue is the value of a complex output
+type wpsComplexValue struct {
+	MimeType string `xml:"mimeType,attr"`
+	Value    string `xml:",innerxml"`
+}
+
+// wpsOutputReference is a reference to an output stored by Geoserver
+type wpsOutputReference struct {
+	Href string `xml:"href,attr"`
+}
+
+// owsExceptionReport is an OWS exception report, returned by Geoserver when an OWS request fails
+type owsExceptionReport struct {
+	XMLName    xml.Name        `xml:"ExceptionReport"`
+	Exceptions []*owsException `xml:"Exception"`
+}
+
+// owsException is an exception within an OWS exception report
+type owsException struct {
+	Code    string   `xml:"exceptionCode,attr"`
+	Locator string   `xml:"locator,attr"`
+	Texts   []string `xml:"ExceptionText"`
+}
+
+// Error returns the exception messages of the report
+func (report *owsExceptionReport) Error() string {
+	messages := make([]string, 0)
+	for _, exception := range report.Exceptions {
+		message := strings.TrimSpace(strings.Join(exception.Texts, " "))
+		if exception.Code != "" {
+			message = exception.Code + ": " + message
+		}
+		messages = append(messages, message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// wpsExceptionFromResponse returns the exception report in the response as an error, or nil if there isn't one
+func wpsExceptionFromResponse(responseBody []byte) error {
+	if !bytes.Contains(responseBody, []byte("ExceptionReport")) {
+		return nil
+	}
+
+	report := &owsExceptionReport{}
+	if xml.Unmarshal(responseBody, report) != nil {
+		return nil
+	}
+	return fmt.Errorf("geoserver reported an error: %s", report.Error())
+}
+
+// parseExecuteResponse parses an execute response document into an ExecuteResponse
+func parseExecuteResponse(responseBody []byte) (response *ExecuteResponse, err error) {
+	restResponse := &wpsExecuteResponse{}
+	err = xml.Unmarshal(responseBody, restResponse)
+	if err != nil {
+		return
+	}
+
+	response = &ExecuteResponse{
+		ExecutionID:    executionIDFromStatusLocation(restResponse.StatusLocation),
+		StatusLocation: restResponse.StatusLocation,
+		Outputs:        make([]*ProcessOutput, 0),
+	}
+
+	if status := restResponse.Status; status != nil {
+		var progress *wpsProgress
+		switch {
+		case status.Accepted != nil:
+			response.Status, progress = ExecutionAccepted, status.Accepted
+		case status.Started != nil:
+			response.Status, progress = ExecutionStarted, status.Started
+		case status.Paused != nil:
+			response.Status, progress = ExecutionPaused, status.Paused
+		case status.Succeeded != nil:
+			response.Status, progress = ExecutionSucceeded, status.Succeeded
+			progress.PercentCompleted = 100
+		case status.Failed != nil:
+			response.Status = ExecutionFailed
+			if status.Failed.ExceptionReport != nil {
+				response.FailureMessage = status.Failed.ExceptionReport.Error()
+			}
+		}
+		if progress != nil {
+			response.PercentCompleted = progress.PercentCompleted
+			response.StatusMessage = strings.TrimSpace(progress.Message)
+		}
+	}
+
+	for _, output := range restResponse.Outputs {
+		response.Outputs = append(response.Outputs, output.toProcessOutput())
+	}
+
+	return
+}
+
+// toProcessOutput converts a wpsOutputData into a ProcessOutput
+func (output *wpsOutputData) toProcessOutput() *ProcessOutput {
+	result := &ProcessOutput{
+		Identifier: output.Identifier,
+		Title:      output.Title,
+	}
+
+	if output.LiteralData != nil {
+		result.Literal = *output.LiteralData
+	}
+	if output.ComplexData != nil {
+		result.Complex = &ComplexData{
+			MimeType: output.ComplexData.MimeType,
+			Value:    stripCDATA(output.ComplexData.Value),
+		}
+	}
+	if output.Reference != nil {
+		result.Reference = output.Reference.Href
+	}
+
+	return result
+}
+
+// stripCDATA removes the CDATA section wrapping a value, if there is one
+func stripCDATA(value string) string {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "<![CDATA[") && strings.HasSuffix(trimmed, "]]>") {
+		return trimmed[len("<![CDATA[") : len(trimmed)-len("]]>")]
+	}
+	return value
+}
+
+// executionIDFromStatusLocation extracts the execution ID from the status location of an asynchronous execution
+func executionIDFromStatusLocation(statusLocation string) string {
+	if statusLocation == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(statusLocation)
+	if err != nil {
+		return ""
+	}
+	return parsed.Query().Get("executionId")
+}
@@ -0,0 +1,231 @@
+package geoserver
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewWPSExecuteEncodesInputsAndRawOutput(t *testing.T) {
+	request := &ExecuteRequest{
+		Process: "JTS:buffer",
+		Inputs: []*ProcessInput{
+			NewComplexInput("geom", MimeTypeWKT, "POINT(0 0)"),
+			NewLiteralInput("distance", "10"),
+			NewReferenceInput("features", MimeTypeGML3, "http://localhost/wfs?request=GetFeature"),
+		},
+		Output: &ProcessOutputRequest{
+			Identifier: "result",
+			MimeType:   MimeTypeGeoJSON,
+		},
+	}
+
+	executeXML, err := xml.Marshal(newWPSExecute(request))
+	assert.NoError(t, err)
+
+	assert.Contains(t, string(executeXML), `<wps:Execute service="WPS" version="1.0.0"`)
+	assert.Contains(t, string(executeXML), `<ows:Identifier>JTS:buffer</ows:Identifier>`)
+	assert.Contains(t, string(executeXML), `<wps:ComplexData mimeType="application/wkt"><![CDATA[POINT(0 0)]]></wps:ComplexData>`)
+	assert.Contains(t, string(executeXML), `<wps:LiteralData>10</wps:LiteralData>`)
+	assert.Contains(t, string(executeXML), `xlink:href="http://localhost/wfs?request=GetFeature"`)
+	assert.Contains(t, string(executeXML), `<wps:RawDataOutput mimeType="application/json"><ows:Identifier>result</ows:Identifier></wps:RawDataOutput>`)
+}
+
+func TestNewWPSExecuteRequestsAStoredResponseDocumentWhenAsync(t *testing.T) {
+	request := &ExecuteRequest{
+		Process: "JTS:buffer",
+		Async:   true,
+	}
+
+	executeXML, err := xml.Marshal(newWPSExecute(request))
+	assert.NoError(t, err)
+
+	assert.Contains(t, string(executeXML), `<wps:ResponseDocument storeExecuteResponse="true" status="true">`)
+	assert.NotContains(t, string(executeXML), "RawDataOutput")
+}
+
+func TestParseExecuteResponseReadsStatusAndExecutionID(t *testing.T) {
+	responseXML := `<?xml version="1.0" encoding="UTF-8"?>
+<wps:ExecuteResponse xmlns:wps="http://www.opengis.net/wps/1.0.0" xmlns:ows="http://www.opengis.net/ows/1.1"
+    statusLocation="http://localhost/geoserver/ows?service=WPS&amp;version=1.0.0&amp;request=GetExecutionStatus&amp;executionId=a1b2c3">
+  <wps:Status creationTime="2018-01-01T00:00:00.000Z">
+    <wps:ProcessStarted percentCompleted="42">Buffering</wps:ProcessStarted>
+  </wps:Status>
+</wps:ExecuteResponse>`
+
+	response, err := parseExecuteResponse([]byte(responseXML))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "a1b2c3", response.ExecutionID)
+	assert.Equal(t, ExecutionStarted, response.Status)
+	assert.Equal(t, 42, response.PercentCompleted)
+	assert.Equal(t, "Buffering", response.StatusMessage)
+	assert.False(t, response.IsComplete())
+}
+
+func TestParseExecuteResponseReadsOutputsAndFailures(t *testing.T) {
+	responseXML := `<wps:ExecuteResponse xmlns:wps="http://www.opengis.net/wps/1.0.0" xmlns:ows="http://www.opengis.net/ows/1.1">
+  <wps:Status><wps:ProcessSucceeded>Done</wps:ProcessSucceeded></wps:Status>
+  <wps:ProcessOutputs>
+    <wps:Output>
+      <ows:Identifier>result</ows:Identifier>
+      <wps:Data><wps:ComplexData mimeType="application/wkt"><![CDATA[POLYGON((0 0, 1 0, 1 1, 0 0))]]></wps:ComplexData></wps:Data>
+    </wps:Output>
+  </wps:ProcessOutputs>
+</wps:ExecuteResponse>`
+
+	response, err := parseExecuteResponse([]byte(responseXML))
+	assert.NoError(t, err)
+	assert.Equal(t, ExecutionSucceeded, response.Status)
+	assert.Equal(t, 1, len(response.Outputs))
+	assert.Equal(t, "POLYGON((0 0, 1 0, 1 1, 0 0))", response.Outputs[0].Complex.Value)
+
+	failedXML := `<wps:ExecuteResponse xmlns:wps="http://www.opengis.net/wps/1.0.0" xmlns:ows="http://www.opengis.net/ows/1.1">
+  <wps:Status><wps:ProcessFailed><ows:ExceptionReport><ows:Exception exceptionCode="NoApplicableCode"><ows:ExceptionText>Process dismissed</ows:ExceptionText></ows:Exception></ows:ExceptionReport></wps:ProcessFailed></wps:Status>
+</wps:ExecuteResponse>`
+
+	response, err = parseExecuteResponse([]byte(failedXML))
+	assert.NoError(t, err)
+	assert.Equal(t, ExecutionFailed, response.Status)
+	assert.Equal(t, "NoApplicableCode: Process dismissed", response.FailureMessage)
+}
+
+func TestProcessDescriptionsAreConvertedFromDescribeProcess(t *testing.T) {
+	describeProcessXML := `<?xml version="1.0" encoding="UTF-8"?>
+<wps:ProcessDescriptions xmlns:wps="http://www.opengis.net/wps/1.0.0" xmlns:ows="http://www.opengis.net/ows/1.1"
+    service="WPS" version="1.0.0" xml:lang="en">
+  <ProcessDescription wps:processVersion="1.0.0" statusSupported="true" storeSupported="true">
+    <ows:Identifier>JTS:buffer</ows:Identifier>
+    <ows:Title>Buffer</ows:Title>
+    <ows:Abstract>Returns a polygonal geometry representing the input geometry enlarged by a given distance.</ows:Abstract>
+    <DataInputs>
+      <Input maxOccurs="1" minOccurs="1">
+        <ows:Identifier>geom</ows:Identifier>
+        <ows:Title>geom</ows:Title>
+        <ows:Abstract>Input geometry</ows:Abstract>
+        <ComplexData>
+          <Default><Format><MimeType>text/xml; subtype=gml/3.1.1</MimeType></Format></Default>
+          <Supported>
+            <Format><MimeType>text/xml; subtype=gml/3.1.1</MimeType></Format>
+            <Format><MimeType>application/wkt</MimeType></Format>
+          </Supported>
+        </ComplexData>
+      </Input>
+      <Input maxOccurs="1" minOccurs="1">
+        <ows:Identifier>distance</ows:Identifier>
+        <ows:Title>distance</ows:Title>
+        <LiteralData>
+          <ows:DataType>xs:double</ows:DataType>
+          <ows:AnyValue/>
+        </LiteralData>
+      </Input>
+      <Input maxOccurs="1" minOccurs="0">
+        <ows:Identifier>capStyle</ows:Identifier>
+        <ows:Title>capStyle</ows:Title>
+        <LiteralData>
+          <ows:AllowedValues>
+            <ows:Value>Round</ows:Value>
+            <ows:Value>Flat</ows:Value>
+            <ows:Value>Square</ows:Value>
+          </ows:AllowedValues>
+          <DefaultValue>Round</DefaultValue>
+        </LiteralData>
+      </Input>
+    </DataInputs>
+    <ProcessOutputs>
+      <Output>
+        <ows:Identifier>result</ows:Identifier>
+        <ows:Title>result</ows:Title>
+        <ComplexOutput>
+          <Default><Format><MimeType>text/xml; subtype=gml/3.1.1</MimeType></Format></Default>
+          <Supported><Format><MimeType>application/wkt</MimeType></Format></Supported>
+        </ComplexOutput>
+      </Output>
+    </ProcessOutputs>
+  </ProcessDescription>
+  <ProcessDescription wps:processVersion="1.0.0" statusSupported="true" storeSupported="true">
+    <ows:Identifier>gs:Bounds</ows:Identifier>
+    <ows:Title>Bounds</ows:Title>
+    <DataInputs>
+      <Input maxOccurs="unbounded" minOccurs="1">
+        <ows:Identifier>features</ows:Identifier>
+        <ows:Title>features</ows:Title>
+        <ComplexData>
+          <Default><Format><MimeType>text/xml; subtype=wfs-collection/1.0</MimeType></Format></Default>
+        </ComplexData>
+      </Input>
+    </DataInputs>
+    <ProcessOutputs>
+      <Output>
+        <ows:Identifier>bounds</ows:Identifier>
+        <ows:Title>bounds</ows:Title>
+        <BoundingBoxOutput>
+          <Default><CRS>EPSG:4326</CRS></Default>
+        </BoundingBoxOutput>
+      </Output>
+    </ProcessOutputs>
+  </ProcessDescription>
+</wps:ProcessDescriptions>`
+
+	descriptions := &wpsProcessDescriptions{}
+	assert.NoError(t, xml.Unmarshal([]byte(describeProcessXML), descriptions))
+	assert.Equal(t, 2, len(descriptions.Descriptions))
+
+	buffer := descriptions.Descriptions[0].toProcessDescription()
+	assert.Equal(t, "JTS:buffer", buffer.Identifier)
+	assert.Equal(t, "Buffer", buffer.Title)
+	assert.True(t, buffer.StatusSupported)
+	assert.True(t, buffer.StoreSupported)
+	assert.Equal(t, []*ProcessParameter{
+		{
+			Identifier:      "geom",
+			Title:           "geom",
+			Abstract:        "Input geometry",
+			MinOccurs:       1,
+			MaxOccurs:       1,
+			Kind:            ProcessParameterComplex,
+			DefaultMimeType: MimeTypeGML3,
+			MimeTypes:       []string{MimeTypeGML3, "application/wkt"},
+		},
+		{
+			Identifier: "distance",
+			Title:      "distance",
+			MinOccurs:  1,
+			MaxOccurs:  1,
+			Kind:       ProcessParameterLiteral,
+			DataType:   "xs:double",
+		},
+		{
+			Identifier:    "capStyle",
+			Title:         "capStyle",
+			MinOccurs:     0,
+			MaxOccurs:     1,
+			Kind:          ProcessParameterLiteral,
+			AllowedValues: []string{"Round", "Flat", "Square"},
+			DefaultValue:  "Round",
+		},
+	}, buffer.Inputs)
+	assert.Equal(t, []*ProcessParameter{
+		{
+			Identifier:      "result",
+			Title:           "result",
+			MinOccurs:       1,
+			MaxOccurs:       1,
+			Kind:            ProcessParameterComplex,
+			DefaultMimeType: MimeTypeGML3,
+			MimeTypes:       []string{"application/wkt"},
+		},
+	}, buffer.Outputs)
+
+	bounds := descriptions.Descriptions[1].toProcessDescription()
+	assert.Equal(t, UnboundedOccurs, bounds.Inputs[0].MaxOccurs)
+	assert.Equal(t, ProcessParameterComplex, bounds.Inputs[0].Kind)
+	assert.Nil(t, bounds.Inputs[0].MimeTypes)
+	assert.Equal(t, &ProcessParameter{
+		Identifier: "bounds",
+		Title:      "bounds",
+		MinOccurs:  1,
+		MaxOccurs:  1,
+		Kind:       ProcessParameterBoundingBox,
+	}, bounds.Outputs[0])
+}